@@ -27,7 +27,8 @@ import (
 )
 
 func Serve(transport string) *server.MCPServer {
-	mcpServerOpts := []server.ServerOption{}
+	// Logging lets tools such as tail_logs stream lines as log notifications.
+	mcpServerOpts := []server.ServerOption{server.WithLogging()}
 	if hooks := logging.NewHooks(); hooks != nil {
 		mcpServerOpts = append(mcpServerOpts, server.WithHooks(hooks))
 	}
@@ -83,3 +83,17 @@ func (p *ListSecretFilesForServiceParams) SetCursor(c *Cursor) {
 func (p *ListSecretFilesForServiceParams) SetLimit(l int) {
 	p.Limit = &l
 }
+
+func (p *ListKeyValueParams) SetCursor(c *Cursor) {
+	p.Cursor = c
+}
+func (p *ListKeyValueParams) SetLimit(l int) {
+	p.Limit = &l
+}
+
+func (p *ListRedisParams) SetCursor(c *Cursor) {
+	p.Cursor = c
+}
+func (p *ListRedisParams) SetLimit(l int) {
+	p.Limit = &l
+}
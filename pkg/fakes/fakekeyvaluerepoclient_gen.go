@@ -24,6 +24,36 @@ type FakeKeyValueRepoClient struct {
 		result1 *client.CreateKeyValueResponse
 		result2 error
 	}
+	DeleteKeyValueWithResponseStub        func(context.Context, string, ...client.RequestEditorFn) (*client.DeleteKeyValueResponse, error)
+	deleteKeyValueWithResponseMutex       sync.RWMutex
+	deleteKeyValueWithResponseArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []client.RequestEditorFn
+	}
+	deleteKeyValueWithResponseReturns struct {
+		result1 *client.DeleteKeyValueResponse
+		result2 error
+	}
+	deleteKeyValueWithResponseReturnsOnCall map[int]struct {
+		result1 *client.DeleteKeyValueResponse
+		result2 error
+	}
+	DeleteRedisWithResponseStub        func(context.Context, string, ...client.RequestEditorFn) (*client.DeleteRedisResponse, error)
+	deleteRedisWithResponseMutex       sync.RWMutex
+	deleteRedisWithResponseArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []client.RequestEditorFn
+	}
+	deleteRedisWithResponseReturns struct {
+		result1 *client.DeleteRedisResponse
+		result2 error
+	}
+	deleteRedisWithResponseReturnsOnCall map[int]struct {
+		result1 *client.DeleteRedisResponse
+		result2 error
+	}
 	ListKeyValueWithResponseStub        func(context.Context, *client.ListKeyValueParams, ...client.RequestEditorFn) (*client.ListKeyValueResponse, error)
 	listKeyValueWithResponseMutex       sync.RWMutex
 	listKeyValueWithResponseArgsForCall []struct {
@@ -39,6 +69,36 @@ type FakeKeyValueRepoClient struct {
 		result1 *client.ListKeyValueResponse
 		result2 error
 	}
+	ListRedisWithResponseStub        func(context.Context, *client.ListRedisParams, ...client.RequestEditorFn) (*client.ListRedisResponse, error)
+	listRedisWithResponseMutex       sync.RWMutex
+	listRedisWithResponseArgsForCall []struct {
+		arg1 context.Context
+		arg2 *client.ListRedisParams
+		arg3 []client.RequestEditorFn
+	}
+	listRedisWithResponseReturns struct {
+		result1 *client.ListRedisResponse
+		result2 error
+	}
+	listRedisWithResponseReturnsOnCall map[int]struct {
+		result1 *client.ListRedisResponse
+		result2 error
+	}
+	ResumeKeyValueWithResponseStub        func(context.Context, string, ...client.RequestEditorFn) (*client.ResumeKeyValueResponse, error)
+	resumeKeyValueWithResponseMutex       sync.RWMutex
+	resumeKeyValueWithResponseArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []client.RequestEditorFn
+	}
+	resumeKeyValueWithResponseReturns struct {
+		result1 *client.ResumeKeyValueResponse
+		result2 error
+	}
+	resumeKeyValueWithResponseReturnsOnCall map[int]struct {
+		result1 *client.ResumeKeyValueResponse
+		result2 error
+	}
 	RetrieveKeyValueConnectionInfoWithResponseStub        func(context.Context, string, ...client.RequestEditorFn) (*client.RetrieveKeyValueConnectionInfoResponse, error)
 	retrieveKeyValueConnectionInfoWithResponseMutex       sync.RWMutex
 	retrieveKeyValueConnectionInfoWithResponseArgsForCall []struct {
@@ -69,6 +129,83 @@ type FakeKeyValueRepoClient struct {
 		result1 *client.RetrieveKeyValueResponse
 		result2 error
 	}
+	RetrieveRedisConnectionInfoWithResponseStub        func(context.Context, string, ...client.RequestEditorFn) (*client.RetrieveRedisConnectionInfoResponse, error)
+	retrieveRedisConnectionInfoWithResponseMutex       sync.RWMutex
+	retrieveRedisConnectionInfoWithResponseArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []client.RequestEditorFn
+	}
+	retrieveRedisConnectionInfoWithResponseReturns struct {
+		result1 *client.RetrieveRedisConnectionInfoResponse
+		result2 error
+	}
+	retrieveRedisConnectionInfoWithResponseReturnsOnCall map[int]struct {
+		result1 *client.RetrieveRedisConnectionInfoResponse
+		result2 error
+	}
+	RetrieveRedisWithResponseStub        func(context.Context, string, ...client.RequestEditorFn) (*client.RetrieveRedisResponse, error)
+	retrieveRedisWithResponseMutex       sync.RWMutex
+	retrieveRedisWithResponseArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []client.RequestEditorFn
+	}
+	retrieveRedisWithResponseReturns struct {
+		result1 *client.RetrieveRedisResponse
+		result2 error
+	}
+	retrieveRedisWithResponseReturnsOnCall map[int]struct {
+		result1 *client.RetrieveRedisResponse
+		result2 error
+	}
+	SuspendKeyValueWithResponseStub        func(context.Context, string, ...client.RequestEditorFn) (*client.SuspendKeyValueResponse, error)
+	suspendKeyValueWithResponseMutex       sync.RWMutex
+	suspendKeyValueWithResponseArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []client.RequestEditorFn
+	}
+	suspendKeyValueWithResponseReturns struct {
+		result1 *client.SuspendKeyValueResponse
+		result2 error
+	}
+	suspendKeyValueWithResponseReturnsOnCall map[int]struct {
+		result1 *client.SuspendKeyValueResponse
+		result2 error
+	}
+	UpdateKeyValueWithResponseStub        func(context.Context, string, client.KeyValuePATCHInput, ...client.RequestEditorFn) (*client.UpdateKeyValueResponse, error)
+	updateKeyValueWithResponseMutex       sync.RWMutex
+	updateKeyValueWithResponseArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 client.KeyValuePATCHInput
+		arg4 []client.RequestEditorFn
+	}
+	updateKeyValueWithResponseReturns struct {
+		result1 *client.UpdateKeyValueResponse
+		result2 error
+	}
+	updateKeyValueWithResponseReturnsOnCall map[int]struct {
+		result1 *client.UpdateKeyValueResponse
+		result2 error
+	}
+	UpdateRedisWithResponseStub        func(context.Context, string, client.RedisPATCHInput, ...client.RequestEditorFn) (*client.UpdateRedisResponse, error)
+	updateRedisWithResponseMutex       sync.RWMutex
+	updateRedisWithResponseArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 client.RedisPATCHInput
+		arg4 []client.RequestEditorFn
+	}
+	updateRedisWithResponseReturns struct {
+		result1 *client.UpdateRedisResponse
+		result2 error
+	}
+	updateRedisWithResponseReturnsOnCall map[int]struct {
+		result1 *client.UpdateRedisResponse
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -139,6 +276,138 @@ func (fake *FakeKeyValueRepoClient) CreateKeyValueWithResponseReturnsOnCall(i in
 	}{result1, result2}
 }
 
+func (fake *FakeKeyValueRepoClient) DeleteKeyValueWithResponse(arg1 context.Context, arg2 string, arg3 ...client.RequestEditorFn) (*client.DeleteKeyValueResponse, error) {
+	fake.deleteKeyValueWithResponseMutex.Lock()
+	ret, specificReturn := fake.deleteKeyValueWithResponseReturnsOnCall[len(fake.deleteKeyValueWithResponseArgsForCall)]
+	fake.deleteKeyValueWithResponseArgsForCall = append(fake.deleteKeyValueWithResponseArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []client.RequestEditorFn
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteKeyValueWithResponseStub
+	fakeReturns := fake.deleteKeyValueWithResponseReturns
+	fake.recordInvocation("DeleteKeyValueWithResponse", []interface{}{arg1, arg2, arg3})
+	fake.deleteKeyValueWithResponseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeKeyValueRepoClient) DeleteKeyValueWithResponseCallCount() int {
+	fake.deleteKeyValueWithResponseMutex.RLock()
+	defer fake.deleteKeyValueWithResponseMutex.RUnlock()
+	return len(fake.deleteKeyValueWithResponseArgsForCall)
+}
+
+func (fake *FakeKeyValueRepoClient) DeleteKeyValueWithResponseCalls(stub func(context.Context, string, ...client.RequestEditorFn) (*client.DeleteKeyValueResponse, error)) {
+	fake.deleteKeyValueWithResponseMutex.Lock()
+	defer fake.deleteKeyValueWithResponseMutex.Unlock()
+	fake.DeleteKeyValueWithResponseStub = stub
+}
+
+func (fake *FakeKeyValueRepoClient) DeleteKeyValueWithResponseArgsForCall(i int) (context.Context, string, []client.RequestEditorFn) {
+	fake.deleteKeyValueWithResponseMutex.RLock()
+	defer fake.deleteKeyValueWithResponseMutex.RUnlock()
+	argsForCall := fake.deleteKeyValueWithResponseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeKeyValueRepoClient) DeleteKeyValueWithResponseReturns(result1 *client.DeleteKeyValueResponse, result2 error) {
+	fake.deleteKeyValueWithResponseMutex.Lock()
+	defer fake.deleteKeyValueWithResponseMutex.Unlock()
+	fake.DeleteKeyValueWithResponseStub = nil
+	fake.deleteKeyValueWithResponseReturns = struct {
+		result1 *client.DeleteKeyValueResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeKeyValueRepoClient) DeleteKeyValueWithResponseReturnsOnCall(i int, result1 *client.DeleteKeyValueResponse, result2 error) {
+	fake.deleteKeyValueWithResponseMutex.Lock()
+	defer fake.deleteKeyValueWithResponseMutex.Unlock()
+	fake.DeleteKeyValueWithResponseStub = nil
+	if fake.deleteKeyValueWithResponseReturnsOnCall == nil {
+		fake.deleteKeyValueWithResponseReturnsOnCall = make(map[int]struct {
+			result1 *client.DeleteKeyValueResponse
+			result2 error
+		})
+	}
+	fake.deleteKeyValueWithResponseReturnsOnCall[i] = struct {
+		result1 *client.DeleteKeyValueResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeKeyValueRepoClient) DeleteRedisWithResponse(arg1 context.Context, arg2 string, arg3 ...client.RequestEditorFn) (*client.DeleteRedisResponse, error) {
+	fake.deleteRedisWithResponseMutex.Lock()
+	ret, specificReturn := fake.deleteRedisWithResponseReturnsOnCall[len(fake.deleteRedisWithResponseArgsForCall)]
+	fake.deleteRedisWithResponseArgsForCall = append(fake.deleteRedisWithResponseArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []client.RequestEditorFn
+	}{arg1, arg2, arg3})
+	stub := fake.DeleteRedisWithResponseStub
+	fakeReturns := fake.deleteRedisWithResponseReturns
+	fake.recordInvocation("DeleteRedisWithResponse", []interface{}{arg1, arg2, arg3})
+	fake.deleteRedisWithResponseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeKeyValueRepoClient) DeleteRedisWithResponseCallCount() int {
+	fake.deleteRedisWithResponseMutex.RLock()
+	defer fake.deleteRedisWithResponseMutex.RUnlock()
+	return len(fake.deleteRedisWithResponseArgsForCall)
+}
+
+func (fake *FakeKeyValueRepoClient) DeleteRedisWithResponseCalls(stub func(context.Context, string, ...client.RequestEditorFn) (*client.DeleteRedisResponse, error)) {
+	fake.deleteRedisWithResponseMutex.Lock()
+	defer fake.deleteRedisWithResponseMutex.Unlock()
+	fake.DeleteRedisWithResponseStub = stub
+}
+
+func (fake *FakeKeyValueRepoClient) DeleteRedisWithResponseArgsForCall(i int) (context.Context, string, []client.RequestEditorFn) {
+	fake.deleteRedisWithResponseMutex.RLock()
+	defer fake.deleteRedisWithResponseMutex.RUnlock()
+	argsForCall := fake.deleteRedisWithResponseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeKeyValueRepoClient) DeleteRedisWithResponseReturns(result1 *client.DeleteRedisResponse, result2 error) {
+	fake.deleteRedisWithResponseMutex.Lock()
+	defer fake.deleteRedisWithResponseMutex.Unlock()
+	fake.DeleteRedisWithResponseStub = nil
+	fake.deleteRedisWithResponseReturns = struct {
+		result1 *client.DeleteRedisResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeKeyValueRepoClient) DeleteRedisWithResponseReturnsOnCall(i int, result1 *client.DeleteRedisResponse, result2 error) {
+	fake.deleteRedisWithResponseMutex.Lock()
+	defer fake.deleteRedisWithResponseMutex.Unlock()
+	fake.DeleteRedisWithResponseStub = nil
+	if fake.deleteRedisWithResponseReturnsOnCall == nil {
+		fake.deleteRedisWithResponseReturnsOnCall = make(map[int]struct {
+			result1 *client.DeleteRedisResponse
+			result2 error
+		})
+	}
+	fake.deleteRedisWithResponseReturnsOnCall[i] = struct {
+		result1 *client.DeleteRedisResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeKeyValueRepoClient) ListKeyValueWithResponse(arg1 context.Context, arg2 *client.ListKeyValueParams, arg3 ...client.RequestEditorFn) (*client.ListKeyValueResponse, error) {
 	fake.listKeyValueWithResponseMutex.Lock()
 	ret, specificReturn := fake.listKeyValueWithResponseReturnsOnCall[len(fake.listKeyValueWithResponseArgsForCall)]
@@ -205,6 +474,138 @@ func (fake *FakeKeyValueRepoClient) ListKeyValueWithResponseReturnsOnCall(i int,
 	}{result1, result2}
 }
 
+func (fake *FakeKeyValueRepoClient) ListRedisWithResponse(arg1 context.Context, arg2 *client.ListRedisParams, arg3 ...client.RequestEditorFn) (*client.ListRedisResponse, error) {
+	fake.listRedisWithResponseMutex.Lock()
+	ret, specificReturn := fake.listRedisWithResponseReturnsOnCall[len(fake.listRedisWithResponseArgsForCall)]
+	fake.listRedisWithResponseArgsForCall = append(fake.listRedisWithResponseArgsForCall, struct {
+		arg1 context.Context
+		arg2 *client.ListRedisParams
+		arg3 []client.RequestEditorFn
+	}{arg1, arg2, arg3})
+	stub := fake.ListRedisWithResponseStub
+	fakeReturns := fake.listRedisWithResponseReturns
+	fake.recordInvocation("ListRedisWithResponse", []interface{}{arg1, arg2, arg3})
+	fake.listRedisWithResponseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeKeyValueRepoClient) ListRedisWithResponseCallCount() int {
+	fake.listRedisWithResponseMutex.RLock()
+	defer fake.listRedisWithResponseMutex.RUnlock()
+	return len(fake.listRedisWithResponseArgsForCall)
+}
+
+func (fake *FakeKeyValueRepoClient) ListRedisWithResponseCalls(stub func(context.Context, *client.ListRedisParams, ...client.RequestEditorFn) (*client.ListRedisResponse, error)) {
+	fake.listRedisWithResponseMutex.Lock()
+	defer fake.listRedisWithResponseMutex.Unlock()
+	fake.ListRedisWithResponseStub = stub
+}
+
+func (fake *FakeKeyValueRepoClient) ListRedisWithResponseArgsForCall(i int) (context.Context, *client.ListRedisParams, []client.RequestEditorFn) {
+	fake.listRedisWithResponseMutex.RLock()
+	defer fake.listRedisWithResponseMutex.RUnlock()
+	argsForCall := fake.listRedisWithResponseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeKeyValueRepoClient) ListRedisWithResponseReturns(result1 *client.ListRedisResponse, result2 error) {
+	fake.listRedisWithResponseMutex.Lock()
+	defer fake.listRedisWithResponseMutex.Unlock()
+	fake.ListRedisWithResponseStub = nil
+	fake.listRedisWithResponseReturns = struct {
+		result1 *client.ListRedisResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeKeyValueRepoClient) ListRedisWithResponseReturnsOnCall(i int, result1 *client.ListRedisResponse, result2 error) {
+	fake.listRedisWithResponseMutex.Lock()
+	defer fake.listRedisWithResponseMutex.Unlock()
+	fake.ListRedisWithResponseStub = nil
+	if fake.listRedisWithResponseReturnsOnCall == nil {
+		fake.listRedisWithResponseReturnsOnCall = make(map[int]struct {
+			result1 *client.ListRedisResponse
+			result2 error
+		})
+	}
+	fake.listRedisWithResponseReturnsOnCall[i] = struct {
+		result1 *client.ListRedisResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeKeyValueRepoClient) ResumeKeyValueWithResponse(arg1 context.Context, arg2 string, arg3 ...client.RequestEditorFn) (*client.ResumeKeyValueResponse, error) {
+	fake.resumeKeyValueWithResponseMutex.Lock()
+	ret, specificReturn := fake.resumeKeyValueWithResponseReturnsOnCall[len(fake.resumeKeyValueWithResponseArgsForCall)]
+	fake.resumeKeyValueWithResponseArgsForCall = append(fake.resumeKeyValueWithResponseArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []client.RequestEditorFn
+	}{arg1, arg2, arg3})
+	stub := fake.ResumeKeyValueWithResponseStub
+	fakeReturns := fake.resumeKeyValueWithResponseReturns
+	fake.recordInvocation("ResumeKeyValueWithResponse", []interface{}{arg1, arg2, arg3})
+	fake.resumeKeyValueWithResponseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeKeyValueRepoClient) ResumeKeyValueWithResponseCallCount() int {
+	fake.resumeKeyValueWithResponseMutex.RLock()
+	defer fake.resumeKeyValueWithResponseMutex.RUnlock()
+	return len(fake.resumeKeyValueWithResponseArgsForCall)
+}
+
+func (fake *FakeKeyValueRepoClient) ResumeKeyValueWithResponseCalls(stub func(context.Context, string, ...client.RequestEditorFn) (*client.ResumeKeyValueResponse, error)) {
+	fake.resumeKeyValueWithResponseMutex.Lock()
+	defer fake.resumeKeyValueWithResponseMutex.Unlock()
+	fake.ResumeKeyValueWithResponseStub = stub
+}
+
+func (fake *FakeKeyValueRepoClient) ResumeKeyValueWithResponseArgsForCall(i int) (context.Context, string, []client.RequestEditorFn) {
+	fake.resumeKeyValueWithResponseMutex.RLock()
+	defer fake.resumeKeyValueWithResponseMutex.RUnlock()
+	argsForCall := fake.resumeKeyValueWithResponseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeKeyValueRepoClient) ResumeKeyValueWithResponseReturns(result1 *client.ResumeKeyValueResponse, result2 error) {
+	fake.resumeKeyValueWithResponseMutex.Lock()
+	defer fake.resumeKeyValueWithResponseMutex.Unlock()
+	fake.ResumeKeyValueWithResponseStub = nil
+	fake.resumeKeyValueWithResponseReturns = struct {
+		result1 *client.ResumeKeyValueResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeKeyValueRepoClient) ResumeKeyValueWithResponseReturnsOnCall(i int, result1 *client.ResumeKeyValueResponse, result2 error) {
+	fake.resumeKeyValueWithResponseMutex.Lock()
+	defer fake.resumeKeyValueWithResponseMutex.Unlock()
+	fake.ResumeKeyValueWithResponseStub = nil
+	if fake.resumeKeyValueWithResponseReturnsOnCall == nil {
+		fake.resumeKeyValueWithResponseReturnsOnCall = make(map[int]struct {
+			result1 *client.ResumeKeyValueResponse
+			result2 error
+		})
+	}
+	fake.resumeKeyValueWithResponseReturnsOnCall[i] = struct {
+		result1 *client.ResumeKeyValueResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeKeyValueRepoClient) RetrieveKeyValueConnectionInfoWithResponse(arg1 context.Context, arg2 string, arg3 ...client.RequestEditorFn) (*client.RetrieveKeyValueConnectionInfoResponse, error) {
 	fake.retrieveKeyValueConnectionInfoWithResponseMutex.Lock()
 	ret, specificReturn := fake.retrieveKeyValueConnectionInfoWithResponseReturnsOnCall[len(fake.retrieveKeyValueConnectionInfoWithResponseArgsForCall)]
@@ -337,17 +738,341 @@ func (fake *FakeKeyValueRepoClient) RetrieveKeyValueWithResponseReturnsOnCall(i
 	}{result1, result2}
 }
 
+func (fake *FakeKeyValueRepoClient) RetrieveRedisConnectionInfoWithResponse(arg1 context.Context, arg2 string, arg3 ...client.RequestEditorFn) (*client.RetrieveRedisConnectionInfoResponse, error) {
+	fake.retrieveRedisConnectionInfoWithResponseMutex.Lock()
+	ret, specificReturn := fake.retrieveRedisConnectionInfoWithResponseReturnsOnCall[len(fake.retrieveRedisConnectionInfoWithResponseArgsForCall)]
+	fake.retrieveRedisConnectionInfoWithResponseArgsForCall = append(fake.retrieveRedisConnectionInfoWithResponseArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []client.RequestEditorFn
+	}{arg1, arg2, arg3})
+	stub := fake.RetrieveRedisConnectionInfoWithResponseStub
+	fakeReturns := fake.retrieveRedisConnectionInfoWithResponseReturns
+	fake.recordInvocation("RetrieveRedisConnectionInfoWithResponse", []interface{}{arg1, arg2, arg3})
+	fake.retrieveRedisConnectionInfoWithResponseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeKeyValueRepoClient) RetrieveRedisConnectionInfoWithResponseCallCount() int {
+	fake.retrieveRedisConnectionInfoWithResponseMutex.RLock()
+	defer fake.retrieveRedisConnectionInfoWithResponseMutex.RUnlock()
+	return len(fake.retrieveRedisConnectionInfoWithResponseArgsForCall)
+}
+
+func (fake *FakeKeyValueRepoClient) RetrieveRedisConnectionInfoWithResponseCalls(stub func(context.Context, string, ...client.RequestEditorFn) (*client.RetrieveRedisConnectionInfoResponse, error)) {
+	fake.retrieveRedisConnectionInfoWithResponseMutex.Lock()
+	defer fake.retrieveRedisConnectionInfoWithResponseMutex.Unlock()
+	fake.RetrieveRedisConnectionInfoWithResponseStub = stub
+}
+
+func (fake *FakeKeyValueRepoClient) RetrieveRedisConnectionInfoWithResponseArgsForCall(i int) (context.Context, string, []client.RequestEditorFn) {
+	fake.retrieveRedisConnectionInfoWithResponseMutex.RLock()
+	defer fake.retrieveRedisConnectionInfoWithResponseMutex.RUnlock()
+	argsForCall := fake.retrieveRedisConnectionInfoWithResponseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeKeyValueRepoClient) RetrieveRedisConnectionInfoWithResponseReturns(result1 *client.RetrieveRedisConnectionInfoResponse, result2 error) {
+	fake.retrieveRedisConnectionInfoWithResponseMutex.Lock()
+	defer fake.retrieveRedisConnectionInfoWithResponseMutex.Unlock()
+	fake.RetrieveRedisConnectionInfoWithResponseStub = nil
+	fake.retrieveRedisConnectionInfoWithResponseReturns = struct {
+		result1 *client.RetrieveRedisConnectionInfoResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeKeyValueRepoClient) RetrieveRedisConnectionInfoWithResponseReturnsOnCall(i int, result1 *client.RetrieveRedisConnectionInfoResponse, result2 error) {
+	fake.retrieveRedisConnectionInfoWithResponseMutex.Lock()
+	defer fake.retrieveRedisConnectionInfoWithResponseMutex.Unlock()
+	fake.RetrieveRedisConnectionInfoWithResponseStub = nil
+	if fake.retrieveRedisConnectionInfoWithResponseReturnsOnCall == nil {
+		fake.retrieveRedisConnectionInfoWithResponseReturnsOnCall = make(map[int]struct {
+			result1 *client.RetrieveRedisConnectionInfoResponse
+			result2 error
+		})
+	}
+	fake.retrieveRedisConnectionInfoWithResponseReturnsOnCall[i] = struct {
+		result1 *client.RetrieveRedisConnectionInfoResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeKeyValueRepoClient) RetrieveRedisWithResponse(arg1 context.Context, arg2 string, arg3 ...client.RequestEditorFn) (*client.RetrieveRedisResponse, error) {
+	fake.retrieveRedisWithResponseMutex.Lock()
+	ret, specificReturn := fake.retrieveRedisWithResponseReturnsOnCall[len(fake.retrieveRedisWithResponseArgsForCall)]
+	fake.retrieveRedisWithResponseArgsForCall = append(fake.retrieveRedisWithResponseArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []client.RequestEditorFn
+	}{arg1, arg2, arg3})
+	stub := fake.RetrieveRedisWithResponseStub
+	fakeReturns := fake.retrieveRedisWithResponseReturns
+	fake.recordInvocation("RetrieveRedisWithResponse", []interface{}{arg1, arg2, arg3})
+	fake.retrieveRedisWithResponseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeKeyValueRepoClient) RetrieveRedisWithResponseCallCount() int {
+	fake.retrieveRedisWithResponseMutex.RLock()
+	defer fake.retrieveRedisWithResponseMutex.RUnlock()
+	return len(fake.retrieveRedisWithResponseArgsForCall)
+}
+
+func (fake *FakeKeyValueRepoClient) RetrieveRedisWithResponseCalls(stub func(context.Context, string, ...client.RequestEditorFn) (*client.RetrieveRedisResponse, error)) {
+	fake.retrieveRedisWithResponseMutex.Lock()
+	defer fake.retrieveRedisWithResponseMutex.Unlock()
+	fake.RetrieveRedisWithResponseStub = stub
+}
+
+func (fake *FakeKeyValueRepoClient) RetrieveRedisWithResponseArgsForCall(i int) (context.Context, string, []client.RequestEditorFn) {
+	fake.retrieveRedisWithResponseMutex.RLock()
+	defer fake.retrieveRedisWithResponseMutex.RUnlock()
+	argsForCall := fake.retrieveRedisWithResponseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeKeyValueRepoClient) RetrieveRedisWithResponseReturns(result1 *client.RetrieveRedisResponse, result2 error) {
+	fake.retrieveRedisWithResponseMutex.Lock()
+	defer fake.retrieveRedisWithResponseMutex.Unlock()
+	fake.RetrieveRedisWithResponseStub = nil
+	fake.retrieveRedisWithResponseReturns = struct {
+		result1 *client.RetrieveRedisResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeKeyValueRepoClient) RetrieveRedisWithResponseReturnsOnCall(i int, result1 *client.RetrieveRedisResponse, result2 error) {
+	fake.retrieveRedisWithResponseMutex.Lock()
+	defer fake.retrieveRedisWithResponseMutex.Unlock()
+	fake.RetrieveRedisWithResponseStub = nil
+	if fake.retrieveRedisWithResponseReturnsOnCall == nil {
+		fake.retrieveRedisWithResponseReturnsOnCall = make(map[int]struct {
+			result1 *client.RetrieveRedisResponse
+			result2 error
+		})
+	}
+	fake.retrieveRedisWithResponseReturnsOnCall[i] = struct {
+		result1 *client.RetrieveRedisResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeKeyValueRepoClient) SuspendKeyValueWithResponse(arg1 context.Context, arg2 string, arg3 ...client.RequestEditorFn) (*client.SuspendKeyValueResponse, error) {
+	fake.suspendKeyValueWithResponseMutex.Lock()
+	ret, specificReturn := fake.suspendKeyValueWithResponseReturnsOnCall[len(fake.suspendKeyValueWithResponseArgsForCall)]
+	fake.suspendKeyValueWithResponseArgsForCall = append(fake.suspendKeyValueWithResponseArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []client.RequestEditorFn
+	}{arg1, arg2, arg3})
+	stub := fake.SuspendKeyValueWithResponseStub
+	fakeReturns := fake.suspendKeyValueWithResponseReturns
+	fake.recordInvocation("SuspendKeyValueWithResponse", []interface{}{arg1, arg2, arg3})
+	fake.suspendKeyValueWithResponseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeKeyValueRepoClient) SuspendKeyValueWithResponseCallCount() int {
+	fake.suspendKeyValueWithResponseMutex.RLock()
+	defer fake.suspendKeyValueWithResponseMutex.RUnlock()
+	return len(fake.suspendKeyValueWithResponseArgsForCall)
+}
+
+func (fake *FakeKeyValueRepoClient) SuspendKeyValueWithResponseCalls(stub func(context.Context, string, ...client.RequestEditorFn) (*client.SuspendKeyValueResponse, error)) {
+	fake.suspendKeyValueWithResponseMutex.Lock()
+	defer fake.suspendKeyValueWithResponseMutex.Unlock()
+	fake.SuspendKeyValueWithResponseStub = stub
+}
+
+func (fake *FakeKeyValueRepoClient) SuspendKeyValueWithResponseArgsForCall(i int) (context.Context, string, []client.RequestEditorFn) {
+	fake.suspendKeyValueWithResponseMutex.RLock()
+	defer fake.suspendKeyValueWithResponseMutex.RUnlock()
+	argsForCall := fake.suspendKeyValueWithResponseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeKeyValueRepoClient) SuspendKeyValueWithResponseReturns(result1 *client.SuspendKeyValueResponse, result2 error) {
+	fake.suspendKeyValueWithResponseMutex.Lock()
+	defer fake.suspendKeyValueWithResponseMutex.Unlock()
+	fake.SuspendKeyValueWithResponseStub = nil
+	fake.suspendKeyValueWithResponseReturns = struct {
+		result1 *client.SuspendKeyValueResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeKeyValueRepoClient) SuspendKeyValueWithResponseReturnsOnCall(i int, result1 *client.SuspendKeyValueResponse, result2 error) {
+	fake.suspendKeyValueWithResponseMutex.Lock()
+	defer fake.suspendKeyValueWithResponseMutex.Unlock()
+	fake.SuspendKeyValueWithResponseStub = nil
+	if fake.suspendKeyValueWithResponseReturnsOnCall == nil {
+		fake.suspendKeyValueWithResponseReturnsOnCall = make(map[int]struct {
+			result1 *client.SuspendKeyValueResponse
+			result2 error
+		})
+	}
+	fake.suspendKeyValueWithResponseReturnsOnCall[i] = struct {
+		result1 *client.SuspendKeyValueResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeKeyValueRepoClient) UpdateKeyValueWithResponse(arg1 context.Context, arg2 string, arg3 client.KeyValuePATCHInput, arg4 ...client.RequestEditorFn) (*client.UpdateKeyValueResponse, error) {
+	fake.updateKeyValueWithResponseMutex.Lock()
+	ret, specificReturn := fake.updateKeyValueWithResponseReturnsOnCall[len(fake.updateKeyValueWithResponseArgsForCall)]
+	fake.updateKeyValueWithResponseArgsForCall = append(fake.updateKeyValueWithResponseArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 client.KeyValuePATCHInput
+		arg4 []client.RequestEditorFn
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.UpdateKeyValueWithResponseStub
+	fakeReturns := fake.updateKeyValueWithResponseReturns
+	fake.recordInvocation("UpdateKeyValueWithResponse", []interface{}{arg1, arg2, arg3, arg4})
+	fake.updateKeyValueWithResponseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeKeyValueRepoClient) UpdateKeyValueWithResponseCallCount() int {
+	fake.updateKeyValueWithResponseMutex.RLock()
+	defer fake.updateKeyValueWithResponseMutex.RUnlock()
+	return len(fake.updateKeyValueWithResponseArgsForCall)
+}
+
+func (fake *FakeKeyValueRepoClient) UpdateKeyValueWithResponseCalls(stub func(context.Context, string, client.KeyValuePATCHInput, ...client.RequestEditorFn) (*client.UpdateKeyValueResponse, error)) {
+	fake.updateKeyValueWithResponseMutex.Lock()
+	defer fake.updateKeyValueWithResponseMutex.Unlock()
+	fake.UpdateKeyValueWithResponseStub = stub
+}
+
+func (fake *FakeKeyValueRepoClient) UpdateKeyValueWithResponseArgsForCall(i int) (context.Context, string, client.KeyValuePATCHInput, []client.RequestEditorFn) {
+	fake.updateKeyValueWithResponseMutex.RLock()
+	defer fake.updateKeyValueWithResponseMutex.RUnlock()
+	argsForCall := fake.updateKeyValueWithResponseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeKeyValueRepoClient) UpdateKeyValueWithResponseReturns(result1 *client.UpdateKeyValueResponse, result2 error) {
+	fake.updateKeyValueWithResponseMutex.Lock()
+	defer fake.updateKeyValueWithResponseMutex.Unlock()
+	fake.UpdateKeyValueWithResponseStub = nil
+	fake.updateKeyValueWithResponseReturns = struct {
+		result1 *client.UpdateKeyValueResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeKeyValueRepoClient) UpdateKeyValueWithResponseReturnsOnCall(i int, result1 *client.UpdateKeyValueResponse, result2 error) {
+	fake.updateKeyValueWithResponseMutex.Lock()
+	defer fake.updateKeyValueWithResponseMutex.Unlock()
+	fake.UpdateKeyValueWithResponseStub = nil
+	if fake.updateKeyValueWithResponseReturnsOnCall == nil {
+		fake.updateKeyValueWithResponseReturnsOnCall = make(map[int]struct {
+			result1 *client.UpdateKeyValueResponse
+			result2 error
+		})
+	}
+	fake.updateKeyValueWithResponseReturnsOnCall[i] = struct {
+		result1 *client.UpdateKeyValueResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeKeyValueRepoClient) UpdateRedisWithResponse(arg1 context.Context, arg2 string, arg3 client.RedisPATCHInput, arg4 ...client.RequestEditorFn) (*client.UpdateRedisResponse, error) {
+	fake.updateRedisWithResponseMutex.Lock()
+	ret, specificReturn := fake.updateRedisWithResponseReturnsOnCall[len(fake.updateRedisWithResponseArgsForCall)]
+	fake.updateRedisWithResponseArgsForCall = append(fake.updateRedisWithResponseArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 client.RedisPATCHInput
+		arg4 []client.RequestEditorFn
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.UpdateRedisWithResponseStub
+	fakeReturns := fake.updateRedisWithResponseReturns
+	fake.recordInvocation("UpdateRedisWithResponse", []interface{}{arg1, arg2, arg3, arg4})
+	fake.updateRedisWithResponseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeKeyValueRepoClient) UpdateRedisWithResponseCallCount() int {
+	fake.updateRedisWithResponseMutex.RLock()
+	defer fake.updateRedisWithResponseMutex.RUnlock()
+	return len(fake.updateRedisWithResponseArgsForCall)
+}
+
+func (fake *FakeKeyValueRepoClient) UpdateRedisWithResponseCalls(stub func(context.Context, string, client.RedisPATCHInput, ...client.RequestEditorFn) (*client.UpdateRedisResponse, error)) {
+	fake.updateRedisWithResponseMutex.Lock()
+	defer fake.updateRedisWithResponseMutex.Unlock()
+	fake.UpdateRedisWithResponseStub = stub
+}
+
+func (fake *FakeKeyValueRepoClient) UpdateRedisWithResponseArgsForCall(i int) (context.Context, string, client.RedisPATCHInput, []client.RequestEditorFn) {
+	fake.updateRedisWithResponseMutex.RLock()
+	defer fake.updateRedisWithResponseMutex.RUnlock()
+	argsForCall := fake.updateRedisWithResponseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeKeyValueRepoClient) UpdateRedisWithResponseReturns(result1 *client.UpdateRedisResponse, result2 error) {
+	fake.updateRedisWithResponseMutex.Lock()
+	defer fake.updateRedisWithResponseMutex.Unlock()
+	fake.UpdateRedisWithResponseStub = nil
+	fake.updateRedisWithResponseReturns = struct {
+		result1 *client.UpdateRedisResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeKeyValueRepoClient) UpdateRedisWithResponseReturnsOnCall(i int, result1 *client.UpdateRedisResponse, result2 error) {
+	fake.updateRedisWithResponseMutex.Lock()
+	defer fake.updateRedisWithResponseMutex.Unlock()
+	fake.UpdateRedisWithResponseStub = nil
+	if fake.updateRedisWithResponseReturnsOnCall == nil {
+		fake.updateRedisWithResponseReturnsOnCall = make(map[int]struct {
+			result1 *client.UpdateRedisResponse
+			result2 error
+		})
+	}
+	fake.updateRedisWithResponseReturnsOnCall[i] = struct {
+		result1 *client.UpdateRedisResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeKeyValueRepoClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
-	fake.createKeyValueWithResponseMutex.RLock()
-	defer fake.createKeyValueWithResponseMutex.RUnlock()
-	fake.listKeyValueWithResponseMutex.RLock()
-	defer fake.listKeyValueWithResponseMutex.RUnlock()
-	fake.retrieveKeyValueConnectionInfoWithResponseMutex.RLock()
-	defer fake.retrieveKeyValueConnectionInfoWithResponseMutex.RUnlock()
-	fake.retrieveKeyValueWithResponseMutex.RLock()
-	defer fake.retrieveKeyValueWithResponseMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -3,7 +3,6 @@ package fakes
 
 import (
 	"context"
-	"net/http"
 	"sync"
 
 	"github.com/render-oss/render-mcp-server/pkg/client"
@@ -25,6 +24,36 @@ type FakePostgresRepoClient struct {
 		result1 *client.CreatePostgresResponse
 		result2 error
 	}
+	DeletePostgresWithResponseStub        func(context.Context, string, ...client.RequestEditorFn) (*client.DeletePostgresResponse, error)
+	deletePostgresWithResponseMutex       sync.RWMutex
+	deletePostgresWithResponseArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []client.RequestEditorFn
+	}
+	deletePostgresWithResponseReturns struct {
+		result1 *client.DeletePostgresResponse
+		result2 error
+	}
+	deletePostgresWithResponseReturnsOnCall map[int]struct {
+		result1 *client.DeletePostgresResponse
+		result2 error
+	}
+	FailoverPostgresWithResponseStub        func(context.Context, string, ...client.RequestEditorFn) (*client.FailoverPostgresResponse, error)
+	failoverPostgresWithResponseMutex       sync.RWMutex
+	failoverPostgresWithResponseArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []client.RequestEditorFn
+	}
+	failoverPostgresWithResponseReturns struct {
+		result1 *client.FailoverPostgresResponse
+		result2 error
+	}
+	failoverPostgresWithResponseReturnsOnCall map[int]struct {
+		result1 *client.FailoverPostgresResponse
+		result2 error
+	}
 	ListPostgresWithResponseStub        func(context.Context, *client.ListPostgresParams, ...client.RequestEditorFn) (*client.ListPostgresResponse, error)
 	listPostgresWithResponseMutex       sync.RWMutex
 	listPostgresWithResponseArgsForCall []struct {
@@ -40,19 +69,34 @@ type FakePostgresRepoClient struct {
 		result1 *client.ListPostgresResponse
 		result2 error
 	}
-	RestartPostgresStub        func(context.Context, string, ...client.RequestEditorFn) (*http.Response, error)
-	restartPostgresMutex       sync.RWMutex
-	restartPostgresArgsForCall []struct {
+	RestartPostgresWithResponseStub        func(context.Context, string, ...client.RequestEditorFn) (*client.RestartPostgresResponse, error)
+	restartPostgresWithResponseMutex       sync.RWMutex
+	restartPostgresWithResponseArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []client.RequestEditorFn
+	}
+	restartPostgresWithResponseReturns struct {
+		result1 *client.RestartPostgresResponse
+		result2 error
+	}
+	restartPostgresWithResponseReturnsOnCall map[int]struct {
+		result1 *client.RestartPostgresResponse
+		result2 error
+	}
+	ResumePostgresWithResponseStub        func(context.Context, string, ...client.RequestEditorFn) (*client.ResumePostgresResponse, error)
+	resumePostgresWithResponseMutex       sync.RWMutex
+	resumePostgresWithResponseArgsForCall []struct {
 		arg1 context.Context
 		arg2 string
 		arg3 []client.RequestEditorFn
 	}
-	restartPostgresReturns struct {
-		result1 *http.Response
+	resumePostgresWithResponseReturns struct {
+		result1 *client.ResumePostgresResponse
 		result2 error
 	}
-	restartPostgresReturnsOnCall map[int]struct {
-		result1 *http.Response
+	resumePostgresWithResponseReturnsOnCall map[int]struct {
+		result1 *client.ResumePostgresResponse
 		result2 error
 	}
 	RetrievePostgresConnectionInfoWithResponseStub        func(context.Context, string, ...client.RequestEditorFn) (*client.RetrievePostgresConnectionInfoResponse, error)
@@ -85,6 +129,37 @@ type FakePostgresRepoClient struct {
 		result1 *client.RetrievePostgresResponse
 		result2 error
 	}
+	SuspendPostgresWithResponseStub        func(context.Context, string, ...client.RequestEditorFn) (*client.SuspendPostgresResponse, error)
+	suspendPostgresWithResponseMutex       sync.RWMutex
+	suspendPostgresWithResponseArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []client.RequestEditorFn
+	}
+	suspendPostgresWithResponseReturns struct {
+		result1 *client.SuspendPostgresResponse
+		result2 error
+	}
+	suspendPostgresWithResponseReturnsOnCall map[int]struct {
+		result1 *client.SuspendPostgresResponse
+		result2 error
+	}
+	UpdatePostgresWithResponseStub        func(context.Context, string, client.PostgresPATCHInput, ...client.RequestEditorFn) (*client.UpdatePostgresResponse, error)
+	updatePostgresWithResponseMutex       sync.RWMutex
+	updatePostgresWithResponseArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 client.PostgresPATCHInput
+		arg4 []client.RequestEditorFn
+	}
+	updatePostgresWithResponseReturns struct {
+		result1 *client.UpdatePostgresResponse
+		result2 error
+	}
+	updatePostgresWithResponseReturnsOnCall map[int]struct {
+		result1 *client.UpdatePostgresResponse
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -155,6 +230,138 @@ func (fake *FakePostgresRepoClient) CreatePostgresWithResponseReturnsOnCall(i in
 	}{result1, result2}
 }
 
+func (fake *FakePostgresRepoClient) DeletePostgresWithResponse(arg1 context.Context, arg2 string, arg3 ...client.RequestEditorFn) (*client.DeletePostgresResponse, error) {
+	fake.deletePostgresWithResponseMutex.Lock()
+	ret, specificReturn := fake.deletePostgresWithResponseReturnsOnCall[len(fake.deletePostgresWithResponseArgsForCall)]
+	fake.deletePostgresWithResponseArgsForCall = append(fake.deletePostgresWithResponseArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []client.RequestEditorFn
+	}{arg1, arg2, arg3})
+	stub := fake.DeletePostgresWithResponseStub
+	fakeReturns := fake.deletePostgresWithResponseReturns
+	fake.recordInvocation("DeletePostgresWithResponse", []interface{}{arg1, arg2, arg3})
+	fake.deletePostgresWithResponseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePostgresRepoClient) DeletePostgresWithResponseCallCount() int {
+	fake.deletePostgresWithResponseMutex.RLock()
+	defer fake.deletePostgresWithResponseMutex.RUnlock()
+	return len(fake.deletePostgresWithResponseArgsForCall)
+}
+
+func (fake *FakePostgresRepoClient) DeletePostgresWithResponseCalls(stub func(context.Context, string, ...client.RequestEditorFn) (*client.DeletePostgresResponse, error)) {
+	fake.deletePostgresWithResponseMutex.Lock()
+	defer fake.deletePostgresWithResponseMutex.Unlock()
+	fake.DeletePostgresWithResponseStub = stub
+}
+
+func (fake *FakePostgresRepoClient) DeletePostgresWithResponseArgsForCall(i int) (context.Context, string, []client.RequestEditorFn) {
+	fake.deletePostgresWithResponseMutex.RLock()
+	defer fake.deletePostgresWithResponseMutex.RUnlock()
+	argsForCall := fake.deletePostgresWithResponseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePostgresRepoClient) DeletePostgresWithResponseReturns(result1 *client.DeletePostgresResponse, result2 error) {
+	fake.deletePostgresWithResponseMutex.Lock()
+	defer fake.deletePostgresWithResponseMutex.Unlock()
+	fake.DeletePostgresWithResponseStub = nil
+	fake.deletePostgresWithResponseReturns = struct {
+		result1 *client.DeletePostgresResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePostgresRepoClient) DeletePostgresWithResponseReturnsOnCall(i int, result1 *client.DeletePostgresResponse, result2 error) {
+	fake.deletePostgresWithResponseMutex.Lock()
+	defer fake.deletePostgresWithResponseMutex.Unlock()
+	fake.DeletePostgresWithResponseStub = nil
+	if fake.deletePostgresWithResponseReturnsOnCall == nil {
+		fake.deletePostgresWithResponseReturnsOnCall = make(map[int]struct {
+			result1 *client.DeletePostgresResponse
+			result2 error
+		})
+	}
+	fake.deletePostgresWithResponseReturnsOnCall[i] = struct {
+		result1 *client.DeletePostgresResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePostgresRepoClient) FailoverPostgresWithResponse(arg1 context.Context, arg2 string, arg3 ...client.RequestEditorFn) (*client.FailoverPostgresResponse, error) {
+	fake.failoverPostgresWithResponseMutex.Lock()
+	ret, specificReturn := fake.failoverPostgresWithResponseReturnsOnCall[len(fake.failoverPostgresWithResponseArgsForCall)]
+	fake.failoverPostgresWithResponseArgsForCall = append(fake.failoverPostgresWithResponseArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []client.RequestEditorFn
+	}{arg1, arg2, arg3})
+	stub := fake.FailoverPostgresWithResponseStub
+	fakeReturns := fake.failoverPostgresWithResponseReturns
+	fake.recordInvocation("FailoverPostgresWithResponse", []interface{}{arg1, arg2, arg3})
+	fake.failoverPostgresWithResponseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePostgresRepoClient) FailoverPostgresWithResponseCallCount() int {
+	fake.failoverPostgresWithResponseMutex.RLock()
+	defer fake.failoverPostgresWithResponseMutex.RUnlock()
+	return len(fake.failoverPostgresWithResponseArgsForCall)
+}
+
+func (fake *FakePostgresRepoClient) FailoverPostgresWithResponseCalls(stub func(context.Context, string, ...client.RequestEditorFn) (*client.FailoverPostgresResponse, error)) {
+	fake.failoverPostgresWithResponseMutex.Lock()
+	defer fake.failoverPostgresWithResponseMutex.Unlock()
+	fake.FailoverPostgresWithResponseStub = stub
+}
+
+func (fake *FakePostgresRepoClient) FailoverPostgresWithResponseArgsForCall(i int) (context.Context, string, []client.RequestEditorFn) {
+	fake.failoverPostgresWithResponseMutex.RLock()
+	defer fake.failoverPostgresWithResponseMutex.RUnlock()
+	argsForCall := fake.failoverPostgresWithResponseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePostgresRepoClient) FailoverPostgresWithResponseReturns(result1 *client.FailoverPostgresResponse, result2 error) {
+	fake.failoverPostgresWithResponseMutex.Lock()
+	defer fake.failoverPostgresWithResponseMutex.Unlock()
+	fake.FailoverPostgresWithResponseStub = nil
+	fake.failoverPostgresWithResponseReturns = struct {
+		result1 *client.FailoverPostgresResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePostgresRepoClient) FailoverPostgresWithResponseReturnsOnCall(i int, result1 *client.FailoverPostgresResponse, result2 error) {
+	fake.failoverPostgresWithResponseMutex.Lock()
+	defer fake.failoverPostgresWithResponseMutex.Unlock()
+	fake.FailoverPostgresWithResponseStub = nil
+	if fake.failoverPostgresWithResponseReturnsOnCall == nil {
+		fake.failoverPostgresWithResponseReturnsOnCall = make(map[int]struct {
+			result1 *client.FailoverPostgresResponse
+			result2 error
+		})
+	}
+	fake.failoverPostgresWithResponseReturnsOnCall[i] = struct {
+		result1 *client.FailoverPostgresResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePostgresRepoClient) ListPostgresWithResponse(arg1 context.Context, arg2 *client.ListPostgresParams, arg3 ...client.RequestEditorFn) (*client.ListPostgresResponse, error) {
 	fake.listPostgresWithResponseMutex.Lock()
 	ret, specificReturn := fake.listPostgresWithResponseReturnsOnCall[len(fake.listPostgresWithResponseArgsForCall)]
@@ -221,18 +428,84 @@ func (fake *FakePostgresRepoClient) ListPostgresWithResponseReturnsOnCall(i int,
 	}{result1, result2}
 }
 
-func (fake *FakePostgresRepoClient) RestartPostgres(arg1 context.Context, arg2 string, arg3 ...client.RequestEditorFn) (*http.Response, error) {
-	fake.restartPostgresMutex.Lock()
-	ret, specificReturn := fake.restartPostgresReturnsOnCall[len(fake.restartPostgresArgsForCall)]
-	fake.restartPostgresArgsForCall = append(fake.restartPostgresArgsForCall, struct {
+func (fake *FakePostgresRepoClient) RestartPostgresWithResponse(arg1 context.Context, arg2 string, arg3 ...client.RequestEditorFn) (*client.RestartPostgresResponse, error) {
+	fake.restartPostgresWithResponseMutex.Lock()
+	ret, specificReturn := fake.restartPostgresWithResponseReturnsOnCall[len(fake.restartPostgresWithResponseArgsForCall)]
+	fake.restartPostgresWithResponseArgsForCall = append(fake.restartPostgresWithResponseArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []client.RequestEditorFn
+	}{arg1, arg2, arg3})
+	stub := fake.RestartPostgresWithResponseStub
+	fakeReturns := fake.restartPostgresWithResponseReturns
+	fake.recordInvocation("RestartPostgresWithResponse", []interface{}{arg1, arg2, arg3})
+	fake.restartPostgresWithResponseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePostgresRepoClient) RestartPostgresWithResponseCallCount() int {
+	fake.restartPostgresWithResponseMutex.RLock()
+	defer fake.restartPostgresWithResponseMutex.RUnlock()
+	return len(fake.restartPostgresWithResponseArgsForCall)
+}
+
+func (fake *FakePostgresRepoClient) RestartPostgresWithResponseCalls(stub func(context.Context, string, ...client.RequestEditorFn) (*client.RestartPostgresResponse, error)) {
+	fake.restartPostgresWithResponseMutex.Lock()
+	defer fake.restartPostgresWithResponseMutex.Unlock()
+	fake.RestartPostgresWithResponseStub = stub
+}
+
+func (fake *FakePostgresRepoClient) RestartPostgresWithResponseArgsForCall(i int) (context.Context, string, []client.RequestEditorFn) {
+	fake.restartPostgresWithResponseMutex.RLock()
+	defer fake.restartPostgresWithResponseMutex.RUnlock()
+	argsForCall := fake.restartPostgresWithResponseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePostgresRepoClient) RestartPostgresWithResponseReturns(result1 *client.RestartPostgresResponse, result2 error) {
+	fake.restartPostgresWithResponseMutex.Lock()
+	defer fake.restartPostgresWithResponseMutex.Unlock()
+	fake.RestartPostgresWithResponseStub = nil
+	fake.restartPostgresWithResponseReturns = struct {
+		result1 *client.RestartPostgresResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePostgresRepoClient) RestartPostgresWithResponseReturnsOnCall(i int, result1 *client.RestartPostgresResponse, result2 error) {
+	fake.restartPostgresWithResponseMutex.Lock()
+	defer fake.restartPostgresWithResponseMutex.Unlock()
+	fake.RestartPostgresWithResponseStub = nil
+	if fake.restartPostgresWithResponseReturnsOnCall == nil {
+		fake.restartPostgresWithResponseReturnsOnCall = make(map[int]struct {
+			result1 *client.RestartPostgresResponse
+			result2 error
+		})
+	}
+	fake.restartPostgresWithResponseReturnsOnCall[i] = struct {
+		result1 *client.RestartPostgresResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePostgresRepoClient) ResumePostgresWithResponse(arg1 context.Context, arg2 string, arg3 ...client.RequestEditorFn) (*client.ResumePostgresResponse, error) {
+	fake.resumePostgresWithResponseMutex.Lock()
+	ret, specificReturn := fake.resumePostgresWithResponseReturnsOnCall[len(fake.resumePostgresWithResponseArgsForCall)]
+	fake.resumePostgresWithResponseArgsForCall = append(fake.resumePostgresWithResponseArgsForCall, struct {
 		arg1 context.Context
 		arg2 string
 		arg3 []client.RequestEditorFn
 	}{arg1, arg2, arg3})
-	stub := fake.RestartPostgresStub
-	fakeReturns := fake.restartPostgresReturns
-	fake.recordInvocation("RestartPostgres", []interface{}{arg1, arg2, arg3})
-	fake.restartPostgresMutex.Unlock()
+	stub := fake.ResumePostgresWithResponseStub
+	fakeReturns := fake.resumePostgresWithResponseReturns
+	fake.recordInvocation("ResumePostgresWithResponse", []interface{}{arg1, arg2, arg3})
+	fake.resumePostgresWithResponseMutex.Unlock()
 	if stub != nil {
 		return stub(arg1, arg2, arg3...)
 	}
@@ -242,47 +515,47 @@ func (fake *FakePostgresRepoClient) RestartPostgres(arg1 context.Context, arg2 s
 	return fakeReturns.result1, fakeReturns.result2
 }
 
-func (fake *FakePostgresRepoClient) RestartPostgresCallCount() int {
-	fake.restartPostgresMutex.RLock()
-	defer fake.restartPostgresMutex.RUnlock()
-	return len(fake.restartPostgresArgsForCall)
+func (fake *FakePostgresRepoClient) ResumePostgresWithResponseCallCount() int {
+	fake.resumePostgresWithResponseMutex.RLock()
+	defer fake.resumePostgresWithResponseMutex.RUnlock()
+	return len(fake.resumePostgresWithResponseArgsForCall)
 }
 
-func (fake *FakePostgresRepoClient) RestartPostgresCalls(stub func(context.Context, string, ...client.RequestEditorFn) (*http.Response, error)) {
-	fake.restartPostgresMutex.Lock()
-	defer fake.restartPostgresMutex.Unlock()
-	fake.RestartPostgresStub = stub
+func (fake *FakePostgresRepoClient) ResumePostgresWithResponseCalls(stub func(context.Context, string, ...client.RequestEditorFn) (*client.ResumePostgresResponse, error)) {
+	fake.resumePostgresWithResponseMutex.Lock()
+	defer fake.resumePostgresWithResponseMutex.Unlock()
+	fake.ResumePostgresWithResponseStub = stub
 }
 
-func (fake *FakePostgresRepoClient) RestartPostgresArgsForCall(i int) (context.Context, string, []client.RequestEditorFn) {
-	fake.restartPostgresMutex.RLock()
-	defer fake.restartPostgresMutex.RUnlock()
-	argsForCall := fake.restartPostgresArgsForCall[i]
+func (fake *FakePostgresRepoClient) ResumePostgresWithResponseArgsForCall(i int) (context.Context, string, []client.RequestEditorFn) {
+	fake.resumePostgresWithResponseMutex.RLock()
+	defer fake.resumePostgresWithResponseMutex.RUnlock()
+	argsForCall := fake.resumePostgresWithResponseArgsForCall[i]
 	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
 }
 
-func (fake *FakePostgresRepoClient) RestartPostgresReturns(result1 *http.Response, result2 error) {
-	fake.restartPostgresMutex.Lock()
-	defer fake.restartPostgresMutex.Unlock()
-	fake.RestartPostgresStub = nil
-	fake.restartPostgresReturns = struct {
-		result1 *http.Response
+func (fake *FakePostgresRepoClient) ResumePostgresWithResponseReturns(result1 *client.ResumePostgresResponse, result2 error) {
+	fake.resumePostgresWithResponseMutex.Lock()
+	defer fake.resumePostgresWithResponseMutex.Unlock()
+	fake.ResumePostgresWithResponseStub = nil
+	fake.resumePostgresWithResponseReturns = struct {
+		result1 *client.ResumePostgresResponse
 		result2 error
 	}{result1, result2}
 }
 
-func (fake *FakePostgresRepoClient) RestartPostgresReturnsOnCall(i int, result1 *http.Response, result2 error) {
-	fake.restartPostgresMutex.Lock()
-	defer fake.restartPostgresMutex.Unlock()
-	fake.RestartPostgresStub = nil
-	if fake.restartPostgresReturnsOnCall == nil {
-		fake.restartPostgresReturnsOnCall = make(map[int]struct {
-			result1 *http.Response
+func (fake *FakePostgresRepoClient) ResumePostgresWithResponseReturnsOnCall(i int, result1 *client.ResumePostgresResponse, result2 error) {
+	fake.resumePostgresWithResponseMutex.Lock()
+	defer fake.resumePostgresWithResponseMutex.Unlock()
+	fake.ResumePostgresWithResponseStub = nil
+	if fake.resumePostgresWithResponseReturnsOnCall == nil {
+		fake.resumePostgresWithResponseReturnsOnCall = make(map[int]struct {
+			result1 *client.ResumePostgresResponse
 			result2 error
 		})
 	}
-	fake.restartPostgresReturnsOnCall[i] = struct {
-		result1 *http.Response
+	fake.resumePostgresWithResponseReturnsOnCall[i] = struct {
+		result1 *client.ResumePostgresResponse
 		result2 error
 	}{result1, result2}
 }
@@ -419,19 +692,142 @@ func (fake *FakePostgresRepoClient) RetrievePostgresWithResponseReturnsOnCall(i
 	}{result1, result2}
 }
 
+func (fake *FakePostgresRepoClient) SuspendPostgresWithResponse(arg1 context.Context, arg2 string, arg3 ...client.RequestEditorFn) (*client.SuspendPostgresResponse, error) {
+	fake.suspendPostgresWithResponseMutex.Lock()
+	ret, specificReturn := fake.suspendPostgresWithResponseReturnsOnCall[len(fake.suspendPostgresWithResponseArgsForCall)]
+	fake.suspendPostgresWithResponseArgsForCall = append(fake.suspendPostgresWithResponseArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 []client.RequestEditorFn
+	}{arg1, arg2, arg3})
+	stub := fake.SuspendPostgresWithResponseStub
+	fakeReturns := fake.suspendPostgresWithResponseReturns
+	fake.recordInvocation("SuspendPostgresWithResponse", []interface{}{arg1, arg2, arg3})
+	fake.suspendPostgresWithResponseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePostgresRepoClient) SuspendPostgresWithResponseCallCount() int {
+	fake.suspendPostgresWithResponseMutex.RLock()
+	defer fake.suspendPostgresWithResponseMutex.RUnlock()
+	return len(fake.suspendPostgresWithResponseArgsForCall)
+}
+
+func (fake *FakePostgresRepoClient) SuspendPostgresWithResponseCalls(stub func(context.Context, string, ...client.RequestEditorFn) (*client.SuspendPostgresResponse, error)) {
+	fake.suspendPostgresWithResponseMutex.Lock()
+	defer fake.suspendPostgresWithResponseMutex.Unlock()
+	fake.SuspendPostgresWithResponseStub = stub
+}
+
+func (fake *FakePostgresRepoClient) SuspendPostgresWithResponseArgsForCall(i int) (context.Context, string, []client.RequestEditorFn) {
+	fake.suspendPostgresWithResponseMutex.RLock()
+	defer fake.suspendPostgresWithResponseMutex.RUnlock()
+	argsForCall := fake.suspendPostgresWithResponseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakePostgresRepoClient) SuspendPostgresWithResponseReturns(result1 *client.SuspendPostgresResponse, result2 error) {
+	fake.suspendPostgresWithResponseMutex.Lock()
+	defer fake.suspendPostgresWithResponseMutex.Unlock()
+	fake.SuspendPostgresWithResponseStub = nil
+	fake.suspendPostgresWithResponseReturns = struct {
+		result1 *client.SuspendPostgresResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePostgresRepoClient) SuspendPostgresWithResponseReturnsOnCall(i int, result1 *client.SuspendPostgresResponse, result2 error) {
+	fake.suspendPostgresWithResponseMutex.Lock()
+	defer fake.suspendPostgresWithResponseMutex.Unlock()
+	fake.SuspendPostgresWithResponseStub = nil
+	if fake.suspendPostgresWithResponseReturnsOnCall == nil {
+		fake.suspendPostgresWithResponseReturnsOnCall = make(map[int]struct {
+			result1 *client.SuspendPostgresResponse
+			result2 error
+		})
+	}
+	fake.suspendPostgresWithResponseReturnsOnCall[i] = struct {
+		result1 *client.SuspendPostgresResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePostgresRepoClient) UpdatePostgresWithResponse(arg1 context.Context, arg2 string, arg3 client.PostgresPATCHInput, arg4 ...client.RequestEditorFn) (*client.UpdatePostgresResponse, error) {
+	fake.updatePostgresWithResponseMutex.Lock()
+	ret, specificReturn := fake.updatePostgresWithResponseReturnsOnCall[len(fake.updatePostgresWithResponseArgsForCall)]
+	fake.updatePostgresWithResponseArgsForCall = append(fake.updatePostgresWithResponseArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 client.PostgresPATCHInput
+		arg4 []client.RequestEditorFn
+	}{arg1, arg2, arg3, arg4})
+	stub := fake.UpdatePostgresWithResponseStub
+	fakeReturns := fake.updatePostgresWithResponseReturns
+	fake.recordInvocation("UpdatePostgresWithResponse", []interface{}{arg1, arg2, arg3, arg4})
+	fake.updatePostgresWithResponseMutex.Unlock()
+	if stub != nil {
+		return stub(arg1, arg2, arg3, arg4...)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePostgresRepoClient) UpdatePostgresWithResponseCallCount() int {
+	fake.updatePostgresWithResponseMutex.RLock()
+	defer fake.updatePostgresWithResponseMutex.RUnlock()
+	return len(fake.updatePostgresWithResponseArgsForCall)
+}
+
+func (fake *FakePostgresRepoClient) UpdatePostgresWithResponseCalls(stub func(context.Context, string, client.PostgresPATCHInput, ...client.RequestEditorFn) (*client.UpdatePostgresResponse, error)) {
+	fake.updatePostgresWithResponseMutex.Lock()
+	defer fake.updatePostgresWithResponseMutex.Unlock()
+	fake.UpdatePostgresWithResponseStub = stub
+}
+
+func (fake *FakePostgresRepoClient) UpdatePostgresWithResponseArgsForCall(i int) (context.Context, string, client.PostgresPATCHInput, []client.RequestEditorFn) {
+	fake.updatePostgresWithResponseMutex.RLock()
+	defer fake.updatePostgresWithResponseMutex.RUnlock()
+	argsForCall := fake.updatePostgresWithResponseArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakePostgresRepoClient) UpdatePostgresWithResponseReturns(result1 *client.UpdatePostgresResponse, result2 error) {
+	fake.updatePostgresWithResponseMutex.Lock()
+	defer fake.updatePostgresWithResponseMutex.Unlock()
+	fake.UpdatePostgresWithResponseStub = nil
+	fake.updatePostgresWithResponseReturns = struct {
+		result1 *client.UpdatePostgresResponse
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePostgresRepoClient) UpdatePostgresWithResponseReturnsOnCall(i int, result1 *client.UpdatePostgresResponse, result2 error) {
+	fake.updatePostgresWithResponseMutex.Lock()
+	defer fake.updatePostgresWithResponseMutex.Unlock()
+	fake.UpdatePostgresWithResponseStub = nil
+	if fake.updatePostgresWithResponseReturnsOnCall == nil {
+		fake.updatePostgresWithResponseReturnsOnCall = make(map[int]struct {
+			result1 *client.UpdatePostgresResponse
+			result2 error
+		})
+	}
+	fake.updatePostgresWithResponseReturnsOnCall[i] = struct {
+		result1 *client.UpdatePostgresResponse
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePostgresRepoClient) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
-	fake.createPostgresWithResponseMutex.RLock()
-	defer fake.createPostgresWithResponseMutex.RUnlock()
-	fake.listPostgresWithResponseMutex.RLock()
-	defer fake.listPostgresWithResponseMutex.RUnlock()
-	fake.restartPostgresMutex.RLock()
-	defer fake.restartPostgresMutex.RUnlock()
-	fake.retrievePostgresConnectionInfoWithResponseMutex.RLock()
-	defer fake.retrievePostgresConnectionInfoWithResponseMutex.RUnlock()
-	fake.retrievePostgresWithResponseMutex.RLock()
-	defer fake.retrievePostgresWithResponseMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,348 @@
+package keyvalue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	metricstypes "github.com/render-oss/render-mcp-server/pkg/client/metrics"
+)
+
+const (
+	// defaultAnalyzeSampleKeys and maxAnalyzeSampleKeys bound how many keys
+	// analyze_key_value samples with SCAN. Every sampled key costs a TYPE,
+	// PTTL and MEMORY USAGE call, so the sample is kept small.
+	defaultAnalyzeSampleKeys = 1000
+	maxAnalyzeSampleKeys     = 10000
+	// analyzeBatchSize is how many sampled keys are inspected per pipeline.
+	analyzeBatchSize = 100
+	// maxKeyGroups caps the prefixes reported; the rest are folded into one
+	// group.
+	maxKeyGroups = 20
+	// maxBiggestKeys is how many of the largest sampled keys are listed.
+	maxBiggestKeys = 10
+	// memoryUsageWindow is how far back the memory_usage metric is
+	// summarized.
+	memoryUsageWindow = time.Hour
+)
+
+// analyzedInfoSections are the INFO sections analyze_key_value reports.
+var analyzedInfoSections = []string{"memory", "stats", "keyspace"}
+
+// otherKeysGroup collects the prefixes beyond maxKeyGroups, and noPrefixGroup
+// the keys that don't contain the delimiter.
+const (
+	otherKeysGroup = "(other prefixes)"
+	noPrefixGroup  = "(no prefix)"
+)
+
+type keyValueAnalysis struct {
+	Summary     *memorySummary               `json:"summary,omitempty"`
+	Info        map[string]map[string]string `json:"info"`
+	Sample      *keyspaceSample              `json:"sample,omitempty"`
+	MemoryUsage *memoryUsageSummary          `json:"memoryUsage,omitempty"`
+	Errors      []string                     `json:"errors,omitempty"`
+}
+
+// memorySummary pulls the INFO fields that explain why an instance is full.
+type memorySummary struct {
+	UsedMemoryBytes    int64    `json:"usedMemoryBytes"`
+	MaxMemoryBytes     int64    `json:"maxMemoryBytes,omitempty"`
+	UsedRatio          *float64 `json:"usedRatio,omitempty"`
+	MaxmemoryPolicy    string   `json:"maxmemoryPolicy,omitempty"`
+	FragmentationRatio *float64 `json:"fragmentationRatio,omitempty"`
+	EvictedKeys        int64    `json:"evictedKeys"`
+	ExpiredKeys        int64    `json:"expiredKeys"`
+	HitRatio           *float64 `json:"hitRatio,omitempty"`
+}
+
+type keyspaceSample struct {
+	TotalKeys   int64 `json:"totalKeys"`
+	SampledKeys int   `json:"sampledKeys"`
+	// Complete is set when the scan covered the whole keyspace, so the
+	// groups are exact counts rather than estimates.
+	Complete          bool        `json:"complete"`
+	KeysWithoutExpiry int         `json:"keysWithoutExpiry"`
+	SampledBytes      int64       `json:"sampledBytes"`
+	Prefixes          []keyGroup  `json:"prefixes"`
+	Types             []keyGroup  `json:"types"`
+	BiggestKeys       []keyMemory `json:"biggestKeys"`
+}
+
+// keyGroup counts the sampled keys sharing a prefix or type. EstimatedKeys
+// scales the sample up to the whole keyspace.
+type keyGroup struct {
+	Name          string `json:"name"`
+	SampledKeys   int    `json:"sampledKeys"`
+	EstimatedKeys int64  `json:"estimatedKeys"`
+	SampledBytes  int64  `json:"sampledBytes"`
+}
+
+type keyMemory struct {
+	Key   string `json:"key"`
+	Type  string `json:"type"`
+	Bytes int64  `json:"bytes"`
+	// TTLSeconds is omitted for keys without an expiry.
+	TTLSeconds *int64 `json:"ttlSeconds,omitempty"`
+}
+
+type memoryUsageSummary struct {
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+	Unit   string    `json:"unit,omitempty"`
+	Latest float32   `json:"latest"`
+	Peak   float32   `json:"peak"`
+}
+
+// runAnalysis reads the INFO sections and samples the keyspace. Failures
+// are collected in Errors so that one unsupported command doesn't hide the
+// rest of the analysis.
+func runAnalysis(ctx context.Context, rdb redis.Cmdable, sampleKeys int, delimiter string) *keyValueAnalysis {
+	analysis := &keyValueAnalysis{Info: map[string]map[string]string{}}
+
+	// Sections are requested one at a time since INFO only accepts several
+	// sections from Redis 7 on.
+	for _, section := range analyzedInfoSections {
+		info, err := rdb.Info(ctx, section).Result()
+		if err != nil {
+			analysis.Errors = append(analysis.Errors, fmt.Sprintf("INFO %s: %s", section, err.Error()))
+			continue
+		}
+		for name, fields := range parseInfo(info) {
+			analysis.Info[name] = fields
+		}
+	}
+	analysis.Summary = summarizeMemory(analysis.Info)
+
+	sample, err := sampleKeyspace(ctx, rdb, sampleKeys, delimiter)
+	if err != nil {
+		analysis.Errors = append(analysis.Errors, fmt.Sprintf("sampling keys: %s", err.Error()))
+	} else {
+		analysis.Sample = sample
+	}
+
+	return analysis
+}
+
+// summarizeMemory returns nil when INFO memory couldn't be read.
+func summarizeMemory(info map[string]map[string]string) *memorySummary {
+	memory, ok := info["memory"]
+	if !ok {
+		return nil
+	}
+	stats := info["stats"]
+
+	summary := &memorySummary{
+		UsedMemoryBytes: infoInt(memory, "used_memory"),
+		MaxMemoryBytes:  infoInt(memory, "maxmemory"),
+		MaxmemoryPolicy: memory["maxmemory_policy"],
+		EvictedKeys:     infoInt(stats, "evicted_keys"),
+		ExpiredKeys:     infoInt(stats, "expired_keys"),
+	}
+	if summary.MaxMemoryBytes > 0 {
+		ratio := float64(summary.UsedMemoryBytes) / float64(summary.MaxMemoryBytes)
+		summary.UsedRatio = &ratio
+	}
+	if fragmentation, err := strconv.ParseFloat(memory["mem_fragmentation_ratio"], 64); err == nil {
+		summary.FragmentationRatio = &fragmentation
+	}
+	if hits, misses := infoInt(stats, "keyspace_hits"), infoInt(stats, "keyspace_misses"); hits+misses > 0 {
+		ratio := float64(hits) / float64(hits+misses)
+		summary.HitRatio = &ratio
+	}
+	return summary
+}
+
+func infoInt(fields map[string]string, name string) int64 {
+	n, _ := strconv.ParseInt(fields[name], 10, 64)
+	return n
+}
+
+// sampleKeyspace scans up to sampleKeys keys and inspects each one's type,
+// expiry and memory usage. SCAN visits keys in hash order, which is close
+// enough to random for estimating the mix of prefixes and types.
+func sampleKeyspace(ctx context.Context, rdb redis.Cmdable, sampleKeys int, delimiter string) (*keyspaceSample, error) {
+	total, err := rdb.DBSize(ctx).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	sample := &keyspaceSample{TotalKeys: total}
+	var keys []string
+	var cursor uint64
+	// A keyspace that is mostly empty buckets can return few keys per call,
+	// so the number of calls is bounded as well
+	for calls := 0; len(keys) < sampleKeys && calls < 2*sampleKeys/defaultScanCount+10; calls++ {
+		var page []string
+		page, cursor, err = rdb.Scan(ctx, cursor, "", defaultScanCount).Result()
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, page...)
+		if cursor == 0 {
+			sample.Complete = len(keys) <= sampleKeys
+			break
+		}
+	}
+	if len(keys) > sampleKeys {
+		keys = keys[:sampleKeys]
+	}
+
+	inspected := make([]keyMemory, 0, len(keys))
+	for start := 0; start < len(keys); start += analyzeBatchSize {
+		batch, err := inspectKeys(ctx, rdb, keys[start:min(start+analyzeBatchSize, len(keys))])
+		if err != nil {
+			return nil, err
+		}
+		inspected = append(inspected, batch...)
+	}
+
+	summarizeSample(sample, inspected, delimiter)
+	return sample, nil
+}
+
+// inspectKeys reads the type, TTL and memory usage of keys in one pipeline.
+// Keys that expire between the scan and the pipeline are skipped.
+func inspectKeys(ctx context.Context, rdb redis.Cmdable, keys []string) ([]keyMemory, error) {
+	types := make([]*redis.StatusCmd, len(keys))
+	ttls := make([]*redis.DurationCmd, len(keys))
+	usages := make([]*redis.IntCmd, len(keys))
+	_, err := rdb.Pipelined(ctx, func(pipe redis.Pipeliner) error {
+		for i, key := range keys {
+			types[i] = pipe.Type(ctx, key)
+			ttls[i] = pipe.PTTL(ctx, key)
+			usages[i] = pipe.MemoryUsage(ctx, key)
+		}
+		return nil
+	})
+	// A key that vanished makes MEMORY USAGE return nil, which the pipeline
+	// reports as its error
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+
+	inspected := make([]keyMemory, 0, len(keys))
+	for i, key := range keys {
+		keyType, err := types[i].Result()
+		if err != nil || keyType == "none" {
+			continue
+		}
+		bytes, err := usages[i].Result()
+		if err != nil {
+			continue
+		}
+		km := keyMemory{Key: key, Type: keyType, Bytes: bytes}
+		if ttl, err := ttls[i].Result(); err == nil && ttl >= 0 {
+			seconds := int64(ttl / time.Second)
+			km.TTLSeconds = &seconds
+		}
+		inspected = append(inspected, km)
+	}
+	return inspected, nil
+}
+
+func summarizeSample(sample *keyspaceSample, keys []keyMemory, delimiter string) {
+	sample.SampledKeys = len(keys)
+	scale := 1.0
+	if !sample.Complete && len(keys) > 0 {
+		scale = float64(sample.TotalKeys) / float64(len(keys))
+	}
+
+	prefixes := map[string]*keyGroup{}
+	types := map[string]*keyGroup{}
+	for _, k := range keys {
+		sample.SampledBytes += k.Bytes
+		if k.TTLSeconds == nil {
+			sample.KeysWithoutExpiry++
+		}
+		addToGroup(prefixes, keyPrefix(k.Key, delimiter), k.Bytes)
+		addToGroup(types, k.Type, k.Bytes)
+	}
+
+	sample.Prefixes = rankGroups(prefixes, scale, maxKeyGroups)
+	sample.Types = rankGroups(types, scale, len(types))
+
+	sort.SliceStable(keys, func(i, j int) bool { return keys[i].Bytes > keys[j].Bytes })
+	sample.BiggestKeys = keys[:min(len(keys), maxBiggestKeys)]
+}
+
+// keyPrefix returns the part of key up to and including the first
+// delimiter, so that user:1 and user:2 share the prefix user:.
+func keyPrefix(key, delimiter string) string {
+	if delimiter == "" {
+		return noPrefixGroup
+	}
+	i := strings.Index(key, delimiter)
+	if i < 0 {
+		return noPrefixGroup
+	}
+	return key[:i+len(delimiter)]
+}
+
+func addToGroup(groups map[string]*keyGroup, name string, bytes int64) {
+	group, ok := groups[name]
+	if !ok {
+		group = &keyGroup{Name: name}
+		groups[name] = group
+	}
+	group.SampledKeys++
+	group.SampledBytes += bytes
+}
+
+// rankGroups orders groups by sampled key count and folds everything past
+// limit into a single group.
+func rankGroups(groups map[string]*keyGroup, scale float64, limit int) []keyGroup {
+	ranked := make([]keyGroup, 0, len(groups))
+	for _, group := range groups {
+		ranked = append(ranked, *group)
+	}
+	sort.Slice(ranked, func(i, j int) bool {
+		if ranked[i].SampledKeys != ranked[j].SampledKeys {
+			return ranked[i].SampledKeys > ranked[j].SampledKeys
+		}
+		return ranked[i].Name < ranked[j].Name
+	})
+
+	if len(ranked) > limit {
+		other := keyGroup{Name: otherKeysGroup}
+		for _, group := range ranked[limit:] {
+			other.SampledKeys += group.SampledKeys
+			other.SampledBytes += group.SampledBytes
+		}
+		ranked = append(ranked[:limit], other)
+	}
+	for i := range ranked {
+		ranked[i].EstimatedKeys = int64(float64(ranked[i].SampledKeys)*scale + 0.5)
+	}
+	return ranked
+}
+
+func summarizeMemoryUsage(start, end time.Time, data metricstypes.TimeSeriesCollection) *memoryUsageSummary {
+	var (
+		summary = &memoryUsageSummary{Start: start, End: end}
+		latest  time.Time
+		found   bool
+	)
+	for _, series := range data {
+		for _, v := range series.Values {
+			found = true
+			summary.Unit = series.Unit
+			if v.Value > summary.Peak {
+				summary.Peak = v.Value
+			}
+			if !v.Timestamp.Before(latest) {
+				latest = v.Timestamp
+				summary.Latest = v.Value
+			}
+		}
+	}
+	if !found {
+		return nil
+	}
+	return summary
+}
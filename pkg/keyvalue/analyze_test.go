@@ -0,0 +1,116 @@
+package keyvalue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunAnalysisSamplesKeyspace(t *testing.T) {
+	server := miniredis.RunT(t)
+	for i := 0; i < 30; i++ {
+		require.NoError(t, server.Set(fmt.Sprintf("session:%d", i), "x"))
+		server.SetTTL(fmt.Sprintf("session:%d", i), time.Hour)
+	}
+	for i := 0; i < 10; i++ {
+		server.HSet(fmt.Sprintf("user:%d", i), "name", "someone")
+	}
+	require.NoError(t, server.Set("report", strings.Repeat("r", 10000)))
+
+	rdb, err := newKeyValueClient("redis://" + server.Addr())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	analysis := runAnalysis(context.Background(), rdb, defaultAnalyzeSampleKeys, ":")
+
+	// miniredis doesn't implement INFO memory or keyspace, which is reported
+	// without failing the sample
+	assert.NotEmpty(t, analysis.Errors)
+	require.NotNil(t, analysis.Sample)
+
+	sample := analysis.Sample
+	assert.Equal(t, int64(41), sample.TotalKeys)
+	assert.Equal(t, 41, sample.SampledKeys)
+	assert.True(t, sample.Complete)
+	assert.Equal(t, 11, sample.KeysWithoutExpiry)
+
+	require.Len(t, sample.Prefixes, 3)
+	assert.Equal(t, keyGroup{Name: "session:", SampledKeys: 30, EstimatedKeys: 30, SampledBytes: sample.Prefixes[0].SampledBytes},
+		sample.Prefixes[0])
+	assert.Equal(t, "user:", sample.Prefixes[1].Name)
+	assert.Equal(t, noPrefixGroup, sample.Prefixes[2].Name)
+
+	require.Len(t, sample.Types, 2)
+	assert.Equal(t, "string", sample.Types[0].Name)
+	assert.Equal(t, 31, sample.Types[0].SampledKeys)
+
+	require.Len(t, sample.BiggestKeys, maxBiggestKeys)
+	assert.Equal(t, "report", sample.BiggestKeys[0].Key)
+	assert.Nil(t, sample.BiggestKeys[0].TTLSeconds)
+}
+
+func TestRunAnalysisEstimatesFromPartialSample(t *testing.T) {
+	server := miniredis.RunT(t)
+	for i := 0; i < 500; i++ {
+		require.NoError(t, server.Set(fmt.Sprintf("job:%d", i), "x"))
+	}
+
+	rdb, err := newKeyValueClient("redis://" + server.Addr())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	analysis := runAnalysis(context.Background(), rdb, 100, ":")
+	require.NotNil(t, analysis.Sample)
+	assert.False(t, analysis.Sample.Complete)
+	assert.Equal(t, 100, analysis.Sample.SampledKeys)
+	require.Len(t, analysis.Sample.Prefixes, 1)
+	assert.Equal(t, int64(500), analysis.Sample.Prefixes[0].EstimatedKeys)
+}
+
+func TestSummarizeMemory(t *testing.T) {
+	info := parseInfo("# Memory\r\nused_memory:75000000\r\nmaxmemory:100000000\r\nmaxmemory_policy:noeviction\r\n" +
+		"mem_fragmentation_ratio:1.25\r\n# Stats\r\nevicted_keys:0\r\nexpired_keys:12\r\n" +
+		"keyspace_hits:90\r\nkeyspace_misses:10\r\n")
+
+	summary := summarizeMemory(info)
+	require.NotNil(t, summary)
+	assert.Equal(t, int64(75000000), summary.UsedMemoryBytes)
+	require.NotNil(t, summary.UsedRatio)
+	assert.InDelta(t, 0.75, *summary.UsedRatio, 1e-9)
+	assert.Equal(t, "noeviction", summary.MaxmemoryPolicy)
+	require.NotNil(t, summary.FragmentationRatio)
+	assert.InDelta(t, 1.25, *summary.FragmentationRatio, 1e-9)
+	assert.Equal(t, int64(12), summary.ExpiredKeys)
+	require.NotNil(t, summary.HitRatio)
+	assert.InDelta(t, 0.9, *summary.HitRatio, 1e-9)
+
+	assert.Nil(t, summarizeMemory(map[string]map[string]string{}))
+}
+
+func TestRankGroupsFoldsTail(t *testing.T) {
+	groups := map[string]*keyGroup{
+		"a:": {Name: "a:", SampledKeys: 5, SampledBytes: 50},
+		"b:": {Name: "b:", SampledKeys: 3, SampledBytes: 30},
+		"c:": {Name: "c:", SampledKeys: 1, SampledBytes: 10},
+		"d:": {Name: "d:", SampledKeys: 1, SampledBytes: 10},
+	}
+
+	assert.Equal(t, []keyGroup{
+		{Name: "a:", SampledKeys: 5, EstimatedKeys: 10, SampledBytes: 50},
+		{Name: "b:", SampledKeys: 3, EstimatedKeys: 6, SampledBytes: 30},
+		{Name: otherKeysGroup, SampledKeys: 2, EstimatedKeys: 4, SampledBytes: 20},
+	}, rankGroups(groups, 2, 2))
+}
+
+func TestKeyPrefix(t *testing.T) {
+	assert.Equal(t, "user:", keyPrefix("user:1:profile", ":"))
+	assert.Equal(t, "cache/", keyPrefix("cache/home", "/"))
+	assert.Equal(t, noPrefixGroup, keyPrefix("counter", ":"))
+	assert.Equal(t, noPrefixGroup, keyPrefix("user:1", ""))
+}
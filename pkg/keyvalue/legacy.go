@@ -0,0 +1,101 @@
+package keyvalue
+
+import (
+	"fmt"
+
+	"github.com/render-oss/render-mcp-server/pkg/client"
+)
+
+// KeyValueListItem is a Key Value instance as list_key_value reports it.
+type KeyValueListItem struct {
+	client.KeyValue
+	// Legacy is set for Redis instances created before Key Value, which are
+	// served by separate API endpoints.
+	Legacy bool `json:"legacy,omitempty"`
+}
+
+// KeyValueInstance is a Key Value instance as the other tools report it.
+type KeyValueInstance struct {
+	client.KeyValueDetail
+	Legacy bool `json:"legacy,omitempty"`
+}
+
+// The legacy Redis types have the same fields as their Key Value
+// counterparts, so legacy instances are converted rather than reported in a
+// second shape.
+
+func keyValueFromRedis(redis client.Redis) client.KeyValue {
+	return client.KeyValue{
+		CreatedAt:     redis.CreatedAt,
+		DashboardUrl:  redis.DashboardUrl,
+		EnvironmentId: redis.EnvironmentId,
+		Id:            redis.Id,
+		IpAllowList:   redis.IpAllowList,
+		Name:          redis.Name,
+		Options:       client.KeyValueOptions(redis.Options),
+		Owner:         redis.Owner,
+		Plan:          client.KeyValuePlan(redis.Plan),
+		Region:        redis.Region,
+		Status:        redis.Status,
+		UpdatedAt:     redis.UpdatedAt,
+		Version:       redis.Version,
+	}
+}
+
+func keyValueDetailFromRedis(redis *client.RedisDetail) client.KeyValueDetail {
+	return client.KeyValueDetail{
+		CreatedAt:     redis.CreatedAt,
+		EnvironmentId: redis.EnvironmentId,
+		Id:            redis.Id,
+		IpAllowList:   redis.IpAllowList,
+		Maintenance:   redis.Maintenance,
+		Name:          redis.Name,
+		Options:       client.KeyValueOptions(redis.Options),
+		Owner:         redis.Owner,
+		Plan:          client.KeyValuePlan(redis.Plan),
+		Region:        redis.Region,
+		Status:        redis.Status,
+		UpdatedAt:     redis.UpdatedAt,
+		Version:       redis.Version,
+	}
+}
+
+// redisPlans maps each Key Value plan to the legacy Redis plan of the same
+// size.
+var redisPlans = map[client.KeyValuePlan]client.RedisPlan{
+	client.KeyValuePlanFree:     client.RedisPlanFree,
+	client.KeyValuePlanStarter:  client.RedisPlanStarter,
+	client.KeyValuePlanStandard: client.RedisPlanStandard,
+	client.KeyValuePlanPro:      client.RedisPlanPro,
+	client.KeyValuePlanProPlus:  client.RedisPlanProPlus,
+	client.KeyValuePlanCustom:   client.RedisPlanCustom,
+}
+
+func redisPatchFromKeyValue(input client.KeyValuePATCHInput) (client.RedisPATCHInput, error) {
+	patch := client.RedisPATCHInput{
+		IpAllowList:     input.IpAllowList,
+		MaxmemoryPolicy: input.MaxmemoryPolicy,
+		Name:            input.Name,
+		PersistenceMode: input.PersistenceMode,
+	}
+	if input.Plan != nil {
+		plan, ok := redisPlans[*input.Plan]
+		if !ok {
+			return client.RedisPATCHInput{}, fmt.Errorf("plan %q isn't available for legacy Redis instances", *input.Plan)
+		}
+		patch.Plan = &plan
+	}
+	return patch, nil
+}
+
+func connectionInfoFromRedis(info *client.RedisConnectionInfo) *client.KeyValueConnectionInfo {
+	return &client.KeyValueConnectionInfo{
+		CliCommand:               info.RedisCLICommand,
+		ExternalConnectionString: info.ExternalConnectionString,
+		InternalConnectionString: info.InternalConnectionString,
+	}
+}
+
+func errLegacyRedisUnsupported(action string) error {
+	return fmt.Errorf("%s legacy Redis instances isn't supported by the Render API", action)
+}
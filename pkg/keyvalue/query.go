@@ -0,0 +1,427 @@
+package keyvalue
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// commandTimeout bounds each round trip to a Key Value instance.
+	commandTimeout = 10 * time.Second
+	// maxMGetKeys caps how many keys one MGET may read.
+	maxMGetKeys = 100
+	// maxCollectionItems caps the elements returned from a list, sorted set
+	// or hash. Larger ranges are cut short and flagged as truncated.
+	maxCollectionItems = 1000
+	// defaultScanCount and maxScanCount are the COUNT hint SCAN uses when
+	// none is given, and the largest one allowed.
+	defaultScanCount = 100
+	maxScanCount     = 1000
+	// maxValueBytes truncates individual string values so that one large
+	// value doesn't flood the agent's context.
+	maxValueBytes = 16 << 10
+)
+
+// allowedCommands are the only commands query_render_key_value runs. They
+// are all read-only and bounded.
+var allowedCommands = []string{"GET", "MGET", "HGETALL", "LRANGE", "ZRANGE", "TTL", "TYPE", "SCAN", "INFO"}
+
+type commandResult struct {
+	Command   string `json:"command"`
+	Result    any    `json:"result"`
+	Truncated bool   `json:"truncated,omitempty"`
+	Note      string `json:"note,omitempty"`
+}
+
+type scanResult struct {
+	Cursor string   `json:"cursor"`
+	Keys   []string `json:"keys"`
+}
+
+type sortedSetMember struct {
+	Member string  `json:"member"`
+	Score  float64 `json:"score"`
+}
+
+// newKeyValueClient connects to a Key Value instance with RESP2, whose
+// replies map directly onto JSON.
+func newKeyValueClient(connString string) (*redis.Client, error) {
+	opts, err := redis.ParseURL(connString)
+	if err != nil {
+		return nil, fmt.Errorf("error parsing connection string: %w", err)
+	}
+	opts.Protocol = 2
+	opts.DisableIdentity = true
+	opts.DialTimeout = commandTimeout
+	opts.ReadTimeout = commandTimeout
+	opts.WriteTimeout = commandTimeout
+	opts.PoolSize = 1
+	return redis.NewClient(opts), nil
+}
+
+// runCommand runs one allowlisted command. Anything not on the allowlist is
+// rejected before it reaches the server.
+func runCommand(ctx context.Context, rdb redis.Cmdable, command string, args []string) (*commandResult, error) {
+	name := strings.ToUpper(strings.TrimSpace(command))
+	result := &commandResult{Command: name}
+
+	var err error
+	switch name {
+	case "GET":
+		if err = checkArgCount(name, args, 1, 1); err == nil {
+			err = runGet(ctx, rdb, args[0], result)
+		}
+	case "MGET":
+		if err = checkArgCount(name, args, 1, maxMGetKeys); err == nil {
+			err = runMGet(ctx, rdb, args, result)
+		}
+	case "HGETALL":
+		if err = checkArgCount(name, args, 1, 1); err == nil {
+			err = runHGetAll(ctx, rdb, args[0], result)
+		}
+	case "LRANGE":
+		if err = checkArgCount(name, args, 3, 3); err == nil {
+			err = runLRange(ctx, rdb, args, result)
+		}
+	case "ZRANGE":
+		if err = checkArgCount(name, args, 3, 5); err == nil {
+			err = runZRange(ctx, rdb, args, result)
+		}
+	case "TTL":
+		if err = checkArgCount(name, args, 1, 1); err == nil {
+			err = runTTL(ctx, rdb, args[0], result)
+		}
+	case "TYPE":
+		if err = checkArgCount(name, args, 1, 1); err == nil {
+			result.Result, err = rdb.Type(ctx, args[0]).Result()
+		}
+	case "SCAN":
+		if err = checkArgCount(name, args, 1, 7); err == nil {
+			err = runScan(ctx, rdb, args, result)
+		}
+	case "INFO":
+		var info string
+		if info, err = rdb.Info(ctx, args...).Result(); err == nil {
+			result.Result = parseInfo(info)
+		}
+	default:
+		return nil, commandNotAllowedError(command)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func commandNotAllowedError(command string) error {
+	return fmt.Errorf("command %q is not allowed; only these read-only commands are: %s",
+		command, strings.Join(allowedCommands, ", "))
+}
+
+func checkArgCount(name string, args []string, min, max int) error {
+	if len(args) < min || len(args) > max {
+		if min == max {
+			return fmt.Errorf("%s takes %d arguments, got %d", name, min, len(args))
+		}
+		return fmt.Errorf("%s takes %d to %d arguments, got %d", name, min, max, len(args))
+	}
+	return nil
+}
+
+func runGet(ctx context.Context, rdb redis.Cmdable, key string, result *commandResult) error {
+	value, err := rdb.Get(ctx, key).Result()
+	if errors.Is(err, redis.Nil) {
+		result.Note = "The key does not exist."
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	result.Result = truncateValue(value, result)
+	return nil
+}
+
+func runMGet(ctx context.Context, rdb redis.Cmdable, keys []string, result *commandResult) error {
+	values, err := rdb.MGet(ctx, keys...).Result()
+	if err != nil {
+		return err
+	}
+	for i, v := range values {
+		if s, ok := v.(string); ok {
+			values[i] = truncateValue(s, result)
+		}
+	}
+	result.Result = values
+	return nil
+}
+
+// runHGetAll reads a hash with HGETALL when it is small enough, and otherwise
+// reads the first maxCollectionItems fields it finds with HSCAN.
+func runHGetAll(ctx context.Context, rdb redis.Cmdable, key string, result *commandResult) error {
+	size, err := rdb.HLen(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+
+	fields := map[string]string{}
+	if size <= maxCollectionItems {
+		if fields, err = rdb.HGetAll(ctx, key).Result(); err != nil {
+			return err
+		}
+	} else {
+		var cursor uint64
+		for len(fields) < maxCollectionItems {
+			var page []string
+			page, cursor, err = rdb.HScan(ctx, key, cursor, "", defaultScanCount).Result()
+			if err != nil {
+				return err
+			}
+			for i := 0; i+1 < len(page) && len(fields) < maxCollectionItems; i += 2 {
+				fields[page[i]] = page[i+1]
+			}
+			if cursor == 0 {
+				break
+			}
+		}
+		result.Truncated = true
+		result.Note = fmt.Sprintf("The hash has %d fields; only %d are returned.", size, len(fields))
+	}
+
+	for field, value := range fields {
+		fields[field] = truncateValue(value, result)
+	}
+	result.Result = fields
+	return nil
+}
+
+func runLRange(ctx context.Context, rdb redis.Cmdable, args []string, result *commandResult) error {
+	start, stop, err := parseRange(args[1], args[2])
+	if err != nil {
+		return err
+	}
+	length, err := rdb.LLen(ctx, args[0]).Result()
+	if err != nil {
+		return err
+	}
+	start, stop = clampRange(start, stop, length, result)
+	if start > stop {
+		result.Result = []string{}
+		return nil
+	}
+
+	items, err := rdb.LRange(ctx, args[0], start, stop).Result()
+	if err != nil {
+		return err
+	}
+	for i, item := range items {
+		items[i] = truncateValue(item, result)
+	}
+	result.Result = items
+	return nil
+}
+
+// runZRange supports ranges by index, optionally with WITHSCORES and REV.
+func runZRange(ctx context.Context, rdb redis.Cmdable, args []string, result *commandResult) error {
+	start, stop, err := parseRange(args[1], args[2])
+	if err != nil {
+		return err
+	}
+	withScores, rev := false, false
+	for _, opt := range args[3:] {
+		switch strings.ToUpper(opt) {
+		case "WITHSCORES":
+			withScores = true
+		case "REV":
+			rev = true
+		default:
+			return fmt.Errorf("ZRANGE option %q is not supported; only ranges by index with WITHSCORES and REV are", opt)
+		}
+	}
+
+	length, err := rdb.ZCard(ctx, args[0]).Result()
+	if err != nil {
+		return err
+	}
+	start, stop = clampRange(start, stop, length, result)
+	if start > stop {
+		result.Result = []string{}
+		return nil
+	}
+
+	zrange := redis.ZRangeArgs{Key: args[0], Start: start, Stop: stop, Rev: rev}
+	if !withScores {
+		members, err := rdb.ZRangeArgs(ctx, zrange).Result()
+		if err != nil {
+			return err
+		}
+		for i, m := range members {
+			members[i] = truncateValue(m, result)
+		}
+		result.Result = members
+		return nil
+	}
+
+	zs, err := rdb.ZRangeArgsWithScores(ctx, zrange).Result()
+	if err != nil {
+		return err
+	}
+	members := make([]sortedSetMember, len(zs))
+	for i, z := range zs {
+		member, _ := z.Member.(string)
+		members[i] = sortedSetMember{Member: truncateValue(member, result), Score: z.Score}
+	}
+	result.Result = members
+	return nil
+}
+
+func runScan(ctx context.Context, rdb redis.Cmdable, args []string, result *commandResult) error {
+	cursor, err := strconv.ParseUint(args[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid SCAN cursor %q", args[0])
+	}
+
+	var match, keyType string
+	count := int64(defaultScanCount)
+	opts := args[1:]
+	for i := 0; i < len(opts); i += 2 {
+		if i+1 >= len(opts) {
+			return fmt.Errorf("SCAN option %s needs a value", opts[i])
+		}
+		value := opts[i+1]
+		switch strings.ToUpper(opts[i]) {
+		case "MATCH":
+			match = value
+		case "COUNT":
+			if count, err = strconv.ParseInt(value, 10, 64); err != nil || count < 1 {
+				return fmt.Errorf("invalid SCAN COUNT %q", value)
+			}
+			if count > maxScanCount {
+				return fmt.Errorf("SCAN COUNT is capped at %d", maxScanCount)
+			}
+		case "TYPE":
+			keyType = value
+		default:
+			return fmt.Errorf("unknown SCAN option %q; MATCH, COUNT and TYPE are supported", opts[i])
+		}
+	}
+
+	keys, next, err := rdb.ScanType(ctx, cursor, match, count, keyType).Result()
+	if err != nil {
+		return err
+	}
+	result.Result = scanResult{Cursor: strconv.FormatUint(next, 10), Keys: keys}
+	if next != 0 {
+		result.Note = "Pass the returned cursor to SCAN to continue; a cursor of 0 means the scan is complete."
+	}
+	return nil
+}
+
+func parseRange(startArg, stopArg string) (int64, int64, error) {
+	start, err := strconv.ParseInt(startArg, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid start index %q", startArg)
+	}
+	stop, err := strconv.ParseInt(stopArg, 10, 64)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid stop index %q", stopArg)
+	}
+	return start, stop, nil
+}
+
+// clampRange resolves negative indexes against length the way Redis does,
+// then shortens the range to maxCollectionItems elements.
+func clampRange(start, stop, length int64, result *commandResult) (int64, int64) {
+	if start < 0 {
+		start += length
+	}
+	if start < 0 {
+		start = 0
+	}
+	if stop < 0 {
+		stop += length
+	}
+	if stop >= length {
+		stop = length - 1
+	}
+	if stop-start+1 > maxCollectionItems {
+		result.Truncated = true
+		result.Note = fmt.Sprintf("The range covers %d of %d elements; only the first %d are returned. "+
+			"Request the rest in smaller ranges.", stop-start+1, length, maxCollectionItems)
+		stop = start + maxCollectionItems - 1
+	}
+	return start, stop
+}
+
+func truncateValue(value string, result *commandResult) string {
+	if len(value) <= maxValueBytes {
+		return value
+	}
+	result.Truncated = true
+	if result.Note == "" {
+		result.Note = fmt.Sprintf("Values longer than %d bytes are cut short.", maxValueBytes)
+	}
+	// Cut at a character boundary so text values stay valid UTF-8. Binary
+	// values aren't backed off by more than a character's worth of bytes.
+	n := maxValueBytes
+	for n > maxValueBytes-utf8.UTFMax && !utf8.RuneStart(value[n]) {
+		n--
+	}
+	if !utf8.RuneStart(value[n]) {
+		n = maxValueBytes
+	}
+	return value[:n]
+}
+
+// runTTL reports the TTL in seconds, keeping the -1 and -2 replies for keys
+// without an expiry and missing keys.
+func runTTL(ctx context.Context, rdb redis.Cmdable, key string, result *commandResult) error {
+	ttl, err := rdb.TTL(ctx, key).Result()
+	if err != nil {
+		return err
+	}
+	// go-redis passes the special replies through as these raw durations
+	switch ttl {
+	case -2:
+		result.Result, result.Note = -2, "The key does not exist."
+	case -1:
+		result.Result, result.Note = -1, "The key has no expiry."
+	default:
+		result.Result = int64(ttl / time.Second)
+	}
+	return nil
+}
+
+// parseInfo parses an INFO reply into its sections, keyed by the lowercased
+// section name.
+func parseInfo(info string) map[string]map[string]string {
+	sections := map[string]map[string]string{}
+	current := ""
+	scanner := bufio.NewScanner(strings.NewReader(info))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			current = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(line, "#")))
+			continue
+		}
+		key, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		if sections[current] == nil {
+			sections[current] = map[string]string{}
+		}
+		sections[current][key] = value
+	}
+	return sections
+}
@@ -0,0 +1,167 @@
+package keyvalue
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestKeyValue(t *testing.T) (*miniredis.Miniredis, func(command string, args ...string) (*commandResult, error)) {
+	t.Helper()
+	server := miniredis.RunT(t)
+	rdb, err := newKeyValueClient("redis://" + server.Addr())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = rdb.Close() })
+
+	return server, func(command string, args ...string) (*commandResult, error) {
+		return runCommand(context.Background(), rdb, command, args)
+	}
+}
+
+func TestRunCommandReads(t *testing.T) {
+	server, run := newTestKeyValue(t)
+	require.NoError(t, server.Set("user:1", "alice"))
+	server.SetTTL("user:1", time.Minute)
+	server.HSet("session:1", "user", "1", "theme", "dark")
+	_, err := server.ZAdd("scores", 2, "bob")
+	require.NoError(t, err)
+	_, err = server.ZAdd("scores", 1, "alice")
+	require.NoError(t, err)
+
+	result, err := run("get", "user:1")
+	require.NoError(t, err)
+	assert.Equal(t, "GET", result.Command)
+	assert.Equal(t, "alice", result.Result)
+
+	result, err = run("GET", "missing")
+	require.NoError(t, err)
+	assert.Nil(t, result.Result)
+	assert.Equal(t, "The key does not exist.", result.Note)
+
+	result, err = run("MGET", "user:1", "missing")
+	require.NoError(t, err)
+	assert.Equal(t, []any{"alice", nil}, result.Result)
+
+	result, err = run("HGETALL", "session:1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"user": "1", "theme": "dark"}, result.Result)
+
+	result, err = run("ZRANGE", "scores", "0", "-1", "WITHSCORES")
+	require.NoError(t, err)
+	assert.Equal(t, []sortedSetMember{{Member: "alice", Score: 1}, {Member: "bob", Score: 2}}, result.Result)
+
+	result, err = run("TTL", "user:1")
+	require.NoError(t, err)
+	assert.Equal(t, int64(60), result.Result)
+
+	result, err = run("TTL", "session:1")
+	require.NoError(t, err)
+	assert.Equal(t, -1, result.Result)
+
+	result, err = run("TYPE", "session:1")
+	require.NoError(t, err)
+	assert.Equal(t, "hash", result.Result)
+}
+
+func TestRunCommandRejectsWrites(t *testing.T) {
+	server, run := newTestKeyValue(t)
+	require.NoError(t, server.Set("user:1", "alice"))
+
+	for _, command := range []string{"SET", "DEL", "FLUSHALL", "CONFIG", "KEYS", "EVAL", "HSET"} {
+		_, err := run(command, "user:1", "bob")
+		require.Error(t, err, command)
+		assert.Contains(t, err.Error(), "is not allowed")
+	}
+	got, err := server.Get("user:1")
+	require.NoError(t, err)
+	assert.Equal(t, "alice", got)
+
+	_, err = run("GET", "a", "b")
+	assert.ErrorContains(t, err, "GET takes 1 arguments, got 2")
+
+	_, err = run("ZRANGE", "scores", "0", "10", "BYSCORE")
+	assert.ErrorContains(t, err, "not supported")
+}
+
+func TestRunCommandLRangeIsCapped(t *testing.T) {
+	server, run := newTestKeyValue(t)
+	for i := 0; i < maxCollectionItems+10; i++ {
+		_, err := server.RPush("jobs", fmt.Sprintf("job-%d", i))
+		require.NoError(t, err)
+	}
+
+	result, err := run("LRANGE", "jobs", "0", "-1")
+	require.NoError(t, err)
+	assert.True(t, result.Truncated)
+	assert.Len(t, result.Result, maxCollectionItems)
+	assert.Equal(t, "job-0", result.Result.([]string)[0])
+
+	result, err = run("LRANGE", "jobs", "-2", "-1")
+	require.NoError(t, err)
+	assert.False(t, result.Truncated)
+	assert.Equal(t, []string{"job-1008", "job-1009"}, result.Result)
+}
+
+func TestRunCommandHGetAllLargeHash(t *testing.T) {
+	server, run := newTestKeyValue(t)
+	for i := 0; i < maxCollectionItems+5; i++ {
+		server.HSet("big", fmt.Sprintf("f%d", i), "v")
+	}
+
+	result, err := run("HGETALL", "big")
+	require.NoError(t, err)
+	assert.True(t, result.Truncated)
+	assert.Len(t, result.Result, maxCollectionItems)
+}
+
+func TestRunCommandScan(t *testing.T) {
+	server, run := newTestKeyValue(t)
+	require.NoError(t, server.Set("session:1", "a"))
+	require.NoError(t, server.Set("session:2", "b"))
+	require.NoError(t, server.Set("user:1", "c"))
+
+	result, err := run("SCAN", "0", "MATCH", "session:*", "COUNT", "100")
+	require.NoError(t, err)
+	scan := result.Result.(scanResult)
+	assert.Equal(t, "0", scan.Cursor)
+	assert.ElementsMatch(t, []string{"session:1", "session:2"}, scan.Keys)
+
+	_, err = run("SCAN", "0", "COUNT", "100000")
+	assert.ErrorContains(t, err, "capped")
+
+	_, err = run("SCAN", "0", "COUNT")
+	assert.ErrorContains(t, err, "needs a value")
+}
+
+func TestRunCommandTruncatesLargeValues(t *testing.T) {
+	server, run := newTestKeyValue(t)
+	require.NoError(t, server.Set("blob", strings.Repeat("x", maxValueBytes+1)))
+
+	result, err := run("GET", "blob")
+	require.NoError(t, err)
+	assert.True(t, result.Truncated)
+	assert.Len(t, result.Result, maxValueBytes)
+
+	// A multi-byte character straddling the limit is left out whole
+	require.NoError(t, server.Set("text", strings.Repeat("x", maxValueBytes-1)+"é"))
+	result, err = run("GET", "text")
+	require.NoError(t, err)
+	assert.True(t, result.Truncated)
+	assert.Equal(t, strings.Repeat("x", maxValueBytes-1), result.Result)
+	assert.True(t, utf8.ValidString(result.Result.(string)))
+}
+
+func TestParseInfo(t *testing.T) {
+	info := "# Server\r\nredis_version:7.2.4\r\n\r\n# Keyspace\r\ndb0:keys=3,expires=1,avg_ttl=0\r\n"
+	assert.Equal(t, map[string]map[string]string{
+		"server":   {"redis_version": "7.2.4"},
+		"keyspace": {"db0": "keys=3,expires=1,avg_ttl=0"},
+	}, parseInfo(info))
+}
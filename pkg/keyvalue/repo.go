@@ -2,6 +2,7 @@ package keyvalue
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/render-oss/render-mcp-server/pkg/client"
 	"github.com/render-oss/render-mcp-server/pkg/session"
@@ -14,6 +15,15 @@ type keyValueRepoClient interface {
 	RetrieveKeyValueWithResponse(ctx context.Context, id string, reqEditors ...client.RequestEditorFn) (*client.RetrieveKeyValueResponse, error)
 	RetrieveKeyValueConnectionInfoWithResponse(ctx context.Context, id string, reqEditors ...client.RequestEditorFn) (*client.RetrieveKeyValueConnectionInfoResponse, error)
 	CreateKeyValueWithResponse(ctx context.Context, body client.KeyValuePOSTInput, reqEditors ...client.RequestEditorFn) (*client.CreateKeyValueResponse, error)
+	UpdateKeyValueWithResponse(ctx context.Context, id string, body client.KeyValuePATCHInput, reqEditors ...client.RequestEditorFn) (*client.UpdateKeyValueResponse, error)
+	SuspendKeyValueWithResponse(ctx context.Context, id string, reqEditors ...client.RequestEditorFn) (*client.SuspendKeyValueResponse, error)
+	ResumeKeyValueWithResponse(ctx context.Context, id string, reqEditors ...client.RequestEditorFn) (*client.ResumeKeyValueResponse, error)
+	DeleteKeyValueWithResponse(ctx context.Context, id string, reqEditors ...client.RequestEditorFn) (*client.DeleteKeyValueResponse, error)
+	ListRedisWithResponse(ctx context.Context, params *client.ListRedisParams, reqEditors ...client.RequestEditorFn) (*client.ListRedisResponse, error)
+	RetrieveRedisWithResponse(ctx context.Context, id string, reqEditors ...client.RequestEditorFn) (*client.RetrieveRedisResponse, error)
+	RetrieveRedisConnectionInfoWithResponse(ctx context.Context, id string, reqEditors ...client.RequestEditorFn) (*client.RetrieveRedisConnectionInfoResponse, error)
+	UpdateRedisWithResponse(ctx context.Context, id string, body client.RedisPATCHInput, reqEditors ...client.RequestEditorFn) (*client.UpdateRedisResponse, error)
+	DeleteRedisWithResponse(ctx context.Context, id string, reqEditors ...client.RequestEditorFn) (*client.DeleteRedisResponse, error)
 }
 
 type Repo struct {
@@ -26,39 +36,101 @@ func NewRepo(c keyValueRepoClient) *Repo {
 	}
 }
 
-func (r *Repo) ListKeyValue(ctx context.Context, params *client.ListKeyValueParams) ([]*client.KeyValue, error) {
+// ListKeyValue lists the Key Value instances in the current workspace.
+func (r *Repo) ListKeyValue(ctx context.Context, params *client.ListKeyValueParams) ([]*KeyValueListItem, error) {
 	workspace, err := session.FromContext(ctx).GetWorkspace(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	params.OwnerId = &client.OwnerIdParam{workspace}
+	return client.ListAll(ctx, params, r.listKeyValuePage)
+}
 
+func (r *Repo) listKeyValuePage(ctx context.Context, params *client.ListKeyValueParams) ([]*KeyValueListItem, *client.Cursor, error) {
 	resp, err := r.client.ListKeyValueWithResponse(ctx, params)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	if err := client.ErrorFromResponse(resp); err != nil {
+		return nil, nil, err
+	}
+	if resp.JSON200 == nil || len(*resp.JSON200) == 0 {
+		return nil, nil, nil
 	}
 
-	res, err := client.BodyFromResponse(resp.JSON200, resp)
+	res := *resp.JSON200
+	kvs := make([]*KeyValueListItem, 0, len(res))
+	for _, kv := range res {
+		kvs = append(kvs, &KeyValueListItem{KeyValue: kv.KeyValue})
+	}
+
+	return kvs, &res[len(res)-1].Cursor, nil
+}
+
+// ListLegacyRedis lists the legacy Redis instances in the current workspace
+// as Key Value instances.
+func (r *Repo) ListLegacyRedis(ctx context.Context, params *client.ListRedisParams) ([]*KeyValueListItem, error) {
+	workspace, err := session.FromContext(ctx).GetWorkspace(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	kvs := make([]*client.KeyValue, 0, len(*res))
-	for _, kv := range *res {
-		kvs = append(kvs, &kv.KeyValue)
+	params.OwnerId = &client.OwnerIdParam{workspace}
+	return client.ListAll(ctx, params, r.listRedisPage)
+}
+
+func (r *Repo) listRedisPage(ctx context.Context, params *client.ListRedisParams) ([]*KeyValueListItem, *client.Cursor, error) {
+	resp, err := r.client.ListRedisWithResponse(ctx, params)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := client.ErrorFromResponse(resp); err != nil {
+		return nil, nil, err
+	}
+	if resp.JSON200 == nil || len(*resp.JSON200) == 0 {
+		return nil, nil, nil
 	}
 
-	return kvs, nil
+	res := *resp.JSON200
+	kvs := make([]*KeyValueListItem, 0, len(res))
+	for _, redis := range res {
+		kvs = append(kvs, &KeyValueListItem{KeyValue: keyValueFromRedis(redis.Redis), Legacy: true})
+	}
+
+	return kvs, &res[len(res)-1].Cursor, nil
 }
 
-func (r *Repo) GetKeyValue(ctx context.Context, id string) (*client.KeyValueDetail, error) {
+// GetKeyValue retrieves a Key Value instance. Legacy Redis instances aren't
+// served by the Key Value endpoint, so when it reports the ID as not found
+// the Redis endpoint is tried next.
+func (r *Repo) GetKeyValue(ctx context.Context, id string) (*KeyValueInstance, error) {
 	resp, err := r.client.RetrieveKeyValueWithResponse(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	return client.BodyFromResponse(resp.JSON200, resp)
+	if resp.StatusCode() == http.StatusNotFound {
+		redisResp, err := r.client.RetrieveRedisWithResponse(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if redisResp.StatusCode() != http.StatusNotFound {
+			redis, err := client.BodyFromResponse(redisResp.JSON200, redisResp)
+			if err != nil {
+				return nil, err
+			}
+			return &KeyValueInstance{KeyValueDetail: keyValueDetailFromRedis(redis), Legacy: true}, nil
+		}
+	}
+
+	keyValue, err := client.BodyFromResponse(resp.JSON200, resp)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyValueInstance{KeyValueDetail: *keyValue}, nil
 }
 
 func (r *Repo) CreateKeyValue(ctx context.Context, input client.KeyValuePOSTInput) (*client.KeyValueDetail, error) {
@@ -73,3 +145,123 @@ func (r *Repo) CreateKeyValue(ctx context.Context, input client.KeyValuePOSTInpu
 
 	return client.BodyFromResponse(resp.JSON201, resp)
 }
+
+// UpdateKeyValue updates keyValue through the endpoint matching its kind.
+func (r *Repo) UpdateKeyValue(ctx context.Context, keyValue *KeyValueInstance, input client.KeyValuePATCHInput) (*KeyValueInstance, error) {
+	if keyValue.Legacy {
+		patch, err := redisPatchFromKeyValue(input)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := r.client.UpdateRedisWithResponse(ctx, keyValue.Id, patch)
+		if err != nil {
+			return nil, err
+		}
+		redis, err := client.BodyFromResponse(resp.JSON200, resp)
+		if err != nil {
+			return nil, err
+		}
+		return &KeyValueInstance{KeyValueDetail: keyValueDetailFromRedis(redis), Legacy: true}, nil
+	}
+
+	resp, err := r.client.UpdateKeyValueWithResponse(ctx, keyValue.Id, input)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := client.BodyFromResponse(resp.JSON200, resp)
+	if err != nil {
+		return nil, err
+	}
+	return &KeyValueInstance{KeyValueDetail: *updated}, nil
+}
+
+func (r *Repo) SuspendKeyValue(ctx context.Context, id string) error {
+	keyValue, err := r.GetKeyValueInWorkspace(ctx, id)
+	if err != nil {
+		return err
+	}
+	if keyValue.Legacy {
+		return errLegacyRedisUnsupported("suspending")
+	}
+
+	resp, err := r.client.SuspendKeyValueWithResponse(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return client.ErrorFromResponse(resp)
+}
+
+func (r *Repo) ResumeKeyValue(ctx context.Context, id string) error {
+	keyValue, err := r.GetKeyValueInWorkspace(ctx, id)
+	if err != nil {
+		return err
+	}
+	if keyValue.Legacy {
+		return errLegacyRedisUnsupported("resuming")
+	}
+
+	resp, err := r.client.ResumeKeyValueWithResponse(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return client.ErrorFromResponse(resp)
+}
+
+func (r *Repo) DeleteKeyValue(ctx context.Context, id string) error {
+	keyValue, err := r.GetKeyValueInWorkspace(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if keyValue.Legacy {
+		resp, err := r.client.DeleteRedisWithResponse(ctx, id)
+		if err != nil {
+			return err
+		}
+		return client.ErrorFromResponse(resp)
+	}
+
+	resp, err := r.client.DeleteKeyValueWithResponse(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return client.ErrorFromResponse(resp)
+}
+
+func (r *Repo) GetKeyValueConnectionInfo(ctx context.Context, keyValue *KeyValueInstance) (*client.KeyValueConnectionInfo, error) {
+	if keyValue.Legacy {
+		resp, err := r.client.RetrieveRedisConnectionInfoWithResponse(ctx, keyValue.Id)
+		if err != nil {
+			return nil, err
+		}
+		info, err := client.BodyFromResponse(resp.JSON200, resp)
+		if err != nil {
+			return nil, err
+		}
+		return connectionInfoFromRedis(info), nil
+	}
+
+	resp, err := r.client.RetrieveKeyValueConnectionInfoWithResponse(ctx, keyValue.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.BodyFromResponse(resp.JSON200, resp)
+}
+
+// GetKeyValueInWorkspace retrieves a Key Value instance and validates that it
+// belongs to the workspace in the current session. Tools that connect to or
+// act on an instance call it first.
+func (r *Repo) GetKeyValueInWorkspace(ctx context.Context, id string) (*KeyValueInstance, error) {
+	keyValue, err := r.GetKeyValue(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := validate.WorkspaceMatches(ctx, keyValue.Owner.Id); err != nil {
+		return nil, err
+	}
+	return keyValue, nil
+}
@@ -3,11 +3,16 @@ package keyvalue
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 	"github.com/render-oss/render-mcp-server/pkg/client"
 	"github.com/render-oss/render-mcp-server/pkg/mcpserver"
+	"github.com/render-oss/render-mcp-server/pkg/metrics"
 	"github.com/render-oss/render-mcp-server/pkg/pointers"
 	"github.com/render-oss/render-mcp-server/pkg/session"
 	"github.com/render-oss/render-mcp-server/pkg/validate"
@@ -15,18 +20,27 @@ import (
 
 func Tools(c *client.ClientWithResponses) []server.ServerTool {
 	keyValueRepo := NewRepo(c)
+	metricsRepo := metrics.NewRepo(c)
 
 	return []server.ServerTool{
 		listKeyValue(keyValueRepo),
 		getKeyValue(keyValueRepo),
 		createKeyValue(keyValueRepo),
+		updateKeyValue(keyValueRepo),
+		queryKeyValue(keyValueRepo),
+		analyzeKeyValue(keyValueRepo, metricsRepo),
+		suspendKeyValue(keyValueRepo),
+		resumeKeyValue(keyValueRepo),
+		deleteKeyValue(keyValueRepo),
 	}
 }
 
 func listKeyValue(keyValueRepo *Repo) server.ServerTool {
 	return server.ServerTool{
 		Tool: mcp.NewTool("list_key_value",
-			mcp.WithDescription("List all Key Value instances in your Render account"),
+			mcp.WithDescription("List all Key Value instances in your Render account. Legacy Redis instances, "+
+				"created before Redis was renamed Key Value, are included and marked with `legacy: true`. "+
+				"The other Key Value tools accept their IDs too."),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:           "List Key Value instances",
 				ReadOnlyHint:    pointers.From(true),
@@ -41,16 +55,28 @@ func listKeyValue(keyValueRepo *Repo) server.ServerTool {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			if len(keyValues) == 0 {
-				return mcp.NewToolResultText("No Key Value instances found"), nil
+			// A failure listing legacy instances shouldn't hide the Key Value
+			// instances, so it's reported alongside them instead.
+			var note string
+			legacy, err := keyValueRepo.ListLegacyRedis(ctx, &client.ListRedisParams{})
+			if err != nil {
+				note = fmt.Sprintf("Legacy Redis instances couldn't be listed: %s", err)
 			}
+			keyValues = append(keyValues, legacy...)
 
-			respJSON, err := json.Marshal(keyValues)
-			if err != nil {
-				return mcp.NewToolResultError(err.Error()), nil
+			result := mcp.NewToolResultText("No Key Value instances found")
+			if len(keyValues) > 0 {
+				respJSON, err := json.Marshal(keyValues)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				result = mcp.NewToolResultText(string(respJSON))
 			}
 
-			return mcp.NewToolResultText(string(respJSON)), nil
+			if note != "" {
+				result.Content = append(result.Content, mcp.NewTextContent(note))
+			}
+			return result, nil
 		},
 	}
 }
@@ -119,7 +145,7 @@ func createKeyValue(keyValueRepo *Repo) server.ServerTool {
 			),
 			mcp.WithString("maxmemoryPolicy",
 				mcp.Description("The eviction policy for the Key Value store"),
-				mcp.Enum(mcpserver.EnumValuesFromClientType(client.Noeviction, client.AllkeysLfu, client.AllkeysLru, client.AllkeysRandom, client.VolatileLfu, client.VolatileLru, client.VolatileRandom, client.VolatileTtl)...),
+				mcp.Enum(mcpserver.MaxmemoryPolicyEnumValues()...),
 			),
 			mcp.WithString("persistenceMode",
 				mcp.Description("The data persistence behavior for the Key Value store"),
@@ -188,3 +214,462 @@ func createKeyValue(keyValueRepo *Repo) server.ServerTool {
 		},
 	}
 }
+
+func updateKeyValue(keyValueRepo *Repo) server.ServerTool {
+	cidrItems := map[string]interface{}{
+		"type":                 "object",
+		"additionalProperties": false,
+		"required":             []string{"cidrBlock"},
+		"properties": map[string]interface{}{
+			"cidrBlock": map[string]interface{}{
+				"type":        "string",
+				"description": "The CIDR block to allow, such as 203.0.113.0/24. Use /32 for a single IPv4 address",
+			},
+			"description": map[string]interface{}{
+				"type":        "string",
+				"description": "What the CIDR block is for, such as the office or service it belongs to",
+			},
+		},
+	}
+
+	return server.ServerTool{
+		Tool: mcp.NewTool("update_key_value",
+			mcp.WithDescription("Update a Key Value instance's plan, eviction policy or IP allow list. Only the "+
+				"provided fields are changed. The IP allow list controls which addresses can connect from outside "+
+				"Render: entries are added or removed from the current list, an entry of 0.0.0.0/0 admits every "+
+				"address, and an empty list blocks all external connections. Connections from services in the "+
+				"same region always work. Changing the plan restarts the instance."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           "Update Key Value instance",
+				ReadOnlyHint:    pointers.From(false),
+				DestructiveHint: pointers.From(true),
+				IdempotentHint:  pointers.From(true),
+				OpenWorldHint:   pointers.From(false),
+			}),
+			mcp.WithString("keyValueId",
+				mcp.Required(),
+				mcp.Description("The ID of the Key Value instance to update"),
+			),
+			mcp.WithString("plan",
+				mcp.Description("The new pricing plan for the Key Value instance"),
+				mcp.Enum(mcpserver.KeyValuePlanEnumValues()...),
+			),
+			mcp.WithString("maxmemoryPolicy",
+				mcp.Description("The new eviction policy for the Key Value store"),
+				mcp.Enum(mcpserver.MaxmemoryPolicyEnumValues()...),
+			),
+			mcp.WithArray("addIpAllowList",
+				mcp.Description("CIDR blocks to add to the IP allow list. Adding a block that is already on the "+
+					"list updates its description."),
+				mcp.Items(cidrItems),
+			),
+			mcp.WithArray("removeIpAllowList",
+				mcp.Description("CIDR blocks to remove from the IP allow list, exactly as they appear on it, "+
+					"for example [\"0.0.0.0/0\"]"),
+				mcp.Items(map[string]interface{}{"type": "string"}),
+			),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			keyValueId, err := validate.RequiredToolParam[string](request, "keyValueId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			current, err := keyValueRepo.GetKeyValueInWorkspace(ctx, keyValueId)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			var updateParams client.KeyValuePATCHInput
+
+			if plan, ok, err := validate.OptionalToolParam[string](request, "plan"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				kvPlan, err := validate.KeyValuePlan(plan)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				updateParams.Plan = kvPlan
+			}
+
+			if maxmemoryPolicy, ok, err := validate.OptionalToolParam[string](request, "maxmemoryPolicy"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				if !slices.Contains(mcpserver.MaxmemoryPolicyEnumValues(), maxmemoryPolicy) {
+					return mcp.NewToolResultError(fmt.Sprintf("invalid maxmemoryPolicy: %s", maxmemoryPolicy)), nil
+				}
+				updateParams.MaxmemoryPolicy = pointers.From(client.MaxmemoryPolicy(maxmemoryPolicy))
+			}
+
+			add, addOk, err := validate.IPAllowList(request, "addIpAllowList")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			remove, removeOk, err := validate.OptionalToolArrayParam[string](request, "removeIpAllowList")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if addOk || removeOk {
+				ipAllowList, err := editIPAllowList(current.IpAllowList, add, remove)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				updateParams.IpAllowList = &ipAllowList
+			}
+
+			if updateParams == (client.KeyValuePATCHInput{}) {
+				return mcp.NewToolResultError("No changes requested. Provide at least one of plan, maxmemoryPolicy, " +
+					"addIpAllowList or removeIpAllowList"), nil
+			}
+
+			keyValue, err := keyValueRepo.UpdateKeyValue(ctx, current, updateParams)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			respJSON, err := json.Marshal(keyValue)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			return mcp.NewToolResultText(string(respJSON)), nil
+		},
+	}
+}
+
+// editIPAllowList returns the allow list the API expects after removing and
+// then adding entries. The API replaces the full list, so entries that aren't
+// mentioned are carried over unchanged.
+func editIPAllowList(current []client.CidrBlockAndDescription, add []client.CidrBlockAndDescription, remove []string) ([]client.CidrBlockAndDescription, error) {
+	result := slices.Clone(current)
+	if result == nil {
+		result = []client.CidrBlockAndDescription{}
+	}
+
+	for _, cidrBlock := range remove {
+		i := slices.IndexFunc(result, func(entry client.CidrBlockAndDescription) bool {
+			return entry.CidrBlock == cidrBlock
+		})
+		if i < 0 {
+			return nil, fmt.Errorf("%s is not on the IP allow list", cidrBlock)
+		}
+		result = slices.Delete(result, i, i+1)
+	}
+
+	for _, entry := range add {
+		i := slices.IndexFunc(result, func(existing client.CidrBlockAndDescription) bool {
+			return existing.CidrBlock == entry.CidrBlock
+		})
+		if i >= 0 {
+			result[i].Description = entry.Description
+			continue
+		}
+		result = append(result, entry)
+	}
+
+	return result, nil
+}
+
+func queryKeyValue(keyValueRepo *Repo) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("query_render_key_value",
+			mcp.WithDescription("Run a read-only command against a Render Key Value instance to inspect cache or "+
+				"queue state. Only these commands are allowed: "+strings.Join(allowedCommands, ", ")+". "+
+				fmt.Sprintf("MGET reads at most %d keys, and LRANGE, ZRANGE and HGETALL return at most %d elements, ",
+					maxMGetKeys, maxCollectionItems)+
+				"flagging the result as truncated when there are more. ZRANGE supports ranges by index with the "+
+				fmt.Sprintf("WITHSCORES and REV options. SCAN accepts MATCH, COUNT (at most %d) and TYPE; ", maxScanCount)+
+				"prefer it over listing keys by pattern. INFO is returned as an object keyed by section. "+
+				"The instance's IP allow list must admit connections from this server."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           "Query Key Value instance",
+				ReadOnlyHint:    pointers.From(true),
+				DestructiveHint: pointers.From(false),
+				IdempotentHint:  pointers.From(true),
+				OpenWorldHint:   pointers.From(false),
+			}),
+			mcp.WithString("keyValueId",
+				mcp.Required(),
+				mcp.Description("The ID of the Key Value instance to query"),
+			),
+			mcp.WithString("command",
+				mcp.Required(),
+				mcp.Description("The command to run"),
+				mcp.Enum(allowedCommands...),
+			),
+			mcp.WithArray("args",
+				mcp.Description("The command's arguments, for example [\"user:42\"] for GET or "+
+					"[\"0\", \"MATCH\", \"session:*\", \"COUNT\", \"100\"] for SCAN"),
+				mcp.Items(map[string]interface{}{"type": "string"}),
+			),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			keyValueId, err := validate.RequiredToolParam[string](request, "keyValueId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			command, err := validate.RequiredToolParam[string](request, "command")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !slices.Contains(allowedCommands, strings.ToUpper(strings.TrimSpace(command))) {
+				return mcp.NewToolResultError(commandNotAllowedError(command).Error()), nil
+			}
+
+			args, _, err := validate.OptionalToolArrayParam[string](request, "args")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			keyValue, err := keyValueRepo.GetKeyValueInWorkspace(ctx, keyValueId)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			connectionInfo, err := keyValueRepo.GetKeyValueConnectionInfo(ctx, keyValue)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			rdb, err := newKeyValueClient(connectionInfo.ExternalConnectionString)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			defer rdb.Close()
+
+			result, err := runCommand(ctx, rdb, command, args)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			respJSON, err := json.Marshal(result)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			return mcp.NewToolResultText(string(respJSON)), nil
+		},
+	}
+}
+
+func analyzeKeyValue(keyValueRepo *Repo, metricsRepo *metrics.Repo) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("analyze_key_value",
+			mcp.WithDescription("Analyze the memory use of a Render Key Value instance. Use this when an instance "+
+				"is full, evicting keys or using more memory than expected. Reports the INFO memory, stats and "+
+				"keyspace sections with a summary of memory use against maxmemory, evictions and the hit ratio; "+
+				"samples the keyspace with SCAN to estimate key counts and memory by prefix and type, how many "+
+				"keys have no expiry, and the biggest keys by MEMORY USAGE; and summarizes the memory_usage metric "+
+				"over the last hour. Only read-only commands are run. The instance's IP allow list must admit "+
+				"connections from this server."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           "Analyze Key Value instance",
+				ReadOnlyHint:    pointers.From(true),
+				DestructiveHint: pointers.From(false),
+				IdempotentHint:  pointers.From(true),
+				OpenWorldHint:   pointers.From(false),
+			}),
+			mcp.WithString("keyValueId",
+				mcp.Required(),
+				mcp.Description("The ID of the Key Value instance to analyze"),
+			),
+			mcp.WithNumber("sampleKeys",
+				mcp.Description("How many keys to sample. Larger samples give better estimates but take longer"),
+				mcp.DefaultNumber(defaultAnalyzeSampleKeys),
+				mcp.Min(1),
+				mcp.Max(maxAnalyzeSampleKeys),
+			),
+			mcp.WithString("delimiter",
+				mcp.Description("The separator that ends a key's prefix; keys are grouped by the text up to its "+
+					"first occurrence"),
+				mcp.DefaultString(":"),
+			),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			keyValueId, err := validate.RequiredToolParam[string](request, "keyValueId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			sampleKeys := defaultAnalyzeSampleKeys
+			if n, ok, err := validate.OptionalToolParam[float64](request, "sampleKeys"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				if n < 1 || n > maxAnalyzeSampleKeys {
+					return mcp.NewToolResultError(fmt.Sprintf("sampleKeys must be between 1 and %d",
+						maxAnalyzeSampleKeys)), nil
+				}
+				sampleKeys = int(n)
+			}
+
+			delimiter := ":"
+			if d, ok, err := validate.OptionalToolParam[string](request, "delimiter"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				delimiter = d
+			}
+
+			keyValue, err := keyValueRepo.GetKeyValueInWorkspace(ctx, keyValueId)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			connectionInfo, err := keyValueRepo.GetKeyValueConnectionInfo(ctx, keyValue)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			rdb, err := newKeyValueClient(connectionInfo.ExternalConnectionString)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			defer rdb.Close()
+
+			if err := rdb.Ping(ctx).Err(); err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("error connecting to Key Value instance: %s", err.Error())), nil
+			}
+
+			analysis := runAnalysis(ctx, rdb, sampleKeys, delimiter)
+
+			end := time.Now().UTC()
+			start := end.Add(-memoryUsageWindow)
+			metricsResp, err := metricsRepo.GetMetrics(ctx, metrics.MetricsRequest{
+				ResourceID:  keyValueId,
+				MetricTypes: []metrics.MetricType{metrics.MetricTypeMemoryUsage},
+				StartTime:   &start,
+				EndTime:     &end,
+			})
+			if err != nil {
+				analysis.Errors = append(analysis.Errors, fmt.Sprintf("memory_usage: %s", err.Error()))
+			} else if len(metricsResp.Metrics) > 0 {
+				analysis.MemoryUsage = summarizeMemoryUsage(start, end, metricsResp.Metrics[0].Data)
+			}
+
+			respJSON, err := json.Marshal(analysis)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("Error marshaling analysis", err), nil
+			}
+
+			return mcp.NewToolResultText(string(respJSON)), nil
+		},
+	}
+}
+
+func suspendKeyValue(keyValueRepo *Repo) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("suspend_key_value",
+			mcp.WithDescription("Suspend a Key Value instance. A suspended instance rejects all connections "+
+				"until it is resumed with resume_key_value. Data that isn't persisted to disk is lost."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           "Suspend Key Value instance",
+				ReadOnlyHint:    pointers.From(false),
+				DestructiveHint: pointers.From(true),
+				IdempotentHint:  pointers.From(true),
+				OpenWorldHint:   pointers.From(false),
+			}),
+			mcp.WithString("keyValueId",
+				mcp.Required(),
+				mcp.Description("The ID of the Key Value instance to suspend"),
+			),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			keyValueId, err := validate.RequiredToolParam[string](request, "keyValueId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if err := keyValueRepo.SuspendKeyValue(ctx, keyValueId); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("Suspend requested for Key Value instance %s. "+
+				"Use get_key_value to check on its status.", keyValueId)), nil
+		},
+	}
+}
+
+func resumeKeyValue(keyValueRepo *Repo) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("resume_key_value",
+			mcp.WithDescription("Resume a suspended Key Value instance"),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           "Resume Key Value instance",
+				ReadOnlyHint:    pointers.From(false),
+				DestructiveHint: pointers.From(false),
+				IdempotentHint:  pointers.From(true),
+				OpenWorldHint:   pointers.From(false),
+			}),
+			mcp.WithString("keyValueId",
+				mcp.Required(),
+				mcp.Description("The ID of the Key Value instance to resume"),
+			),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			keyValueId, err := validate.RequiredToolParam[string](request, "keyValueId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if err := keyValueRepo.ResumeKeyValue(ctx, keyValueId); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("Resume requested for Key Value instance %s. "+
+				"Use get_key_value to check on its status.", keyValueId)), nil
+		},
+	}
+}
+
+func deleteKeyValue(keyValueRepo *Repo) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("delete_key_value",
+			mcp.WithDescription("Permanently delete a Key Value instance and all of its data. This cannot be undone. "+
+				"The first call without `confirm` only describes what will be deleted; confirm with the user, "+
+				"then call again with `confirm` set to true."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           "Delete Key Value instance",
+				ReadOnlyHint:    pointers.From(false),
+				DestructiveHint: pointers.From(true),
+				IdempotentHint:  pointers.From(true),
+				OpenWorldHint:   pointers.From(false),
+			}),
+			mcp.WithString("keyValueId",
+				mcp.Required(),
+				mcp.Description("The ID of the Key Value instance to delete"),
+			),
+			mcp.WithBoolean("confirm",
+				mcp.Description("Set to true only after the user has confirmed the deletion. Defaults to false."),
+				mcp.DefaultBool(false),
+			),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			keyValueId, err := validate.RequiredToolParam[string](request, "keyValueId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			confirm, _, err := validate.OptionalToolParam[bool](request, "confirm")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if !confirm {
+				keyValue, err := keyValueRepo.GetKeyValueInWorkspace(ctx, keyValueId)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				return mcp.NewToolResultText(fmt.Sprintf("Deleting Key Value instance %q (%s) permanently removes "+
+					"all of its keys, and services that connect to it will start failing. Nothing has been deleted "+
+					"yet. Ask the user to confirm, then call delete_key_value again with `confirm` set to true.",
+					keyValue.Name, keyValueId)), nil
+			}
+
+			if err := keyValueRepo.DeleteKeyValue(ctx, keyValueId); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("Key Value instance %s deleted.", keyValueId)), nil
+		},
+	}
+}
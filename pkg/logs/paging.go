@@ -0,0 +1,101 @@
+package logs
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/render-oss/render-mcp-server/pkg/client"
+	logsclient "github.com/render-oss/render-mcp-server/pkg/client/logs"
+	"github.com/render-oss/render-mcp-server/pkg/validate"
+)
+
+const (
+	// listLogsPageLimit is the most logs the API returns per ListLogs call.
+	listLogsPageLimit = 100
+	// maxLogPages bounds the calls made for sparse time ranges, where the API
+	// can return empty pages that still have more to fetch.
+	maxLogPages = 200
+
+	maxListLogsMaxLines = 5000
+	maxListLogsMaxBytes = 1_000_000
+)
+
+type logPagesStopReason string
+
+const (
+	logPagesStoppedByMaxLines logPagesStopReason = "maxLines"
+	logPagesStoppedByMaxBytes logPagesStopReason = "maxBytes"
+	logPagesStoppedByMaxPages logPagesStopReason = "maxPages"
+)
+
+// logPages is a ListLogs response assembled from several pages. HasMore,
+// NextStartTime and NextEndTime continue from the last page included.
+type logPages struct {
+	client.Logs200Response
+	NextPageToken string             `json:"nextPageToken,omitempty"`
+	Pages         int                `json:"pages"`
+	Bytes         int                `json:"bytes"`
+	StoppedBy     logPagesStopReason `json:"stoppedBy,omitempty"`
+	TimeRange     validate.TimeRange `json:"timeRange"`
+}
+
+// listLogPages follows hasMore from query until the time range is exhausted
+// or a budget is hit. A maxLines or maxBytes of 0 means no limit. Bytes are
+// the JSON size of the returned logs. A page that would overflow maxBytes is
+// left out entirely so the continuation times stay exact, unless it is the
+// first page, which is always returned so the caller can make progress.
+func listLogPages(ctx context.Context, query *logQuery, maxLines, maxBytes int) (*logPages, error) {
+	pageSize := listLogsPageLimit
+	if query.params.Limit != nil {
+		pageSize = *query.params.Limit
+	}
+
+	result := &logPages{Logs200Response: client.Logs200Response{Logs: []logsclient.Log{}}}
+	for {
+		limit := pageSize
+		if maxLines > 0 {
+			limit = min(limit, maxLines-len(result.Logs))
+		}
+
+		page, err := query.next(ctx, limit)
+		if err != nil {
+			return nil, err
+		}
+
+		pageBytes := 0
+		for _, log := range page.Logs {
+			logJSON, err := json.Marshal(log)
+			if err != nil {
+				return nil, err
+			}
+			pageBytes += len(logJSON)
+		}
+		if maxBytes > 0 && result.Pages > 0 && result.Bytes+pageBytes > maxBytes {
+			result.StoppedBy = logPagesStoppedByMaxBytes
+			return result, nil
+		}
+
+		query.advance(page)
+		result.NextPageToken = query.pageToken()
+		result.Pages++
+		result.Bytes += pageBytes
+		result.Logs = append(result.Logs, page.Logs...)
+		result.HasMore = page.HasMore
+		result.NextStartTime = page.NextStartTime
+		result.NextEndTime = page.NextEndTime
+
+		switch {
+		case !page.HasMore:
+			return result, nil
+		case maxLines > 0 && len(result.Logs) >= maxLines:
+			result.StoppedBy = logPagesStoppedByMaxLines
+			return result, nil
+		case maxBytes > 0 && result.Bytes >= maxBytes:
+			result.StoppedBy = logPagesStoppedByMaxBytes
+			return result, nil
+		case result.Pages >= maxLogPages:
+			result.StoppedBy = logPagesStoppedByMaxPages
+			return result, nil
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package logs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/render-oss/render-mcp-server/pkg/client"
+	logsclient "github.com/render-oss/render-mcp-server/pkg/client/logs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pagedLogAPI serves numbered pages of logs. Each page holds as many logs as
+// the request's limit, page n continues at pageTime(n), and pages run out
+// after lastPage.
+type pagedLogAPI struct {
+	lastPage int
+	requests []*http.Request
+}
+
+func pageTime(n int) time.Time {
+	return time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC).Add(-time.Duration(n) * time.Minute)
+}
+
+func (a *pagedLogAPI) listPage(r *http.Request) client.Logs200Response {
+	a.requests = append(a.requests, r)
+	n := len(a.requests)
+
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	page := client.Logs200Response{
+		HasMore:       n < a.lastPage,
+		NextStartTime: pageTime(n).Add(-time.Hour),
+		NextEndTime:   pageTime(n),
+	}
+	for i := 0; i < limit; i++ {
+		page.Logs = append(page.Logs, logsclient.Log{
+			Id:        fmt.Sprintf("log-%d-%d", n, i),
+			Message:   "request handled",
+			Labels:    []logsclient.LogLabel{},
+			Timestamp: pageTime(n - 1),
+		})
+	}
+	return page
+}
+
+func logBytes(t *testing.T, log logsclient.Log) int {
+	t.Helper()
+	logJSON, err := json.Marshal(log)
+	require.NoError(t, err)
+	return len(logJSON)
+}
+
+func newTestLogQuery(t *testing.T, logRepo *LogRepo, params *client.ListLogsParams) *logQuery {
+	t.Helper()
+	query, err := newLogQuery(context.Background(), logRepo, params, "")
+	require.NoError(t, err)
+	return query
+}
+
+func TestListLogPagesStopsAtMaxLines(t *testing.T) {
+	api := &pagedLogAPI{lastPage: 10}
+	logRepo := newTestLogAPI(t, api.listPage)
+
+	params := &client.ListLogsParams{OwnerId: "own-123", Resource: []string{"srv-123"}}
+	result, err := listLogPages(context.Background(), newTestLogQuery(t, logRepo, params), 250, 0)
+	require.NoError(t, err)
+
+	require.Len(t, api.requests, 3)
+	assert.Equal(t, "100", api.requests[0].URL.Query().Get("limit"))
+	assert.Equal(t, "50", api.requests[2].URL.Query().Get("limit"))
+	assert.Equal(t, pageTime(1).Format(time.RFC3339), api.requests[1].URL.Query().Get("endTime"))
+	assert.Equal(t, pageTime(2).Format(time.RFC3339), api.requests[2].URL.Query().Get("endTime"))
+
+	assert.Len(t, result.Logs, 250)
+	assert.Equal(t, 3, result.Pages)
+	assert.Equal(t, logPagesStoppedByMaxLines, result.StoppedBy)
+	assert.True(t, result.HasMore)
+	assert.Equal(t, pageTime(3), result.NextEndTime)
+}
+
+func TestListLogPagesLeavesOutPageOverMaxBytes(t *testing.T) {
+	api := &pagedLogAPI{lastPage: 10}
+	logRepo := newTestLogAPI(t, api.listPage)
+
+	pageSize := 10
+	params := &client.ListLogsParams{OwnerId: "own-123", Resource: []string{"srv-123"}, Limit: &pageSize}
+	pageBytes := pageSize * logBytes(t, logsclient.Log{
+		Id:        "log-1-0",
+		Message:   "request handled",
+		Labels:    []logsclient.LogLabel{},
+		Timestamp: pageTime(0),
+	})
+
+	result, err := listLogPages(context.Background(), newTestLogQuery(t, logRepo, params), 0, 2*pageBytes+pageBytes/2)
+	require.NoError(t, err)
+
+	assert.Len(t, api.requests, 3)
+	assert.Len(t, result.Logs, 20)
+	assert.Equal(t, 2, result.Pages)
+	assert.Equal(t, 2*pageBytes, result.Bytes)
+	assert.Equal(t, logPagesStoppedByMaxBytes, result.StoppedBy)
+	assert.True(t, result.HasMore)
+	// Resuming from here fetches the page that was left out
+	assert.Equal(t, pageTime(2), result.NextEndTime)
+}
+
+func TestListLogPagesKeepsFirstPageOverMaxBytes(t *testing.T) {
+	api := &pagedLogAPI{lastPage: 10}
+	logRepo := newTestLogAPI(t, api.listPage)
+
+	params := &client.ListLogsParams{OwnerId: "own-123", Resource: []string{"srv-123"}}
+	result, err := listLogPages(context.Background(), newTestLogQuery(t, logRepo, params), 0, 10)
+	require.NoError(t, err)
+
+	assert.Len(t, api.requests, 1)
+	assert.Len(t, result.Logs, 100)
+	assert.Equal(t, logPagesStoppedByMaxBytes, result.StoppedBy)
+	assert.Equal(t, pageTime(1), result.NextEndTime)
+}
+
+func TestListLogPagesExhaustsRange(t *testing.T) {
+	api := &pagedLogAPI{lastPage: 2}
+	logRepo := newTestLogAPI(t, api.listPage)
+
+	params := &client.ListLogsParams{OwnerId: "own-123", Resource: []string{"srv-123"}}
+	result, err := listLogPages(context.Background(), newTestLogQuery(t, logRepo, params), 1000, 0)
+	require.NoError(t, err)
+
+	assert.Len(t, result.Logs, 200)
+	assert.Equal(t, 2, result.Pages)
+	assert.False(t, result.HasMore)
+	assert.Empty(t, result.StoppedBy)
+}
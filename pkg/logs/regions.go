@@ -0,0 +1,490 @@
+package logs
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/render-oss/render-mcp-server/pkg/client"
+	logsclient "github.com/render-oss/render-mcp-server/pkg/client/logs"
+	"github.com/render-oss/render-mcp-server/pkg/validate"
+)
+
+// unknownRegion groups resources whose region couldn't be looked up, such as
+// static sites and resource types this server doesn't know about. They are
+// queried together and left to the logs API to accept or reject.
+const unknownRegion = ""
+
+// logRegion is a set of resources in one region whose logs are queried
+// together, and the time range of the next page of them.
+type logRegion struct {
+	Region    string     `json:"region"`
+	Resources []string   `json:"resources"`
+	StartTime *time.Time `json:"startTime,omitempty"`
+	EndTime   *time.Time `json:"endTime,omitempty"`
+}
+
+// logQuery runs ListLogs as one query per region, because the logs API only
+// accepts resources from a single region in each call. Queries over a single
+// region behave exactly like calling ListLogs directly.
+type logQuery struct {
+	repo    *LogRepo
+	params  client.ListLogsParams
+	regions []logRegion
+	// split is set once the query spans more than one region, after which
+	// it can only be continued with a page token.
+	split bool
+}
+
+// logQueryPage is a page of logs merged from every region still being
+// queried, along with where each region continues from.
+type logQueryPage struct {
+	client.Logs200Response
+	regions []logRegion
+}
+
+// newLogQuery prepares params to be queried by region. A non-empty pageToken
+// continues a previous query over the same resources and replaces the time
+// range in params.
+func newLogQuery(ctx context.Context, logRepo *LogRepo, params *client.ListLogsParams, pageToken string) (*logQuery, error) {
+	query := &logQuery{repo: logRepo, params: *params}
+
+	if pageToken != "" {
+		regions, err := decodePageToken(pageToken, params.Resource)
+		if err != nil {
+			return nil, err
+		}
+		query.regions = regions
+		query.split = true
+		return query, nil
+	}
+
+	regions, err := logRepo.resourceRegions(ctx, params.Resource)
+	if err != nil {
+		return nil, err
+	}
+	for region, resources := range regions {
+		query.regions = append(query.regions, logRegion{
+			Region:    region,
+			Resources: resources,
+			StartTime: params.StartTime,
+			EndTime:   params.EndTime,
+		})
+	}
+	slices.SortFunc(query.regions, func(a, b logRegion) int { return cmp.Compare(a.Region, b.Region) })
+	query.split = len(query.regions) > 1
+	return query, nil
+}
+
+// next fetches the next page from every region concurrently and merges the
+// logs by timestamp in the requested direction. A limit above 0 is shared
+// between the regions. The query doesn't move past the page until it is
+// passed to advance.
+func (q *logQuery) next(ctx context.Context, limit int) (*logQueryPage, error) {
+	regionLimit := 0
+	if limit > 0 && len(q.regions) > 0 {
+		regionLimit = (limit + len(q.regions) - 1) / len(q.regions)
+	}
+
+	pages := make([]*client.Logs200Response, len(q.regions))
+	errs := make([]error, len(q.regions))
+	var wg sync.WaitGroup
+	for i, region := range q.regions {
+		params := q.params
+		params.Resource = region.Resources
+		params.StartTime, params.EndTime = region.StartTime, region.EndTime
+		if regionLimit > 0 {
+			params.Limit = &regionLimit
+		}
+		wg.Go(func() {
+			pages[i], errs[i] = q.repo.ListLogs(ctx, &params)
+			if errs[i] != nil && q.split {
+				errs[i] = fmt.Errorf("%s: %w", regionName(region.Region), errs[i])
+			}
+		})
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	if !q.split {
+		result := &logQueryPage{Logs200Response: *pages[0]}
+		if pages[0].HasMore {
+			region := q.regions[0]
+			region.StartTime, region.EndTime = &pages[0].NextStartTime, &pages[0].NextEndTime
+			result.regions = []logRegion{region}
+		}
+		return result, nil
+	}
+	return q.merge(pages, limit), nil
+}
+
+// regionLog is a log along with the index of the region it came from.
+type regionLog struct {
+	logsclient.Log
+	region int
+}
+
+// merge combines a page from each region into one page in the requested
+// direction. Each region's page only covers the time up to where that region
+// continues, so the merged page stops at the region that reached the least
+// far, and is trimmed to limit if it is above 0. Logs past the cut are
+// returned to their region's time range to be fetched again.
+func (q *logQuery) merge(pages []*client.Logs200Response, limit int) *logQueryPage {
+	backward := q.params.Direction == nil || *q.params.Direction == logsclient.Backward
+	// past reports whether a comes after b in the direction of the query.
+	past := func(a, b time.Time) bool {
+		if backward {
+			return a.Before(b)
+		}
+		return a.After(b)
+	}
+
+	var logs []regionLog
+	var boundary *time.Time
+	for i, page := range pages {
+		for _, log := range page.Logs {
+			logs = append(logs, regionLog{Log: log, region: i})
+		}
+		if !page.HasMore {
+			continue
+		}
+		next := page.NextStartTime
+		if backward {
+			next = page.NextEndTime
+		}
+		if boundary == nil || past(*boundary, next) {
+			boundary = &next
+		}
+	}
+	slices.SortStableFunc(logs, func(a, b regionLog) int {
+		if backward {
+			return b.Timestamp.Compare(a.Timestamp)
+		}
+		return a.Timestamp.Compare(b.Timestamp)
+	})
+
+	keep := len(logs)
+	if boundary != nil {
+		keep = slices.IndexFunc(logs, func(log regionLog) bool { return past(log.Timestamp, *boundary) })
+		if keep < 0 {
+			keep = len(logs)
+		}
+	}
+	if limit > 0 && keep > limit {
+		// Logs sharing a timestamp are kept or cut together, because the
+		// region's next page would return the kept ones again. A page of
+		// nothing but one timestamp is cut at the limit regardless.
+		keep = limit
+		for keep > 0 && logs[keep-1].Timestamp.Equal(logs[keep].Timestamp) {
+			keep--
+		}
+		if keep == 0 {
+			keep = limit
+		}
+	}
+
+	// A region with cut logs continues from the first of them, which it
+	// returned in the query's direction.
+	resume := make([]*time.Time, len(pages))
+	for _, log := range logs[keep:] {
+		if resume[log.region] == nil {
+			resume[log.region] = &log.Timestamp
+		}
+	}
+
+	result := &logQueryPage{Logs200Response: client.Logs200Response{Logs: make([]logsclient.Log, 0, keep)}}
+	for _, log := range logs[:keep] {
+		result.Logs = append(result.Logs, log.Log)
+	}
+	for i, page := range pages {
+		region := q.regions[i]
+		if page.HasMore {
+			region.StartTime, region.EndTime = &page.NextStartTime, &page.NextEndTime
+		} else if resume[i] == nil {
+			continue
+		}
+		if resume[i] != nil {
+			if backward {
+				region.EndTime = resume[i]
+			} else {
+				region.StartTime = resume[i]
+			}
+		}
+		result.regions = append(result.regions, region)
+
+		// The next times of a split query span every region that has more,
+		// so they cover the rest of the range but not a single next page.
+		if region.StartTime != nil && (result.NextStartTime.IsZero() || region.StartTime.Before(result.NextStartTime)) {
+			result.NextStartTime = *region.StartTime
+		}
+		if region.EndTime != nil && region.EndTime.After(result.NextEndTime) {
+			result.NextEndTime = *region.EndTime
+		}
+		result.HasMore = true
+	}
+	return result
+}
+
+// advance moves the query past page, dropping regions that have no more logs.
+func (q *logQuery) advance(page *logQueryPage) {
+	q.regions = page.regions
+}
+
+// pageToken returns the token that continues a split query, or "" if the
+// query covers a single region or has no more logs.
+func (q *logQuery) pageToken() string {
+	if !q.split || len(q.regions) == 0 {
+		return ""
+	}
+	tokenJSON, err := json.Marshal(q.regions)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(tokenJSON)
+}
+
+// timeRange returns the range the query has left to read, from the earliest
+// start to the latest end of its regions.
+func (q *logQuery) timeRange() validate.TimeRange {
+	var timeRange validate.TimeRange
+	for _, region := range q.regions {
+		if region.StartTime != nil && (timeRange.Start == nil || region.StartTime.Before(*timeRange.Start)) {
+			timeRange.Start = region.StartTime
+		}
+		if region.EndTime != nil && (timeRange.End == nil || region.EndTime.After(*timeRange.End)) {
+			timeRange.End = region.EndTime
+		}
+	}
+	return timeRange
+}
+
+func decodePageToken(pageToken string, resources []string) ([]logRegion, error) {
+	invalid := errors.New("invalid pageToken. Pass nextPageToken from a previous response unchanged")
+
+	tokenJSON, err := base64.RawURLEncoding.DecodeString(pageToken)
+	if err != nil {
+		return nil, invalid
+	}
+	var regions []logRegion
+	if err := json.Unmarshal(tokenJSON, &regions); err != nil || len(regions) == 0 {
+		return nil, invalid
+	}
+
+	// Regions that ran out of logs are left out of the token, so the token
+	// may cover fewer resources than the request but never others.
+	for _, region := range regions {
+		for _, resource := range region.Resources {
+			if !slices.Contains(resources, resource) {
+				return nil, fmt.Errorf("pageToken is for a different set of resources than %s", strings.Join(resources, ", "))
+			}
+		}
+	}
+	return regions, nil
+}
+
+func regionName(region string) string {
+	if region == unknownRegion {
+		return "unknown region"
+	}
+	return region
+}
+
+// resourceRegions groups resources by the region they run in. A single
+// resource is returned as is, without looking up its region. Resources whose
+// lookup fails, for example because the API refuses or rate limits it, go in
+// unknownRegion like resources that weren't found, so one of them doesn't
+// fail the whole query.
+func (l *LogRepo) resourceRegions(ctx context.Context, resources []string) (map[string][]string, error) {
+	if len(resources) <= 1 {
+		return map[string][]string{unknownRegion: resources}, nil
+	}
+
+	regions := make([]string, len(resources))
+	var wg sync.WaitGroup
+	for i, resource := range resources {
+		wg.Go(func() {
+			location, _, err := l.lookupResource(ctx, resource)
+			if err != nil {
+				regions[i] = unknownRegion
+				return
+			}
+			regions[i] = location.Region
+		})
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	grouped := map[string][]string{}
+	for i, resource := range resources {
+		grouped[regions[i]] = append(grouped[regions[i]], resource)
+	}
+	return grouped, nil
+}
+
+// listRegionLogLabelValues lists label values with one query per region and
+// returns the sorted union of the values.
+func listRegionLogLabelValues(ctx context.Context, logRepo *LogRepo, params *client.ListLogsValuesParams) ([]string, error) {
+	regions, err := logRepo.resourceRegions(ctx, params.Resource)
+	if err != nil {
+		return nil, err
+	}
+	if len(regions) == 1 {
+		return logRepo.ListLogLabelValues(ctx, params)
+	}
+
+	var (
+		mu     sync.Mutex
+		values []string
+		errs   []error
+		wg     sync.WaitGroup
+	)
+	for region, resources := range regions {
+		regionParams := *params
+		regionParams.Resource = resources
+		wg.Go(func() {
+			regionValues, err := logRepo.ListLogLabelValues(ctx, &regionParams)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", regionName(region), err))
+				return
+			}
+			values = append(values, regionValues...)
+		})
+	}
+	wg.Wait()
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+
+	slices.Sort(values)
+	return slices.Compact(values), nil
+}
+
+// resourceLocation is where a resource runs and which workspace owns it.
+type resourceLocation struct {
+	Region  string
+	OwnerId string
+}
+
+type resourceLookup func(ctx context.Context, resourceId string) (location resourceLocation, found bool, err error)
+
+// resourceOwner returns the workspace that owns a service, cron job, postgres
+// or key value instance.
+func (l *LogRepo) resourceOwner(ctx context.Context, resourceId string) (string, error) {
+	location, found, err := l.lookupResource(ctx, resourceId)
+	if err != nil {
+		return "", fmt.Errorf("looking up %s: %w", resourceId, err)
+	}
+	if !found {
+		return "", fmt.Errorf("resource %s not found. A resource is the id of a service, cron job, postgres, or key value instance", resourceId)
+	}
+	return location.OwnerId, nil
+}
+
+// lookupResource finds a service, cron job, postgres or key value instance.
+// IDs with a known prefix only need the matching lookup; others are tried
+// against each kind of resource in turn.
+func (l *LogRepo) lookupResource(ctx context.Context, resourceId string) (resourceLocation, bool, error) {
+	lookups := []resourceLookup{l.lookupService, l.lookupPostgres, l.lookupKeyValue}
+	switch {
+	case strings.HasPrefix(resourceId, "srv-"), strings.HasPrefix(resourceId, "crn-"):
+		lookups = lookups[:1]
+	case strings.HasPrefix(resourceId, "dpg-"):
+		lookups = lookups[1:2]
+	case strings.HasPrefix(resourceId, "red-"):
+		lookups = lookups[2:]
+	}
+
+	for _, lookup := range lookups {
+		location, found, err := lookup(ctx, resourceId)
+		if err != nil || found {
+			return location, found, err
+		}
+	}
+	return resourceLocation{Region: unknownRegion}, false, nil
+}
+
+func (l *LogRepo) lookupService(ctx context.Context, serviceId string) (resourceLocation, bool, error) {
+	resp, err := l.c.RetrieveServiceWithResponse(ctx, serviceId)
+	if err != nil {
+		return resourceLocation{}, false, err
+	}
+	if resp.StatusCode() == http.StatusNotFound {
+		return resourceLocation{}, false, nil
+	}
+	service, err := client.BodyFromResponse(resp.JSON200, resp)
+	if err != nil {
+		return resourceLocation{}, false, err
+	}
+
+	// Every kind of service details has a region except static sites, which
+	// are served from a CDN and stay in unknownRegion.
+	detailsJSON, err := service.ServiceDetails.MarshalJSON()
+	if err != nil {
+		return resourceLocation{}, false, err
+	}
+	var details struct {
+		Region client.Region `json:"region"`
+	}
+	if err := json.Unmarshal(detailsJSON, &details); err != nil {
+		return resourceLocation{}, false, err
+	}
+	return resourceLocation{Region: string(details.Region), OwnerId: service.OwnerId}, true, nil
+}
+
+func (l *LogRepo) lookupPostgres(ctx context.Context, postgresId string) (resourceLocation, bool, error) {
+	resp, err := l.c.RetrievePostgresWithResponse(ctx, postgresId)
+	if err != nil {
+		return resourceLocation{}, false, err
+	}
+	if resp.StatusCode() == http.StatusNotFound {
+		return resourceLocation{}, false, nil
+	}
+	postgres, err := client.BodyFromResponse(resp.JSON200, resp)
+	if err != nil {
+		return resourceLocation{}, false, err
+	}
+	return resourceLocation{Region: string(postgres.Region), OwnerId: postgres.Owner.Id}, true, nil
+}
+
+// lookupKeyValue also finds legacy Redis instances, which are served by
+// separate endpoints.
+func (l *LogRepo) lookupKeyValue(ctx context.Context, keyValueId string) (resourceLocation, bool, error) {
+	resp, err := l.c.RetrieveKeyValueWithResponse(ctx, keyValueId)
+	if err != nil {
+		return resourceLocation{}, false, err
+	}
+	if resp.StatusCode() != http.StatusNotFound {
+		keyValue, err := client.BodyFromResponse(resp.JSON200, resp)
+		if err != nil {
+			return resourceLocation{}, false, err
+		}
+		return resourceLocation{Region: string(keyValue.Region), OwnerId: keyValue.Owner.Id}, true, nil
+	}
+
+	redisResp, err := l.c.RetrieveRedisWithResponse(ctx, keyValueId)
+	if err != nil {
+		return resourceLocation{}, false, err
+	}
+	if redisResp.StatusCode() == http.StatusNotFound {
+		return resourceLocation{}, false, nil
+	}
+	redis, err := client.BodyFromResponse(redisResp.JSON200, redisResp)
+	if err != nil {
+		return resourceLocation{}, false, err
+	}
+	return resourceLocation{Region: string(redis.Region), OwnerId: redis.Owner.Id}, true, nil
+}
@@ -0,0 +1,385 @@
+package logs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/render-oss/render-mcp-server/pkg/client"
+	logsclient "github.com/render-oss/render-mcp-server/pkg/client/logs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// regionLogAPI stands in for the resource and logs endpoints. Resources are
+// looked up in regions, and a ListLogs call that mixes regions fails like it
+// does in the real API.
+type regionLogAPI struct {
+	// regions maps resource IDs to their region. Key Value IDs are served as
+	// legacy Redis instances.
+	regions map[string]string
+	// forbidden lists resource IDs whose lookups the API refuses.
+	forbidden map[string]bool
+	// logs answers a ListLogs call for resources in region.
+	logs func(region string, r *http.Request) client.Logs200Response
+
+	mu          sync.Mutex
+	logRequests map[string][]*http.Request
+}
+
+func newRegionLogAPI(t *testing.T, api *regionLogAPI) *LogRepo {
+	t.Helper()
+	api.logRequests = map[string][]*http.Request{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		kind, id, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+		region, ok := api.regions[id]
+		switch {
+		case kind != "logs" && api.forbidden[id]:
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message":"forbidden"}`))
+		case kind == "services" && ok:
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": id, "serviceDetails": map[string]any{"region": region}})
+		case kind == "postgres" && ok:
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": id, "region": region})
+		case kind == "redis" && ok:
+			_ = json.NewEncoder(w).Encode(map[string]any{"id": id, "region": region})
+		case kind == "logs":
+			resources := r.URL.Query()["resource"]
+			region := api.regions[resources[0]]
+			for _, resource := range resources {
+				if api.regions[resource] != region {
+					w.WriteHeader(http.StatusBadRequest)
+					_, _ = w.Write([]byte(`{"message":"resources must be in the same region"}`))
+					return
+				}
+			}
+
+			api.mu.Lock()
+			api.logRequests[region] = append(api.logRequests[region], r)
+			api.mu.Unlock()
+
+			if id == "values" {
+				_ = json.NewEncoder(w).Encode([]string{region, "shared"})
+				return
+			}
+			_ = json.NewEncoder(w).Encode(api.logs(region, r))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"message":"not found"}`))
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := client.NewClientWithResponses(srv.URL)
+	require.NoError(t, err)
+	return NewLogRepo(c)
+}
+
+func regionTestLog(id string, minute int) logsclient.Log {
+	return logsclient.Log{
+		Id:        id,
+		Message:   id,
+		Labels:    []logsclient.LogLabel{},
+		Timestamp: time.Date(2026, 1, 2, 3, minute, 0, 0, time.UTC),
+	}
+}
+
+func logIds(logs []logsclient.Log) []string {
+	ids := make([]string, 0, len(logs))
+	for _, log := range logs {
+		ids = append(ids, log.Id)
+	}
+	return ids
+}
+
+func TestLogQuerySplitsResourcesByRegion(t *testing.T) {
+	api := &regionLogAPI{
+		regions: map[string]string{
+			"srv-oregon":    "oregon",
+			"dpg-oregon":    "oregon",
+			"red-frankfurt": "frankfurt",
+		},
+		logs: func(region string, r *http.Request) client.Logs200Response {
+			if region == "oregon" {
+				return client.Logs200Response{
+					Logs:          []logsclient.Log{regionTestLog("oregon-2", 50), regionTestLog("oregon-1", 30)},
+					HasMore:       true,
+					NextStartTime: time.Date(2026, 1, 2, 2, 0, 0, 0, time.UTC),
+					NextEndTime:   time.Date(2026, 1, 2, 3, 30, 0, 0, time.UTC),
+				}
+			}
+			return client.Logs200Response{
+				Logs: []logsclient.Log{regionTestLog("frankfurt-2", 40), regionTestLog("frankfurt-1", 35)},
+			}
+		},
+	}
+	logRepo := newRegionLogAPI(t, api)
+
+	params := &client.ListLogsParams{OwnerId: "own-123", Resource: []string{"srv-oregon", "red-frankfurt", "dpg-oregon"}}
+	query, err := newLogQuery(context.Background(), logRepo, params, "")
+	require.NoError(t, err)
+
+	page, err := query.next(context.Background(), 10)
+	require.NoError(t, err)
+	query.advance(page)
+
+	assert.Equal(t, []string{"oregon-2", "frankfurt-2", "frankfurt-1", "oregon-1"}, logIds(page.Logs))
+	assert.True(t, page.HasMore)
+
+	require.Len(t, api.logRequests["oregon"], 1)
+	require.Len(t, api.logRequests["frankfurt"], 1)
+	assert.Equal(t, []string{"srv-oregon", "dpg-oregon"}, api.logRequests["oregon"][0].URL.Query()["resource"])
+	assert.Equal(t, "5", api.logRequests["oregon"][0].URL.Query().Get("limit"))
+	assert.Equal(t, []string{"red-frankfurt"}, api.logRequests["frankfurt"][0].URL.Query()["resource"])
+
+	// Frankfurt has no more logs, so the token only continues Oregon.
+	pageToken := query.pageToken()
+	require.NotEmpty(t, pageToken)
+
+	query, err = newLogQuery(context.Background(), logRepo, params, pageToken)
+	require.NoError(t, err)
+	_, err = query.next(context.Background(), 10)
+	require.NoError(t, err)
+
+	require.Len(t, api.logRequests["oregon"], 2)
+	assert.Len(t, api.logRequests["frankfurt"], 1)
+	next := api.logRequests["oregon"][1].URL.Query()
+	assert.Equal(t, []string{"srv-oregon", "dpg-oregon"}, next["resource"])
+	assert.Equal(t, "2026-01-02T03:30:00Z", next.Get("endTime"))
+	assert.Equal(t, "10", next.Get("limit"))
+}
+
+func TestLogQueryFallsBackWhenLookupFails(t *testing.T) {
+	api := &regionLogAPI{
+		regions:   map[string]string{"srv-oregon": "oregon", "srv-ohio": "ohio"},
+		forbidden: map[string]bool{"srv-denied": true},
+		logs: func(region string, r *http.Request) client.Logs200Response {
+			return client.Logs200Response{Logs: []logsclient.Log{}}
+		},
+	}
+	logRepo := newRegionLogAPI(t, api)
+
+	params := &client.ListLogsParams{OwnerId: "own-123", Resource: []string{"srv-oregon", "srv-denied", "srv-ohio"}}
+	query, err := newLogQuery(context.Background(), logRepo, params, "")
+	require.NoError(t, err)
+	_, err = query.next(context.Background(), 0)
+	require.NoError(t, err)
+
+	require.Len(t, api.logRequests[unknownRegion], 1)
+	assert.Equal(t, []string{"srv-denied"}, api.logRequests[unknownRegion][0].URL.Query()["resource"])
+	assert.Len(t, api.logRequests["oregon"], 1)
+	assert.Len(t, api.logRequests["ohio"], 1)
+}
+
+func TestListLogsPageTokenKeepsTimeRange(t *testing.T) {
+	api := &regionLogAPI{
+		regions: map[string]string{"srv-oregon": "oregon", "srv-ohio": "ohio"},
+		logs: func(region string, r *http.Request) client.Logs200Response {
+			return client.Logs200Response{
+				Logs:          []logsclient.Log{},
+				HasMore:       true,
+				NextStartTime: time.Date(2026, 1, 2, 1, 0, 0, 0, time.UTC),
+				NextEndTime:   time.Date(2026, 1, 2, 2, 0, 0, 0, time.UTC),
+			}
+		},
+	}
+	logRepo := newRegionLogAPI(t, api)
+	ctx := createTestContext(t, "own-123")
+	resources := []any{"srv-oregon", "srv-ohio"}
+
+	text, isError := callTool(t, ctx, listLogs(logRepo).Handler, map[string]any{
+		"resource":  resources,
+		"startTime": "2026-01-02T00:00:00Z",
+		"endTime":   "2026-01-02T03:00:00Z",
+	})
+	require.False(t, isError, text)
+	var first listLogsResponse
+	require.NoError(t, json.Unmarshal([]byte(text), &first))
+	require.NotEmpty(t, first.NextPageToken)
+
+	text, isError = callTool(t, ctx, listLogs(logRepo).Handler, map[string]any{
+		"resource":  resources,
+		"pageToken": first.NextPageToken,
+		"startTime": "2026-01-02T00:00:00Z",
+	})
+	assert.True(t, isError)
+	assert.Contains(t, text, "can't be used with pageToken")
+
+	text, isError = callTool(t, ctx, listLogs(logRepo).Handler, map[string]any{
+		"resource":  resources,
+		"pageToken": first.NextPageToken,
+	})
+	require.False(t, isError, text)
+	var next listLogsResponse
+	require.NoError(t, json.Unmarshal([]byte(text), &next))
+	require.NotNil(t, next.TimeRange.Start)
+	require.NotNil(t, next.TimeRange.End)
+	assert.Equal(t, time.Date(2026, 1, 2, 1, 0, 0, 0, time.UTC), next.TimeRange.Start.UTC())
+	assert.Equal(t, time.Date(2026, 1, 2, 2, 0, 0, 0, time.UTC), next.TimeRange.End.UTC())
+}
+
+// regionTimeline answers ListLogs from a fixed set of logs per region, newest
+// first, paging backward through them like the logs API.
+func regionTimeline(t *testing.T, timeline map[string][]logsclient.Log) func(region string, r *http.Request) client.Logs200Response {
+	return func(region string, r *http.Request) client.Logs200Response {
+		limit, err := strconv.Atoi(r.URL.Query().Get("limit"))
+		require.NoError(t, err)
+		var end time.Time
+		if endTime := r.URL.Query().Get("endTime"); endTime != "" {
+			end, err = time.Parse(time.RFC3339, endTime)
+			require.NoError(t, err)
+		}
+
+		page := client.Logs200Response{Logs: []logsclient.Log{}}
+		for _, log := range timeline[region] {
+			if !end.IsZero() && log.Timestamp.After(end) {
+				continue
+			}
+			if len(page.Logs) == limit {
+				page.HasMore = true
+				page.NextEndTime = log.Timestamp
+				break
+			}
+			page.Logs = append(page.Logs, log)
+		}
+		return page
+	}
+}
+
+func TestLogQueryPagesStayInOrder(t *testing.T) {
+	// Oregon logs every minute, while Ohio only logs at the start, middle
+	// and end, so Oregon's pages reach back much less far.
+	timeline := map[string][]logsclient.Log{}
+	for minute := 20; minute >= 1; minute-- {
+		timeline["oregon"] = append(timeline["oregon"], regionTestLog(fmt.Sprintf("oregon-%d", minute), minute))
+	}
+	for _, minute := range []int{20, 10, 1} {
+		timeline["ohio"] = append(timeline["ohio"], regionTestLog(fmt.Sprintf("ohio-%d", minute), minute))
+	}
+
+	api := &regionLogAPI{
+		regions: map[string]string{"srv-oregon": "oregon", "srv-ohio": "ohio"},
+		logs:    regionTimeline(t, timeline),
+	}
+	logRepo := newRegionLogAPI(t, api)
+
+	params := &client.ListLogsParams{OwnerId: "own-123", Resource: []string{"srv-oregon", "srv-ohio"}}
+	query, err := newLogQuery(context.Background(), logRepo, params, "")
+	require.NoError(t, err)
+
+	var logs []logsclient.Log
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 50, "query didn't finish")
+		page, err := query.next(context.Background(), 5)
+		require.NoError(t, err)
+		query.advance(page)
+
+		assert.LessOrEqual(t, len(page.Logs), 5)
+		logs = append(logs, page.Logs...)
+		if !page.HasMore {
+			assert.Empty(t, query.pageToken())
+			break
+		}
+
+		// Continue from the token, as a client would.
+		query, err = newLogQuery(context.Background(), logRepo, params, query.pageToken())
+		require.NoError(t, err)
+	}
+
+	assert.Len(t, logs, 23)
+	assert.ElementsMatch(t, logIds(append(timeline["oregon"], timeline["ohio"]...)), logIds(logs))
+	assert.True(t, slices.IsSortedFunc(logs, func(a, b logsclient.Log) int { return b.Timestamp.Compare(a.Timestamp) }),
+		"logs out of order: %v", logIds(logs))
+}
+
+func TestLogQueryMergesForward(t *testing.T) {
+	api := &regionLogAPI{
+		regions: map[string]string{"srv-oregon": "oregon", "srv-ohio": "ohio"},
+		logs: func(region string, r *http.Request) client.Logs200Response {
+			if region == "oregon" {
+				return client.Logs200Response{Logs: []logsclient.Log{regionTestLog("oregon-1", 10), regionTestLog("oregon-2", 30)}}
+			}
+			return client.Logs200Response{Logs: []logsclient.Log{regionTestLog("ohio-1", 20)}}
+		},
+	}
+	logRepo := newRegionLogAPI(t, api)
+
+	forward := logsclient.Forward
+	params := &client.ListLogsParams{OwnerId: "own-123", Resource: []string{"srv-oregon", "srv-ohio"}, Direction: &forward}
+	query, err := newLogQuery(context.Background(), logRepo, params, "")
+	require.NoError(t, err)
+
+	page, err := query.next(context.Background(), 0)
+	require.NoError(t, err)
+	query.advance(page)
+
+	assert.Equal(t, []string{"oregon-1", "ohio-1", "oregon-2"}, logIds(page.Logs))
+	assert.False(t, page.HasMore)
+	assert.Empty(t, query.pageToken())
+}
+
+func TestLogQuerySingleRegionSkipsLookups(t *testing.T) {
+	api := &pagedLogAPI{lastPage: 2}
+	var paths []string
+	logRepo := newTestLogAPI(t, func(r *http.Request) client.Logs200Response {
+		paths = append(paths, r.URL.Path)
+		return api.listPage(r)
+	})
+
+	params := &client.ListLogsParams{OwnerId: "own-123", Resource: []string{"srv-123"}}
+	query, err := newLogQuery(context.Background(), logRepo, params, "")
+	require.NoError(t, err)
+
+	page, err := query.next(context.Background(), 10)
+	require.NoError(t, err)
+	query.advance(page)
+
+	assert.Equal(t, []string{"/logs"}, paths)
+	assert.Equal(t, pageTime(1), page.NextEndTime)
+	assert.Empty(t, query.pageToken())
+}
+
+func TestLogQueryRejectsTokenForOtherResources(t *testing.T) {
+	api := &regionLogAPI{
+		regions: map[string]string{"srv-oregon": "oregon", "srv-ohio": "ohio"},
+		logs: func(region string, r *http.Request) client.Logs200Response {
+			return client.Logs200Response{Logs: []logsclient.Log{}, HasMore: true}
+		},
+	}
+	logRepo := newRegionLogAPI(t, api)
+
+	params := &client.ListLogsParams{OwnerId: "own-123", Resource: []string{"srv-oregon", "srv-ohio"}}
+	query, err := newLogQuery(context.Background(), logRepo, params, "")
+	require.NoError(t, err)
+	page, err := query.next(context.Background(), 0)
+	require.NoError(t, err)
+	query.advance(page)
+
+	other := &client.ListLogsParams{OwnerId: "own-123", Resource: []string{"srv-oregon"}}
+	_, err = newLogQuery(context.Background(), logRepo, other, query.pageToken())
+	assert.ErrorContains(t, err, "pageToken is for a different set of resources")
+
+	_, err = newLogQuery(context.Background(), logRepo, params, "not-a-token")
+	assert.ErrorContains(t, err, "invalid pageToken")
+}
+
+func TestListRegionLogLabelValues(t *testing.T) {
+	api := &regionLogAPI{regions: map[string]string{"srv-oregon": "oregon", "crn-ohio": "ohio"}}
+	logRepo := newRegionLogAPI(t, api)
+
+	params := &client.ListLogsValuesParams{OwnerId: "own-123", Label: client.ListLogsValuesParamsLabel("instance"), Resource: []string{"srv-oregon", "crn-ohio"}}
+	values, err := listRegionLogLabelValues(context.Background(), logRepo, params)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"ohio", "oregon", "shared"}, values)
+}
@@ -2,10 +2,19 @@ package logs
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
 
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
 	"github.com/render-oss/render-mcp-server/pkg/client"
+	logsclient "github.com/render-oss/render-mcp-server/pkg/client/logs"
 )
 
+// maxLogMessageBytes bounds a single streamed log entry.
+const maxLogMessageBytes = 1 << 20
+
 func NewLogRepo(c *client.ClientWithResponses) *LogRepo {
 	return &LogRepo{c: c}
 }
@@ -39,3 +48,122 @@ func (l *LogRepo) ListLogLabelValues(ctx context.Context, params *client.ListLog
 
 	return *resp.JSON200, nil
 }
+
+// SubscribeLogs streams logs matching params to onLog until ctx is done,
+// the server closes the stream, or onLog returns false. The generated client
+// can't follow the websocket upgrade, so the request it builds is dialed here
+// with the same request editors, which carry auth and forwarding headers.
+func (l *LogRepo) SubscribeLogs(ctx context.Context, params *client.SubscribeLogsParams, onLog func(logsclient.Log) bool) error {
+	c, ok := l.c.ClientInterface.(*client.Client)
+	if !ok {
+		return errors.New("log subscriptions are not supported by this client")
+	}
+
+	req, err := client.NewSubscribeLogsRequest(c.Server, params)
+	if err != nil {
+		return err
+	}
+	req = req.WithContext(ctx)
+	for _, edit := range c.RequestEditors {
+		if err := edit(ctx, req); err != nil {
+			return err
+		}
+	}
+
+	switch req.URL.Scheme {
+	case "https":
+		req.URL.Scheme = "wss"
+	case "http":
+		req.URL.Scheme = "ws"
+	}
+
+	opts := &websocket.DialOptions{HTTPHeader: req.Header}
+	if httpClient, ok := c.Client.(*http.Client); ok {
+		opts.HTTPClient = httpClient
+	}
+
+	conn, resp, err := websocket.Dial(ctx, req.URL.String(), opts)
+	if err != nil {
+		if resp != nil {
+			switch resp.StatusCode {
+			case http.StatusUnauthorized:
+				return client.ErrUnauthorized
+			case http.StatusForbidden:
+				return client.ErrForbidden
+			}
+		}
+		return fmt.Errorf("subscribing to logs: %w", err)
+	}
+	defer conn.CloseNow()
+	conn.SetReadLimit(maxLogMessageBytes)
+
+	for {
+		var log logsclient.Log
+		if err := wsjson.Read(ctx, conn, &log); err != nil {
+			if ctx.Err() != nil || websocket.CloseStatus(err) == websocket.StatusNormalClosure {
+				return nil
+			}
+			return fmt.Errorf("reading log stream: %w", err)
+		}
+		if !onLog(log) {
+			// The caller has what it needs, so a failed close handshake
+			// isn't worth reporting.
+			_ = conn.Close(websocket.StatusNormalClosure, "")
+			return nil
+		}
+	}
+}
+
+// GetOwnerLogStream returns the workspace's log stream, or nil if none is
+// configured.
+func (l *LogRepo) GetOwnerLogStream(ctx context.Context, ownerId string) (*logsclient.OwnerLogStreamSetting, error) {
+	resp, err := l.c.GetOwnerLogStreamWithResponse(ctx, ownerId)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode() == http.StatusNotFound {
+		return nil, nil
+	}
+
+	return client.BodyFromResponse(resp.JSON200, resp)
+}
+
+func (l *LogRepo) UpdateOwnerLogStream(ctx context.Context, ownerId string, update logsclient.LogStreamOwnerUpdate) (*logsclient.OwnerLogStreamSetting, error) {
+	resp, err := l.c.UpdateOwnerLogStreamWithResponse(ctx, ownerId, update)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.BodyFromResponse(resp.JSON200, resp)
+}
+
+func (l *LogRepo) ListResourceLogStreams(ctx context.Context, params *client.ListResourceLogStreamsParams) ([]logsclient.ResourceLogStreamSetting, error) {
+	resp, err := l.c.ListResourceLogStreamsWithResponse(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	streams, err := client.BodyFromResponse(resp.JSON200, resp)
+	if err != nil {
+		return nil, err
+	}
+	return *streams, nil
+}
+
+func (l *LogRepo) UpdateResourceLogStream(ctx context.Context, resourceId string, update logsclient.LogStreamResourceUpdate) (*logsclient.ResourceLogStreamSetting, error) {
+	resp, err := l.c.UpdateResourceLogStreamWithResponse(ctx, resourceId, update)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.BodyFromResponse(resp.JSON200, resp)
+}
+
+func (l *LogRepo) DeleteResourceLogStream(ctx context.Context, resourceId string) error {
+	resp, err := l.c.DeleteResourceLogStreamWithResponse(ctx, resourceId)
+	if err != nil {
+		return err
+	}
+
+	return client.ErrorFromResponse(resp)
+}
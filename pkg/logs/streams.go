@@ -0,0 +1,57 @@
+package logs
+
+import (
+	"net/url"
+
+	logsclient "github.com/render-oss/render-mcp-server/pkg/client/logs"
+)
+
+// maskedToken stands in for a log stream token in tool output. The API never
+// returns tokens, so output only says whether the request set one.
+const maskedToken = "********"
+
+// resourceLogStreamsLimit is the most overrides list_resource_log_streams
+// returns. The API doesn't return cursors for them, so a full page is
+// reported as possibly truncated rather than followed.
+const resourceLogStreamsLimit = 100
+
+type workspaceLogStream struct {
+	logsclient.OwnerLogStreamSetting
+	Token string `json:"token,omitempty"`
+}
+
+type resourceLogStream struct {
+	logsclient.ResourceLogStreamSetting
+	Token string `json:"token,omitempty"`
+}
+
+func maskWorkspaceLogStream(setting logsclient.OwnerLogStreamSetting, token *string) workspaceLogStream {
+	setting.Endpoint = redactEndpoint(setting.Endpoint)
+	return workspaceLogStream{OwnerLogStreamSetting: setting, Token: maskToken(token)}
+}
+
+func maskResourceLogStream(setting logsclient.ResourceLogStreamSetting, token *string) resourceLogStream {
+	setting.Endpoint = redactEndpoint(setting.Endpoint)
+	return resourceLogStream{ResourceLogStreamSetting: setting, Token: maskToken(token)}
+}
+
+func maskToken(token *string) string {
+	if token == nil || *token == "" {
+		return ""
+	}
+	return maskedToken
+}
+
+// redactEndpoint hides a password embedded in an endpoint URL. Endpoints
+// without one, such as plain syslog host:port addresses, are unchanged.
+func redactEndpoint(endpoint *string) *string {
+	if endpoint == nil {
+		return nil
+	}
+	u, err := url.Parse(*endpoint)
+	if err != nil || u.User == nil {
+		return endpoint
+	}
+	redacted := u.Redacted()
+	return &redacted
+}
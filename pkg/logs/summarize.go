@@ -0,0 +1,212 @@
+package logs
+
+import (
+	"context"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/render-oss/render-mcp-server/pkg/client"
+	logsclient "github.com/render-oss/render-mcp-server/pkg/client/logs"
+	"github.com/render-oss/render-mcp-server/pkg/validate"
+)
+
+const (
+	defaultSummarizeMaxLines  = 2000
+	maxSummarizeMaxLines      = 10000
+	defaultSummarizeTemplates = 20
+	maxSummarizeTemplates     = 100
+
+	maxTemplateLength = 500
+	maxExampleLength  = 2000
+)
+
+// templateMasks replace the variable parts of a log message so lines that
+// differ only in IDs, counts or times group together. Order matters: the
+// broader patterns run first so their digits aren't masked piecemeal.
+var templateMasks = []func(string) string{
+	maskPattern(`\d{4}-\d{2}-\d{2}[T ]\d{2}:\d{2}:\d{2}(?:[.,]\d+)?(?:Z|[+-]\d{2}:?\d{2})?`, "<ts>"),
+	maskPattern(`\d{2}:\d{2}:\d{2}(?:[.,]\d+)?`, "<ts>"),
+	maskPattern(`(?i)\b[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}\b`, "<uuid>"),
+	maskResourceID,
+	maskPattern(`\b\d{1,3}(?:\.\d{1,3}){3}(?::\d+)?\b`, "<ip>"),
+	maskHex,
+	maskPattern(`\d+(?:\.\d+)?`, "<num>"),
+}
+
+func maskPattern(pattern, replacement string) func(string) string {
+	re := regexp.MustCompile(pattern)
+	return func(s string) string {
+		return re.ReplaceAllString(s, replacement)
+	}
+}
+
+var resourceIDToken = regexp.MustCompile(`\b[a-z]{3,5}-[a-z0-9]{10,}(?:-[a-z0-9]{4,6})?\b`)
+
+// maskResourceID masks Render IDs such as srv-d1a2b3c4d5e6f7g8h9i0. Their
+// random part needs a digit to count, so hyphenated words like
+// "user-authentication" are kept.
+func maskResourceID(s string) string {
+	return resourceIDToken.ReplaceAllStringFunc(s, func(token string) string {
+		_, body, _ := strings.Cut(token, "-")
+		if strings.ContainsAny(body, "0123456789") {
+			return "<id>"
+		}
+		return token
+	})
+}
+
+var hexToken = regexp.MustCompile(`\b(?:0x)?[0-9a-fA-F]{8,}\b`)
+
+// maskHex masks hashes and request IDs. Tokens need a digit to count, so
+// words that happen to be valid hex, like "deadbeef", are kept.
+func maskHex(s string) string {
+	return hexToken.ReplaceAllStringFunc(s, func(token string) string {
+		if strings.ContainsAny(token, "0123456789") {
+			return "<hex>"
+		}
+		return token
+	})
+}
+
+var whitespace = regexp.MustCompile(`\s+`)
+
+// logTemplate normalizes a log message into the template it is grouped by.
+func logTemplate(message string) string {
+	template := message
+	for _, mask := range templateMasks {
+		template = mask(template)
+	}
+	template = strings.TrimSpace(whitespace.ReplaceAllString(template, " "))
+	return truncate(template, maxTemplateLength)
+}
+
+// truncate shortens s to at most n bytes without splitting a UTF-8 sequence.
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
+
+type templateSummary struct {
+	Template  string         `json:"template"`
+	Count     int            `json:"count"`
+	FirstSeen time.Time      `json:"firstSeen"`
+	LastSeen  time.Time      `json:"lastSeen"`
+	Levels    map[string]int `json:"levels,omitempty"`
+	Example   string         `json:"example"`
+}
+
+type logSummary struct {
+	Lines     int               `json:"lines"`
+	Templates int               `json:"templates"`
+	Levels    map[string]int    `json:"levels,omitempty"`
+	Top       []templateSummary `json:"top"`
+	// Complete is false when the line or page budget ran out before the time
+	// range was exhausted. NextStartTime and NextEndTime continue from there.
+	Complete      bool               `json:"complete"`
+	NextStartTime *time.Time         `json:"nextStartTime,omitempty"`
+	NextEndTime   *time.Time         `json:"nextEndTime,omitempty"`
+	TimeRange     validate.TimeRange `json:"timeRange"`
+}
+
+type templateCollector struct {
+	lines     int
+	levels    map[string]int
+	templates map[string]*templateSummary
+}
+
+func (c *templateCollector) add(log logsclient.Log) {
+	c.lines++
+
+	key := logTemplate(log.Message)
+	summary, ok := c.templates[key]
+	if !ok {
+		summary = &templateSummary{
+			Template:  key,
+			FirstSeen: log.Timestamp,
+			LastSeen:  log.Timestamp,
+			Example:   truncate(log.Message, maxExampleLength),
+		}
+		c.templates[key] = summary
+	}
+	summary.Count++
+	if log.Timestamp.Before(summary.FirstSeen) {
+		summary.FirstSeen = log.Timestamp
+	}
+	if log.Timestamp.After(summary.LastSeen) {
+		summary.LastSeen = log.Timestamp
+	}
+
+	for _, label := range log.Labels {
+		if label.Name == logsclient.LogLabelNameLevel {
+			summary.Levels = incrementCount(summary.Levels, label.Value)
+			c.levels = incrementCount(c.levels, label.Value)
+		}
+	}
+}
+
+// top returns the n most frequent templates, most recently seen first on ties.
+func (c *templateCollector) top(n int) []templateSummary {
+	summaries := make([]templateSummary, 0, len(c.templates))
+	for _, summary := range c.templates {
+		summaries = append(summaries, *summary)
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Count != summaries[j].Count {
+			return summaries[i].Count > summaries[j].Count
+		}
+		if !summaries[i].LastSeen.Equal(summaries[j].LastSeen) {
+			return summaries[i].LastSeen.After(summaries[j].LastSeen)
+		}
+		return summaries[i].Template < summaries[j].Template
+	})
+	if len(summaries) > n {
+		summaries = summaries[:n]
+	}
+	return summaries
+}
+
+// summarizeLogs pages through the logs matching params until the time range
+// is exhausted or maxLines logs have been read, grouping them by template.
+func summarizeLogs(ctx context.Context, logRepo *LogRepo, params *client.ListLogsParams, maxLines, topTemplates int) (*logSummary, error) {
+	collector := &templateCollector{templates: map[string]*templateSummary{}}
+	summary := &logSummary{}
+
+	for pages := 1; ; pages++ {
+		limit := min(listLogsPageLimit, maxLines-collector.lines)
+		params.Limit = &limit
+
+		page, err := logRepo.ListLogs(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		for _, log := range page.Logs {
+			collector.add(log)
+		}
+
+		if !page.HasMore {
+			summary.Complete = true
+			break
+		}
+		if collector.lines >= maxLines || pages >= maxLogPages {
+			summary.NextStartTime = &page.NextStartTime
+			summary.NextEndTime = &page.NextEndTime
+			break
+		}
+		params.StartTime = &page.NextStartTime
+		params.EndTime = &page.NextEndTime
+	}
+
+	summary.Lines = collector.lines
+	summary.Templates = len(collector.templates)
+	summary.Levels = collector.levels
+	summary.Top = collector.top(topTemplates)
+	return summary, nil
+}
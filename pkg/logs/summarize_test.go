@@ -0,0 +1,165 @@
+package logs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/render-oss/render-mcp-server/pkg/client"
+	logsclient "github.com/render-oss/render-mcp-server/pkg/client/logs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestLogAPI starts a stand-in for the ListLogs endpoint that answers each
+// request with listPage, and returns a repo pointed at it.
+func newTestLogAPI(t *testing.T, listPage func(r *http.Request) client.Logs200Response) *LogRepo {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/logs" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(listPage(r))
+	}))
+	t.Cleanup(srv.Close)
+
+	c, err := client.NewClientWithResponses(srv.URL)
+	require.NoError(t, err)
+	return NewLogRepo(c)
+}
+
+func TestLogTemplate(t *testing.T) {
+	tests := []struct {
+		message  string
+		expected string
+	}{
+		{
+			message:  "GET /users/1234 completed in 35.2ms",
+			expected: "GET /users/<num> completed in <num>ms",
+		},
+		{
+			message:  "2026-01-02T03:04:05.123Z worker  started job 3f2b6c1e-8a4d-4e0f-9b7a-2c1d5e6f7a8b",
+			expected: "<ts> worker started job <uuid>",
+		},
+		{
+			message:  "deploy dep-d1a2b3c4d5e6f7g8h9i0 finished on srv-d1a2b3c4d5e6f7g8h9i0-abcde",
+			expected: "deploy <id> finished on <id>",
+		},
+		{
+			message:  "connection from 10.0.4.12:5432 reset, commit 9fceb02d0ae598e95dc970b74767f19372d61af8",
+			expected: "connection from <ip> reset, commit <hex>",
+		},
+		{
+			message:  "user-authentication failed in auth-middleware, retrying send-notifications",
+			expected: "user-authentication failed in auth-middleware, retrying send-notifications",
+		},
+		{
+			message:  "cache key deadbeef missed",
+			expected: "cache key deadbeef missed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			assert.Equal(t, tt.expected, logTemplate(tt.message))
+		})
+	}
+}
+
+func summaryTestLog(i int, level, message string) logsclient.Log {
+	return logsclient.Log{
+		Id:        fmt.Sprintf("log-%d", i),
+		Message:   message,
+		Labels:    []logsclient.LogLabel{{Name: logsclient.LogLabelNameLevel, Value: level}},
+		Timestamp: time.Date(2026, 1, 2, 3, 0, i, 0, time.UTC),
+	}
+}
+
+func TestSummarizeLogsFollowsPages(t *testing.T) {
+	nextStart := time.Date(2026, 1, 2, 2, 0, 0, 0, time.UTC)
+	nextEnd := time.Date(2026, 1, 2, 2, 30, 0, 0, time.UTC)
+
+	var requests []string
+	logRepo := newTestLogAPI(t, func(r *http.Request) client.Logs200Response {
+		requests = append(requests, r.URL.Query().Get("startTime"))
+		if len(requests) == 1 {
+			return client.Logs200Response{
+				HasMore: true,
+				Logs: []logsclient.Log{
+					summaryTestLog(3, "error", "timeout talking to db after 3000ms"),
+					summaryTestLog(2, "info", "GET /health 200"),
+					summaryTestLog(1, "error", "timeout talking to db after 2500ms"),
+				},
+				NextStartTime: nextStart,
+				NextEndTime:   nextEnd,
+			}
+		}
+		return client.Logs200Response{
+			Logs: []logsclient.Log{
+				summaryTestLog(0, "warning", "timeout talking to db after 5000ms"),
+			},
+		}
+	})
+
+	params := &client.ListLogsParams{OwnerId: "own-123", Resource: []string{"srv-123"}}
+	summary, err := summarizeLogs(context.Background(), logRepo, params, 100, 10)
+	require.NoError(t, err)
+
+	require.Len(t, requests, 2)
+	assert.Equal(t, "", requests[0])
+	assert.Equal(t, nextStart.Format(time.RFC3339), requests[1])
+
+	assert.True(t, summary.Complete)
+	assert.Nil(t, summary.NextStartTime)
+	assert.Equal(t, 4, summary.Lines)
+	assert.Equal(t, 2, summary.Templates)
+	assert.Equal(t, map[string]int{"error": 2, "info": 1, "warning": 1}, summary.Levels)
+
+	require.Len(t, summary.Top, 2)
+	assert.Equal(t, templateSummary{
+		Template:  "timeout talking to db after <num>ms",
+		Count:     3,
+		FirstSeen: summaryTestLog(0, "", "").Timestamp,
+		LastSeen:  summaryTestLog(3, "", "").Timestamp,
+		Levels:    map[string]int{"error": 2, "warning": 1},
+		Example:   "timeout talking to db after 3000ms",
+	}, summary.Top[0])
+	assert.Equal(t, "GET /health <num>", summary.Top[1].Template)
+}
+
+func TestSummarizeLogsStopsAtMaxLines(t *testing.T) {
+	nextStart := time.Date(2026, 1, 2, 2, 0, 0, 0, time.UTC)
+	nextEnd := time.Date(2026, 1, 2, 2, 30, 0, 0, time.UTC)
+
+	var limits []string
+	logRepo := newTestLogAPI(t, func(r *http.Request) client.Logs200Response {
+		limits = append(limits, r.URL.Query().Get("limit"))
+		var limit int
+		_, _ = fmt.Sscan(r.URL.Query().Get("limit"), &limit)
+		page := client.Logs200Response{HasMore: true, NextStartTime: nextStart, NextEndTime: nextEnd}
+		for i := 0; i < limit; i++ {
+			page.Logs = append(page.Logs, summaryTestLog(i%60, "info", fmt.Sprintf("request %d handled", i)))
+		}
+		return page
+	})
+
+	params := &client.ListLogsParams{OwnerId: "own-123", Resource: []string{"srv-123"}}
+	summary, err := summarizeLogs(context.Background(), logRepo, params, 150, 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"100", "50"}, limits)
+	assert.False(t, summary.Complete)
+	assert.Equal(t, 150, summary.Lines)
+	require.NotNil(t, summary.NextStartTime)
+	assert.Equal(t, nextStart, *summary.NextStartTime)
+	assert.Equal(t, nextEnd, *summary.NextEndTime)
+	require.Len(t, summary.Top, 1)
+	assert.Equal(t, "request <num> handled", summary.Top[0].Template)
+	assert.Equal(t, 150, summary.Top[0].Count)
+}
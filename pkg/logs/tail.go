@@ -0,0 +1,175 @@
+package logs
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+	"github.com/render-oss/render-mcp-server/pkg/client"
+	logsclient "github.com/render-oss/render-mcp-server/pkg/client/logs"
+)
+
+const (
+	defaultTailDurationSeconds = 60
+	maxTailDurationSeconds     = 300
+	defaultTailMaxLines        = 200
+	maxTailMaxLines            = 1000
+
+	// tailRecentLogs is how many of the latest lines the digest repeats, so
+	// clients that drop notifications still see how the stream ended.
+	tailRecentLogs = 20
+
+	tailLogger = "render-logs"
+)
+
+type tailStopReason string
+
+const (
+	tailStoppedByDuration     tailStopReason = "duration"
+	tailStoppedByMaxLines     tailStopReason = "maxLines"
+	tailStoppedByStreamClosed tailStopReason = "streamClosed"
+	tailStoppedByError        tailStopReason = "error"
+)
+
+type tailDigest struct {
+	StartedAt      time.Time        `json:"startedAt"`
+	EndedAt        time.Time        `json:"endedAt"`
+	StoppedBy      tailStopReason   `json:"stoppedBy"`
+	Error          string           `json:"error,omitempty"`
+	Lines          int              `json:"lines"`
+	FirstTimestamp *time.Time       `json:"firstTimestamp,omitempty"`
+	LastTimestamp  *time.Time       `json:"lastTimestamp,omitempty"`
+	Levels         map[string]int   `json:"levels,omitempty"`
+	Types          map[string]int   `json:"types,omitempty"`
+	Instances      map[string]int   `json:"instances,omitempty"`
+	RecentLogs     []logsclient.Log `json:"recentLogs"`
+}
+
+func (d *tailDigest) add(log logsclient.Log) {
+	d.Lines++
+	if d.FirstTimestamp == nil {
+		d.FirstTimestamp = &log.Timestamp
+	}
+	d.LastTimestamp = &log.Timestamp
+
+	for _, label := range log.Labels {
+		switch label.Name {
+		case logsclient.LogLabelNameLevel:
+			d.Levels = incrementCount(d.Levels, label.Value)
+		case logsclient.LogLabelNameType:
+			d.Types = incrementCount(d.Types, label.Value)
+		case logsclient.LogLabelNameInstance:
+			d.Instances = incrementCount(d.Instances, label.Value)
+		}
+	}
+
+	d.RecentLogs = append(d.RecentLogs, log)
+	if len(d.RecentLogs) > tailRecentLogs {
+		d.RecentLogs = d.RecentLogs[1:]
+	}
+}
+
+func incrementCount(counts map[string]int, key string) map[string]int {
+	if counts == nil {
+		counts = map[string]int{}
+	}
+	counts[key]++
+	return counts
+}
+
+// notifyFunc sends an MCP notification to the client that made the request.
+type notifyFunc func(ctx context.Context, method string, params map[string]any) error
+
+// clientNotifier returns a notifyFunc for the session in ctx. Outside of a
+// session, such as in tests, notifications are discarded.
+func clientNotifier(ctx context.Context) notifyFunc {
+	if s := server.ServerFromContext(ctx); s != nil {
+		return s.SendNotificationToClient
+	}
+	return func(context.Context, string, map[string]any) error { return nil }
+}
+
+// tailLogs subscribes to logs matching params until duration elapses or
+// maxLines have been received. Each line is forwarded as a log message
+// notification, and as a progress notification when the client asked for
+// progress. Lines received before a stream error are still summarized.
+func tailLogs(
+	ctx context.Context,
+	logRepo *LogRepo,
+	params *client.SubscribeLogsParams,
+	duration time.Duration,
+	maxLines int,
+	progressToken mcp.ProgressToken,
+	notify notifyFunc,
+) (*tailDigest, error) {
+	digest := &tailDigest{StartedAt: time.Now(), RecentLogs: []logsclient.Log{}}
+
+	tailCtx, cancel := context.WithTimeout(ctx, duration)
+	defer cancel()
+
+	err := logRepo.SubscribeLogs(tailCtx, params, func(log logsclient.Log) bool {
+		digest.add(log)
+		notifyLog(ctx, notify, log, digest.Lines, maxLines, progressToken)
+		return digest.Lines < maxLines
+	})
+	digest.EndedAt = time.Now()
+
+	switch {
+	case ctx.Err() != nil:
+		return nil, ctx.Err()
+	case err != nil && digest.Lines == 0:
+		return nil, err
+	case err != nil:
+		digest.StoppedBy = tailStoppedByError
+		digest.Error = err.Error()
+	case digest.Lines >= maxLines:
+		digest.StoppedBy = tailStoppedByMaxLines
+	case tailCtx.Err() != nil:
+		digest.StoppedBy = tailStoppedByDuration
+	default:
+		digest.StoppedBy = tailStoppedByStreamClosed
+	}
+	return digest, nil
+}
+
+// notifyLog forwards a streamed line to the client. Notifications are best
+// effort: a client without an initialized session, or one that isn't keeping
+// up, still gets the digest when tailing ends.
+func notifyLog(ctx context.Context, notify notifyFunc, log logsclient.Log, lines, maxLines int, progressToken mcp.ProgressToken) {
+	_ = notify(ctx, "notifications/message", map[string]any{
+		"level":  notificationLevel(log),
+		"logger": tailLogger,
+		"data":   log,
+	})
+
+	if progressToken != nil {
+		_ = notify(ctx, "notifications/progress", map[string]any{
+			"progressToken": progressToken,
+			"progress":      lines,
+			"total":         maxLines,
+			"message":       log.Message,
+		})
+	}
+}
+
+// notificationLevel maps a log's level label onto the MCP logging levels.
+func notificationLevel(log logsclient.Log) mcp.LoggingLevel {
+	for _, label := range log.Labels {
+		if label.Name != logsclient.LogLabelNameLevel {
+			continue
+		}
+		switch strings.ToLower(label.Value) {
+		case "debug", "trace":
+			return mcp.LoggingLevelDebug
+		case "warn", "warning":
+			return mcp.LoggingLevelWarning
+		case "error":
+			return mcp.LoggingLevelError
+		case "fatal", "critical", "panic":
+			return mcp.LoggingLevelCritical
+		}
+	}
+	return mcp.LoggingLevelInfo
+}
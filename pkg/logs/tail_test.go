@@ -0,0 +1,203 @@
+package logs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/coder/websocket"
+	"github.com/coder/websocket/wsjson"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/render-oss/render-mcp-server/pkg/client"
+	logsclient "github.com/render-oss/render-mcp-server/pkg/client/logs"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestLogStream starts a stand-in for the log subscription endpoint that
+// hands each accepted connection to stream, and returns a repo pointed at it.
+// The ctx passed to stream is done once the client closes the connection.
+func newTestLogStream(t *testing.T, stream func(ctx context.Context, r *http.Request, conn *websocket.Conn)) *LogRepo {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/logs/subscribe" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		conn, err := websocket.Accept(w, r, nil)
+		if err != nil {
+			return
+		}
+		defer conn.CloseNow()
+		stream(conn.CloseRead(r.Context()), r, conn)
+	}))
+	t.Cleanup(srv.Close)
+
+	insertAuth := func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer test-token")
+		return nil
+	}
+	c, err := client.NewClientWithResponses(srv.URL, client.WithRequestEditorFn(insertAuth))
+	require.NoError(t, err)
+	return NewLogRepo(c)
+}
+
+func testLog(i int, level string) logsclient.Log {
+	return logsclient.Log{
+		Id:      fmt.Sprintf("log-%d", i),
+		Message: fmt.Sprintf("line %d", i),
+		Labels: []logsclient.LogLabel{
+			{Name: logsclient.LogLabelNameLevel, Value: level},
+			{Name: logsclient.LogLabelNameType, Value: "app"},
+			{Name: logsclient.LogLabelNameInstance, Value: "srv-123-abcde"},
+		},
+		Timestamp: time.Date(2026, 1, 2, 3, 4, i, 0, time.UTC),
+	}
+}
+
+type recordedNotification struct {
+	method string
+	params map[string]any
+}
+
+type notificationRecorder struct {
+	mu            sync.Mutex
+	notifications []recordedNotification
+}
+
+func (n *notificationRecorder) notify(_ context.Context, method string, params map[string]any) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.notifications = append(n.notifications, recordedNotification{method: method, params: params})
+	return nil
+}
+
+func (n *notificationRecorder) methods() []string {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	var methods []string
+	for _, notification := range n.notifications {
+		methods = append(methods, notification.method)
+	}
+	return methods
+}
+
+func TestTailLogsStopsAtMaxLines(t *testing.T) {
+	var query map[string][]string
+	logRepo := newTestLogStream(t, func(ctx context.Context, r *http.Request, conn *websocket.Conn) {
+		query = r.URL.Query()
+		for i := 0; i < 5; i++ {
+			if err := wsjson.Write(ctx, conn, testLog(i, "info")); err != nil {
+				return
+			}
+		}
+		<-ctx.Done()
+	})
+
+	level := []string{"error", "info"}
+	params := &client.SubscribeLogsParams{
+		OwnerId:  "own-123",
+		Resource: []string{"srv-123"},
+		Level:    &level,
+	}
+	recorder := &notificationRecorder{}
+
+	digest, err := tailLogs(context.Background(), logRepo, params, 5*time.Second, 3, "token-1", recorder.notify)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"own-123"}, query["ownerId"])
+	assert.Equal(t, []string{"srv-123"}, query["resource"])
+	assert.Equal(t, []string{"error", "info"}, query["level"])
+
+	assert.Equal(t, tailStoppedByMaxLines, digest.StoppedBy)
+	assert.Equal(t, 3, digest.Lines)
+	assert.Equal(t, map[string]int{"info": 3}, digest.Levels)
+	assert.Equal(t, map[string]int{"app": 3}, digest.Types)
+	assert.Equal(t, map[string]int{"srv-123-abcde": 3}, digest.Instances)
+	require.NotNil(t, digest.FirstTimestamp)
+	assert.Equal(t, testLog(0, "info").Timestamp, *digest.FirstTimestamp)
+	assert.Equal(t, testLog(2, "info").Timestamp, *digest.LastTimestamp)
+	assert.Len(t, digest.RecentLogs, 3)
+
+	assert.Equal(t, []string{
+		"notifications/message", "notifications/progress",
+		"notifications/message", "notifications/progress",
+		"notifications/message", "notifications/progress",
+	}, recorder.methods())
+	last := recorder.notifications[len(recorder.notifications)-1].params
+	assert.Equal(t, "token-1", last["progressToken"])
+	assert.Equal(t, 3, last["progress"])
+	assert.Equal(t, 3, last["total"])
+	assert.Equal(t, "line 2", last["message"])
+}
+
+func TestTailLogsStopsAtDuration(t *testing.T) {
+	logRepo := newTestLogStream(t, func(ctx context.Context, r *http.Request, conn *websocket.Conn) {
+		_ = wsjson.Write(ctx, conn, testLog(0, "error"))
+		<-ctx.Done()
+	})
+	recorder := &notificationRecorder{}
+
+	params := &client.SubscribeLogsParams{OwnerId: "own-123", Resource: []string{"srv-123"}}
+	digest, err := tailLogs(context.Background(), logRepo, params, 200*time.Millisecond, 10, nil, recorder.notify)
+	require.NoError(t, err)
+
+	assert.Equal(t, tailStoppedByDuration, digest.StoppedBy)
+	assert.Equal(t, 1, digest.Lines)
+	assert.Equal(t, map[string]int{"error": 1}, digest.Levels)
+	require.Len(t, recorder.notifications, 1)
+	assert.Equal(t, "notifications/message", recorder.notifications[0].method)
+	assert.Equal(t, mcp.LoggingLevelError, recorder.notifications[0].params["level"])
+}
+
+func TestTailLogsStreamClosed(t *testing.T) {
+	logRepo := newTestLogStream(t, func(ctx context.Context, r *http.Request, conn *websocket.Conn) {
+		_ = wsjson.Write(ctx, conn, testLog(0, "info"))
+		_ = conn.Close(websocket.StatusNormalClosure, "")
+	})
+
+	params := &client.SubscribeLogsParams{OwnerId: "own-123", Resource: []string{"srv-123"}}
+	digest, err := tailLogs(context.Background(), logRepo, params, 5*time.Second, 10, nil, (&notificationRecorder{}).notify)
+	require.NoError(t, err)
+	assert.Equal(t, tailStoppedByStreamClosed, digest.StoppedBy)
+	assert.Equal(t, 1, digest.Lines)
+}
+
+func TestTailLogsKeepsLinesBeforeStreamError(t *testing.T) {
+	logRepo := newTestLogStream(t, func(ctx context.Context, r *http.Request, conn *websocket.Conn) {
+		_ = wsjson.Write(ctx, conn, testLog(0, "info"))
+		_ = conn.Close(websocket.StatusInternalError, "backend unavailable")
+	})
+
+	params := &client.SubscribeLogsParams{OwnerId: "own-123", Resource: []string{"srv-123"}}
+	digest, err := tailLogs(context.Background(), logRepo, params, 5*time.Second, 10, nil, (&notificationRecorder{}).notify)
+	require.NoError(t, err)
+	assert.Equal(t, tailStoppedByError, digest.StoppedBy)
+	assert.Contains(t, digest.Error, "backend unavailable")
+	assert.Equal(t, 1, digest.Lines)
+}
+
+func TestTailLogsUnauthorized(t *testing.T) {
+	logRepo := newTestLogStream(t, func(context.Context, *http.Request, *websocket.Conn) {})
+	c := logRepo.c.ClientInterface.(*client.Client)
+	c.RequestEditors = nil
+
+	params := &client.SubscribeLogsParams{OwnerId: "own-123", Resource: []string{"srv-123"}}
+	_, err := tailLogs(context.Background(), logRepo, params, time.Second, 10, nil, (&notificationRecorder{}).notify)
+	assert.ErrorIs(t, err, client.ErrUnauthorized)
+}
+
+func TestNotificationLevel(t *testing.T) {
+	assert.Equal(t, mcp.LoggingLevelWarning, notificationLevel(testLog(0, "WARN")))
+	assert.Equal(t, mcp.LoggingLevelCritical, notificationLevel(testLog(0, "fatal")))
+	assert.Equal(t, mcp.LoggingLevelInfo, notificationLevel(testLog(0, "unknown")))
+	assert.Equal(t, mcp.LoggingLevelInfo, notificationLevel(logsclient.Log{}))
+}
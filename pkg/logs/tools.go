@@ -3,6 +3,7 @@ package logs
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"time"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -21,206 +22,460 @@ func Tools(c *client.ClientWithResponses) []server.ServerTool {
 	return []server.ServerTool{
 		listLogs(logRepo),
 		listLogLabelValues(logRepo),
+		tailLogsTool(logRepo),
+		summarizeLogsTool(logRepo),
+		getWorkspaceLogStream(logRepo),
+		updateWorkspaceLogStream(logRepo),
+		listResourceLogStreams(logRepo),
+		updateResourceLogStream(logRepo),
+		deleteResourceLogStream(logRepo),
 	}
 }
 
-func listLogs(logRepo *LogRepo) server.ServerTool {
-	return server.ServerTool{
-		Tool: mcp.NewTool("list_logs",
-			mcp.WithDescription("List logs matching the provided filters. Logs are paginated by start and end timestamps. "+
-				"There are more logs to fetch if hasMore is true in the response. "+
-				"Provide the nextStartTime and nextEndTime timestamps as the startTime and endTime query parameters to fetch the next page of logs. "+
-				"You can query for logs across multiple resources, but all resources must be in the same region and belong to the same owner."),
-			mcp.WithToolAnnotation(mcp.ToolAnnotation{
-				Title:           "List logs",
-				ReadOnlyHint:    pointers.From(true),
-				DestructiveHint: pointers.From(false),
-				IdempotentHint:  pointers.From(true),
-				OpenWorldHint:   pointers.From(false),
+// logFilterOptions are the resource and label filters shared by list_logs and
+// tail_logs.
+func logFilterOptions() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithArray("resource",
+			mcp.Required(),
+			mcp.Description("Filter logs by their resource. A resource is the id of a server, cronjob, job, postgres, or redis."),
+			mcp.Items(map[string]interface{}{
+				"type": "string",
 			}),
-			mcp.WithArray("resource",
-				mcp.Required(),
-				mcp.Description("Filter logs by their resource. A resource is the id of a server, cronjob, job, postgres, or redis."),
-				mcp.Items(map[string]interface{}{
-					"type": "string",
-				}),
-			),
-			mcp.WithArray("level",
-				mcp.Description("Filter logs by their severity level. Wildcards and regex are supported."),
-				mcp.Items(map[string]interface{}{
-					"type": "string",
-				}),
-			),
-			mcp.WithArray("type",
-				mcp.Description("Filter logs by their type. Types include app for application logs, request for request logs, and build for build logs. You can find the full set of types available for a query by using the list_log_label_values tool."),
-				mcp.Items(map[string]interface{}{
-					"type": "string",
-				}),
-			),
-			mcp.WithArray("instance",
-				mcp.Description("Filter logs by the instance they were emitted from. An instance is the id of a specific running server."),
-				mcp.Items(map[string]interface{}{
-					"type": "string",
-				}),
-			),
-			mcp.WithArray("host",
-				mcp.Description("Filter request logs by their host. Wildcards and regex are supported."),
-				mcp.Items(map[string]interface{}{
-					"type": "string",
-				}),
-			),
-			mcp.WithArray("statusCode",
-				mcp.Description("Filter request logs by their status code. Wildcards and regex are supported."),
-				mcp.Items(map[string]interface{}{
-					"type": "string",
-				}),
-			),
-			mcp.WithArray("method",
-				mcp.Description("Filter request logs by their requests method. Wildcards and regex are supported."),
-				mcp.Items(map[string]interface{}{
-					"type": "string",
-				}),
-			),
-			mcp.WithArray("path",
-				mcp.Description("Filter request logs by their path. Wildcards and regex are supported."),
-				mcp.Items(map[string]interface{}{
-					"type": "string",
-				}),
-			),
-			mcp.WithArray("text",
-				mcp.Description("Filter by the text of the logs. Wildcards and regex are supported."),
-				mcp.Items(map[string]interface{}{
-					"type": "string",
-				}),
-			),
-			mcp.WithString("startTime",
-				mcp.Description("Start time for log query (RFC3339 format). "+
-					"Defaults to 1 hour ago. "+
-					"The start time must be within the last 30 days."),
-			),
-			mcp.WithString("endTime",
-				mcp.Description("End time for log query (RFC3339 format). "+
-					"Defaults to the current time. "+
-					"The end time must be within the last 30 days."),
-			),
-			mcp.WithString("direction",
-				mcp.Description("The direction to query logs for. Backward will return most recent logs first. Forward will start with the oldest logs in the time range."),
-				mcp.Enum(string(logsclient.Backward), string(logsclient.Forward)),
-				mcp.DefaultString(string(logsclient.Backward)),
-			),
-			mcp.WithNumber("limit",
-				mcp.Description("Maximum number of logs to return"),
-				mcp.Min(1),
-				mcp.Max(100),
-			),
 		),
+		mcp.WithArray("level",
+			mcp.Description("Filter logs by their severity level. Wildcards and regex are supported."),
+			mcp.Items(map[string]interface{}{
+				"type": "string",
+			}),
+		),
+		mcp.WithArray("type",
+			mcp.Description("Filter logs by their type. Types include app for application logs, request for request logs, and build for build logs. You can find the full set of types available for a query by using the list_log_label_values tool."),
+			mcp.Items(map[string]interface{}{
+				"type": "string",
+			}),
+		),
+		mcp.WithArray("instance",
+			mcp.Description("Filter logs by the instance they were emitted from. An instance is the id of a specific running server."),
+			mcp.Items(map[string]interface{}{
+				"type": "string",
+			}),
+		),
+		mcp.WithArray("host",
+			mcp.Description("Filter request logs by their host. Wildcards and regex are supported."),
+			mcp.Items(map[string]interface{}{
+				"type": "string",
+			}),
+		),
+		mcp.WithArray("statusCode",
+			mcp.Description("Filter request logs by their status code. Wildcards and regex are supported."),
+			mcp.Items(map[string]interface{}{
+				"type": "string",
+			}),
+		),
+		mcp.WithArray("method",
+			mcp.Description("Filter request logs by their requests method. Wildcards and regex are supported."),
+			mcp.Items(map[string]interface{}{
+				"type": "string",
+			}),
+		),
+		mcp.WithArray("path",
+			mcp.Description("Filter request logs by their path. Wildcards and regex are supported."),
+			mcp.Items(map[string]interface{}{
+				"type": "string",
+			}),
+		),
+		mcp.WithArray("text",
+			mcp.Description("Filter by the text of the logs. Wildcards and regex are supported."),
+			mcp.Items(map[string]interface{}{
+				"type": "string",
+			}),
+		),
+	}
+}
+
+// logFiltersFromRequest reads the parameters declared by logFilterOptions.
+func logFiltersFromRequest(request mcp.CallToolRequest, ownerId string) (*client.ListLogsParams, error) {
+	resource, err := validate.RequiredToolArrayParam[string](request, "resource")
+	if err != nil {
+		return nil, err
+	}
+
+	params := &client.ListLogsParams{
+		OwnerId:  ownerId,
+		Resource: resource,
+	}
+
+	filters := []struct {
+		name  string
+		field **[]string
+	}{
+		{"level", &params.Level},
+		{"type", &params.Type},
+		{"instance", &params.Instance},
+		{"host", &params.Host},
+		{"statusCode", &params.StatusCode},
+		{"method", &params.Method},
+		{"path", &params.Path},
+		{"text", &params.Text},
+	}
+	for _, filter := range filters {
+		if values, ok, err := validate.OptionalToolArrayParam[string](request, filter.name); err != nil {
+			return nil, err
+		} else if ok {
+			*filter.field = &values
+		}
+	}
+
+	return params, nil
+}
+
+// logTimeRangeOptions declare the startTime and endTime parameters, which are
+// read with validate.TimeRangeParams.
+func logTimeRangeOptions() []mcp.ToolOption {
+	return []mcp.ToolOption{
+		mcp.WithString("startTime",
+			mcp.Description("Start time for log query. "+validate.TimeFormatsDescription+" "+
+				"Defaults to 1 hour before endTime. "+
+				"The start time must be within the last 30 days."),
+		),
+		mcp.WithString("endTime",
+			mcp.Description("End time for log query. "+validate.TimeFormatsDescription+" "+
+				"Defaults to the current time. "+
+				"The end time must be within the last 30 days."),
+		),
+	}
+}
+
+// listLogsResponse is a page of logs along with the time range it was
+// requested for.
+type listLogsResponse struct {
+	client.Logs200Response
+	NextPageToken string             `json:"nextPageToken,omitempty"`
+	TimeRange     validate.TimeRange `json:"timeRange"`
+}
+
+func listLogs(logRepo *LogRepo) server.ServerTool {
+	opts := []mcp.ToolOption{
+		mcp.WithDescription("List logs matching the provided filters. Logs are paginated by start and end timestamps. " +
+			"There are more logs to fetch if hasMore is true in the response. " +
+			"Provide the nextStartTime and nextEndTime timestamps as the startTime and endTime query parameters to fetch the next page of logs. " +
+			"Set maxLines or maxBytes to have the tool follow hasMore itself until that budget is reached; " +
+			"the response then reports the number of pages fetched, the JSON size of the logs, and which budget stopped paging. " +
+			"You can query for logs across multiple resources in the same workspace. " +
+			"Resources in different regions are queried separately and their logs merged by timestamp; " +
+			"to fetch the next page of such a query, pass nextPageToken from the response as pageToken instead of using nextStartTime and nextEndTime."),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:           "List logs",
+			ReadOnlyHint:    pointers.From(true),
+			DestructiveHint: pointers.From(false),
+			IdempotentHint:  pointers.From(true),
+			OpenWorldHint:   pointers.From(false),
+		}),
+	}
+	opts = append(opts, logFilterOptions()...)
+	opts = append(opts, logTimeRangeOptions()...)
+	opts = append(opts,
+		mcp.WithString("direction",
+			mcp.Description("The direction to query logs for. Backward will return most recent logs first. Forward will start with the oldest logs in the time range."),
+			mcp.Enum(string(logsclient.Backward), string(logsclient.Forward)),
+			mcp.DefaultString(string(logsclient.Backward)),
+		),
+		mcp.WithString("pageToken",
+			mcp.Description("The nextPageToken from a previous response, to fetch the next page of a query across regions. "+
+				"Pass the same resources and filters as the previous call, without startTime and endTime."),
+		),
+		mcp.WithNumber("limit",
+			mcp.Description("Maximum number of logs to return. With maxLines or maxBytes, this is the size of each page fetched. "+
+				"When resources span several regions, the limit is divided between them."),
+			mcp.Min(1),
+			mcp.Max(listLogsPageLimit),
+		),
+		mcp.WithNumber("maxLines",
+			mcp.Description("Fetch pages until this many logs have been returned or there are no more logs."),
+			mcp.Min(1),
+			mcp.Max(maxListLogsMaxLines),
+		),
+		mcp.WithNumber("maxBytes",
+			mcp.Description("Fetch pages until the returned logs reach this many bytes of JSON or there are no more logs. "+
+				"A page that would go over the budget is left out, except for the first page."),
+			mcp.Min(1),
+			mcp.Max(maxListLogsMaxBytes),
+		),
+	)
+
+	return server.ServerTool{
+		Tool: mcp.NewTool("list_logs", opts...),
 		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
 			ownerId, err := session.FromContext(ctx).GetWorkspace(ctx)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			resource, err := validate.RequiredToolArrayParam[string](request, "resource")
+			llParams, err := logFiltersFromRequest(request, ownerId)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			llParams := &client.ListLogsParams{
-				OwnerId:  ownerId,
-				Resource: resource,
+			timeRange, err := validate.TimeRangeParams(request, time.Now())
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
+			llParams.StartTime, llParams.EndTime = timeRange.Start, timeRange.End
 
-			// Optional filters
-			if levelFilters, ok, err := validate.OptionalToolArrayParam[string](request, "level"); err != nil {
+			if direction, ok, err := validate.OptionalToolParam[string](request, "direction"); err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			} else if ok {
-				llParams.Level = &levelFilters
+				directionParam := logsclient.LogDirection(direction)
+				llParams.Direction = &directionParam
 			}
 
-			if typeFilters, ok, err := validate.OptionalToolArrayParam[string](request, "type"); err != nil {
+			if limit, ok, err := validate.OptionalToolParam[float64](request, "limit"); err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			} else if ok {
-				llParams.Type = &typeFilters
+				limitInt := int(limit)
+				llParams.Limit = &limitInt
 			}
 
-			if instanceFilters, ok, err := validate.OptionalToolArrayParam[string](request, "instance"); err != nil {
+			maxLines, pageLines, err := validate.OptionalToolParam[float64](request, "maxLines")
+			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
-			} else if ok {
-				llParams.Instance = &instanceFilters
+			}
+			if pageLines && (maxLines < 1 || maxLines > maxListLogsMaxLines) {
+				return mcp.NewToolResultError(fmt.Sprintf("maxLines must be between 1 and %d", maxListLogsMaxLines)), nil
 			}
 
-			if hostFilters, ok, err := validate.OptionalToolArrayParam[string](request, "host"); err != nil {
+			maxBytes, pageBytes, err := validate.OptionalToolParam[float64](request, "maxBytes")
+			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
-			} else if ok {
-				llParams.Host = &hostFilters
+			}
+			if pageBytes && (maxBytes < 1 || maxBytes > maxListLogsMaxBytes) {
+				return mcp.NewToolResultError(fmt.Sprintf("maxBytes must be between 1 and %d", maxListLogsMaxBytes)), nil
 			}
 
-			if statusCodeFilters, ok, err := validate.OptionalToolArrayParam[string](request, "statusCode"); err != nil {
+			pageToken, _, err := validate.OptionalToolParam[string](request, "pageToken")
+			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
-			} else if ok {
-				llParams.StatusCode = &statusCodeFilters
+			}
+			if pageToken != "" {
+				_, hasStart := request.GetArguments()["startTime"]
+				_, hasEnd := request.GetArguments()["endTime"]
+				if hasStart || hasEnd {
+					return mcp.NewToolResultError("startTime and endTime can't be used with pageToken, which continues the time range of the previous call"), nil
+				}
 			}
 
-			if methodFilters, ok, err := validate.OptionalToolArrayParam[string](request, "method"); err != nil {
+			query, err := newLogQuery(ctx, logRepo, llParams, pageToken)
+			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
-			} else if ok {
-				llParams.Method = &methodFilters
+			}
+			// A continued query reads the rest of the range in its token.
+			if pageToken != "" {
+				timeRange = query.timeRange()
 			}
 
-			if pathFilters, ok, err := validate.OptionalToolArrayParam[string](request, "path"); err != nil {
+			var response any
+			if pageLines || pageBytes {
+				pages, err := listLogPages(ctx, query, int(maxLines), int(maxBytes))
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				pages.TimeRange = timeRange
+				response = pages
+			} else {
+				limit := 0
+				if llParams.Limit != nil {
+					limit = *llParams.Limit
+				}
+				page, err := query.next(ctx, limit)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				query.advance(page)
+				response = listLogsResponse{
+					Logs200Response: page.Logs200Response,
+					NextPageToken:   query.pageToken(),
+					TimeRange:       timeRange,
+				}
+			}
+
+			respJSON, err := json.Marshal(response)
+			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
-			} else if ok {
-				llParams.Path = &pathFilters
 			}
 
-			if textFilters, ok, err := validate.OptionalToolArrayParam[string](request, "text"); err != nil {
+			return mcp.NewToolResultText(string(respJSON)), nil
+		},
+	}
+}
+
+func tailLogsTool(logRepo *LogRepo) server.ServerTool {
+	opts := []mcp.ToolOption{
+		mcp.WithDescription("Stream new logs matching the provided filters as they are emitted, for example to watch a deploy come up. " +
+			"Tailing stops after durationSeconds or once maxLines logs have been received, whichever comes first. " +
+			"Each log is sent to the client as a log message notification, and as a progress notification if the request includes a progress token. " +
+			"The response is a digest of the tailed logs: counts by level, type and instance, the first and last timestamps, " +
+			"the most recent logs, and why tailing stopped. " +
+			"Use list_logs for logs that were emitted before tailing started. " +
+			"All resources must be in the same region and belong to the same owner."),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:           "Tail logs",
+			ReadOnlyHint:    pointers.From(true),
+			DestructiveHint: pointers.From(false),
+			IdempotentHint:  pointers.From(false),
+			OpenWorldHint:   pointers.From(false),
+		}),
+	}
+	opts = append(opts, logFilterOptions()...)
+	opts = append(opts,
+		mcp.WithNumber("durationSeconds",
+			mcp.Description("How long to tail logs for, in seconds."),
+			mcp.Min(1),
+			mcp.Max(maxTailDurationSeconds),
+			mcp.DefaultNumber(defaultTailDurationSeconds),
+		),
+		mcp.WithNumber("maxLines",
+			mcp.Description("Stop tailing once this many logs have been received."),
+			mcp.Min(1),
+			mcp.Max(maxTailMaxLines),
+			mcp.DefaultNumber(defaultTailMaxLines),
+		),
+	)
+
+	return server.ServerTool{
+		Tool: mcp.NewTool("tail_logs", opts...),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ownerId, err := session.FromContext(ctx).GetWorkspace(ctx)
+			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
-			} else if ok {
-				llParams.Text = &textFilters
 			}
 
-			if startTimeStr, ok, err := validate.OptionalToolParam[string](request, "startTime"); err != nil {
+			llParams, err := logFiltersFromRequest(request, ownerId)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			params := client.SubscribeLogsParams(*llParams)
+
+			durationSeconds := float64(defaultTailDurationSeconds)
+			if value, ok, err := validate.OptionalToolParam[float64](request, "durationSeconds"); err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			} else if ok {
-				parsedTime, err := time.Parse(time.RFC3339, startTimeStr)
-				if err != nil {
-					return mcp.NewToolResultError(err.Error()), nil
-				}
-				startTimeParam := client.StartTimeParam(parsedTime)
-				llParams.StartTime = &startTimeParam
+				durationSeconds = value
+			}
+			if durationSeconds < 1 || durationSeconds > maxTailDurationSeconds {
+				return mcp.NewToolResultError(fmt.Sprintf("durationSeconds must be between 1 and %d", maxTailDurationSeconds)), nil
 			}
 
-			if endTimeStr, ok, err := validate.OptionalToolParam[string](request, "endTime"); err != nil {
+			maxLines := defaultTailMaxLines
+			if value, ok, err := validate.OptionalToolParam[float64](request, "maxLines"); err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			} else if ok {
-				parsedTime, err := time.Parse(time.RFC3339, endTimeStr)
-				if err != nil {
-					return mcp.NewToolResultError(err.Error()), nil
-				}
-				endTimeParam := client.EndTimeParam(parsedTime)
-				llParams.EndTime = &endTimeParam
+				maxLines = int(value)
+			}
+			if maxLines < 1 || maxLines > maxTailMaxLines {
+				return mcp.NewToolResultError(fmt.Sprintf("maxLines must be between 1 and %d", maxTailMaxLines)), nil
 			}
 
-			if direction, ok, err := validate.OptionalToolParam[string](request, "direction"); err != nil {
+			var progressToken mcp.ProgressToken
+			if request.Params.Meta != nil {
+				progressToken = request.Params.Meta.ProgressToken
+			}
+
+			duration := time.Duration(durationSeconds * float64(time.Second))
+			digest, err := tailLogs(ctx, logRepo, &params, duration, maxLines, progressToken, clientNotifier(ctx))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			respJSON, err := json.Marshal(digest)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			return mcp.NewToolResultText(string(respJSON)), nil
+		},
+	}
+}
+
+func summarizeLogsTool(logRepo *LogRepo) server.ServerTool {
+	opts := []mcp.ToolOption{
+		mcp.WithDescription("Summarize the logs matching the provided filters by grouping them into message templates. " +
+			"IDs, numbers, UUIDs, IP addresses, hashes and timestamps in each message are masked, so lines that differ only in those values share a template. " +
+			"Returns the most frequent templates with their counts, first and last seen times, levels and one example line each. " +
+			"Use this to answer questions like which errors are happening without reading every line, then use list_logs with a text filter to see specific lines. " +
+			"Pages through up to maxLines logs in the time range. If complete is false, pass nextStartTime and nextEndTime as startTime and endTime to summarize the rest. " +
+			"All resources must be in the same region and belong to the same owner."),
+		mcp.WithToolAnnotation(mcp.ToolAnnotation{
+			Title:           "Summarize logs",
+			ReadOnlyHint:    pointers.From(true),
+			DestructiveHint: pointers.From(false),
+			IdempotentHint:  pointers.From(true),
+			OpenWorldHint:   pointers.From(false),
+		}),
+	}
+	opts = append(opts, logFilterOptions()...)
+	opts = append(opts, logTimeRangeOptions()...)
+	opts = append(opts,
+		mcp.WithNumber("maxLines",
+			mcp.Description("Maximum number of logs to read while summarizing."),
+			mcp.Min(1),
+			mcp.Max(maxSummarizeMaxLines),
+			mcp.DefaultNumber(defaultSummarizeMaxLines),
+		),
+		mcp.WithNumber("topTemplates",
+			mcp.Description("Number of templates to return, most frequent first."),
+			mcp.Min(1),
+			mcp.Max(maxSummarizeTemplates),
+			mcp.DefaultNumber(defaultSummarizeTemplates),
+		),
+	)
+
+	return server.ServerTool{
+		Tool: mcp.NewTool("summarize_logs", opts...),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ownerId, err := session.FromContext(ctx).GetWorkspace(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			params, err := logFiltersFromRequest(request, ownerId)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			timeRange, err := validate.TimeRangeParams(request, time.Now())
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			params.StartTime, params.EndTime = timeRange.Start, timeRange.End
+
+			maxLines := defaultSummarizeMaxLines
+			if value, ok, err := validate.OptionalToolParam[float64](request, "maxLines"); err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			} else if ok {
-				directionParam := logsclient.LogDirection(direction)
-				llParams.Direction = &directionParam
+				maxLines = int(value)
+			}
+			if maxLines < 1 || maxLines > maxSummarizeMaxLines {
+				return mcp.NewToolResultError(fmt.Sprintf("maxLines must be between 1 and %d", maxSummarizeMaxLines)), nil
 			}
 
-			if limit, ok, err := validate.OptionalToolParam[float64](request, "limit"); err != nil {
+			topTemplates := defaultSummarizeTemplates
+			if value, ok, err := validate.OptionalToolParam[float64](request, "topTemplates"); err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			} else if ok {
-				limitInt := int(limit)
-				llParams.Limit = &limitInt
+				topTemplates = int(value)
+			}
+			if topTemplates < 1 || topTemplates > maxSummarizeTemplates {
+				return mcp.NewToolResultError(fmt.Sprintf("topTemplates must be between 1 and %d", maxSummarizeTemplates)), nil
 			}
 
-			response, err := logRepo.ListLogs(ctx, llParams)
+			summary, err := summarizeLogs(ctx, logRepo, params, maxLines, topTemplates)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
+			summary.TimeRange = timeRange
 
-			respJSON, err := json.Marshal(response)
+			respJSON, err := json.Marshal(summary)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
@@ -235,7 +490,7 @@ func listLogLabelValues(logRepo *LogRepo) server.ServerTool {
 		Tool: mcp.NewTool("list_log_label_values",
 			mcp.WithDescription("List all values for a given log label in the logs matching the provided filters. "+
 				"This can be used to discover what values are available for filtering logs using the list_logs tool. "+
-				"You can query for logs across multiple resources, but all resources must be in the same region and belong to the same owner.",
+				"You can query for logs across multiple resources in the same workspace, including resources in different regions.",
 			),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:           "List log label values",
@@ -305,12 +560,12 @@ func listLogLabelValues(logRepo *LogRepo) server.ServerTool {
 				}),
 			),
 			mcp.WithString("startTime",
-				mcp.Description("Start time for log query (RFC3339 format). "+
-					"Defaults to 1 hour ago. "+
+				mcp.Description("Start time for log query. "+validate.TimeFormatsDescription+" "+
+					"Defaults to 1 hour before endTime. "+
 					"The start time must be within the last 30 days."),
 			),
 			mcp.WithString("endTime",
-				mcp.Description("End time for log query (RFC3339 format). "+
+				mcp.Description("End time for log query. "+validate.TimeFormatsDescription+" "+
 					"Defaults to the current time. "+
 					"The end time must be within the last 30 days."),
 			),
@@ -391,41 +646,324 @@ func listLogLabelValues(logRepo *LogRepo) server.ServerTool {
 				params.Text = &textFilters
 			}
 
-			if startTimeStr, ok, err := validate.OptionalToolParam[string](request, "startTime"); err != nil {
+			timeRange, err := validate.TimeRangeParams(request, time.Now())
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			params.StartTime, params.EndTime = timeRange.Start, timeRange.End
+
+			if direction, ok, err := validate.OptionalToolParam[string](request, "direction"); err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			} else if ok {
-				parsedTime, err := time.Parse(time.RFC3339, startTimeStr)
-				if err != nil {
-					return mcp.NewToolResultError(err.Error()), nil
+				directionParam := logsclient.LogDirection(direction)
+				params.Direction = &directionParam
+			}
+
+			values, err := listRegionLogLabelValues(ctx, logRepo, params)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			respJSON, err := json.Marshal(struct {
+				Values    []string           `json:"values"`
+				TimeRange validate.TimeRange `json:"timeRange"`
+			}{values, timeRange})
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			return mcp.NewToolResultText(string(respJSON)), nil
+		},
+	}
+}
+
+func getWorkspaceLogStream(logRepo *LogRepo) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("get_workspace_log_stream",
+			mcp.WithDescription("Get the workspace's log stream, which forwards logs from all of its resources to a syslog endpoint such as a log vendor. "+
+				"Individual resources can override it; see list_resource_log_streams. "+
+				"Tokens are write-only and are never returned."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           "Get workspace log stream",
+				ReadOnlyHint:    pointers.From(true),
+				DestructiveHint: pointers.From(false),
+				IdempotentHint:  pointers.From(true),
+				OpenWorldHint:   pointers.From(false),
+			}),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ownerId, err := session.FromContext(ctx).GetWorkspace(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			stream, err := logRepo.GetOwnerLogStream(ctx, ownerId)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if stream == nil {
+				return mcp.NewToolResultText("No log stream is configured for this workspace."), nil
+			}
+
+			respJSON, err := json.Marshal(maskWorkspaceLogStream(*stream, nil))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			return mcp.NewToolResultText(string(respJSON)), nil
+		},
+	}
+}
+
+func updateWorkspaceLogStream(logRepo *LogRepo) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("update_workspace_log_stream",
+			mcp.WithDescription("Configure the workspace's log stream, which forwards logs from all of its resources to a syslog endpoint such as a log vendor. "+
+				"An omitted endpoint or preview keeps its current value. "+
+				"The current token can never be read back, so updating an existing log stream requires token: pass it again, or an empty string if the log vendor doesn't use one. "+
+				"The token is masked in the response."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           "Update workspace log stream",
+				ReadOnlyHint:    pointers.From(false),
+				DestructiveHint: pointers.From(false),
+				IdempotentHint:  pointers.From(true),
+				OpenWorldHint:   pointers.From(false),
+			}),
+			mcp.WithString("endpoint",
+				mcp.Description("The syslog endpoint to stream logs to, as host:port. Required if the workspace has no log stream yet."),
+			),
+			mcp.WithString("token",
+				mcp.Description("The token the log vendor uses to authenticate the stream. Required if the workspace already has a log stream; pass an empty string if the log vendor doesn't use one."),
+			),
+			mcp.WithString("preview",
+				mcp.Description("Whether to send or drop logs from preview services and preview environments. Defaults to send for a new log stream."),
+				mcp.Enum(string(logsclient.LogStreamPreviewSettingSend), string(logsclient.LogStreamPreviewSettingDrop)),
+			),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ownerId, err := session.FromContext(ctx).GetWorkspace(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			endpoint, hasEndpoint, err := validate.OptionalToolParam[string](request, "endpoint")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			token, hasToken, err := validate.OptionalToolParam[string](request, "token")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			preview, hasPreview, err := validate.OptionalToolParam[string](request, "preview")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if !hasEndpoint && !hasToken && !hasPreview {
+				return mcp.NewToolResultError("at least one of endpoint, token or preview must be provided"), nil
+			}
+
+			current, err := logRepo.GetOwnerLogStream(ctx, ownerId)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// The API never returns the token, so it can't be carried over
+			// like the endpoint and preview setting. Requiring it keeps an
+			// update to the other fields from silently dropping it.
+			if current != nil && !hasToken {
+				return mcp.NewToolResultError("token is required because the workspace already has a log stream and its token can't be read back; pass an empty string if the log vendor doesn't use one"), nil
+			}
+			update := logsclient.LogStreamOwnerUpdate{Preview: logsclient.LogStreamPreviewSettingSend}
+			if current != nil {
+				update.Endpoint = current.Endpoint
+				if current.Preview != nil {
+					update.Preview = *current.Preview
 				}
-				startTimeParam := client.StartTimeParam(parsedTime)
-				params.StartTime = &startTimeParam
+			}
+			if hasEndpoint {
+				update.Endpoint = &endpoint
+			}
+			if hasToken {
+				update.Token = &token
+			}
+			if hasPreview {
+				update.Preview = logsclient.LogStreamPreviewSetting(preview)
+			}
+			if update.Endpoint == nil || *update.Endpoint == "" {
+				return mcp.NewToolResultError("endpoint is required because the workspace has no log stream yet"), nil
+			}
+
+			stream, err := logRepo.UpdateOwnerLogStream(ctx, ownerId, update)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			respJSON, err := json.Marshal(maskWorkspaceLogStream(*stream, update.Token))
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			return mcp.NewToolResultText(string(respJSON)), nil
+		},
+	}
+}
+
+func listResourceLogStreams(logRepo *LogRepo) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("list_resource_log_streams",
+			mcp.WithDescription("List the resources in the workspace that override the workspace log stream, "+
+				"either by sending their logs to a different endpoint or by dropping them. "+
+				"Resources that are not listed use the workspace log stream. "+
+				"Tokens are write-only and are never returned."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           "List resource log streams",
+				ReadOnlyHint:    pointers.From(true),
+				DestructiveHint: pointers.From(false),
+				IdempotentHint:  pointers.From(true),
+				OpenWorldHint:   pointers.From(false),
+			}),
+			mcp.WithArray("resourceIds",
+				mcp.Description("Only list overrides for these resources. A resource is the id of a service, cron job, postgres, or key value instance."),
+				mcp.Items(map[string]interface{}{
+					"type": "string",
+				}),
+			),
+			mcp.WithString("setting",
+				mcp.Description("Only list overrides with this setting."),
+				mcp.Enum(string(logsclient.LogStreamSettingSend), string(logsclient.LogStreamSettingDrop)),
+			),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			ownerId, err := session.FromContext(ctx).GetWorkspace(ctx)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			limit := resourceLogStreamsLimit
+			params := &client.ListResourceLogStreamsParams{
+				OwnerId: &client.OwnerIdParam{ownerId},
+				Limit:   &limit,
 			}
 
-			if endTimeStr, ok, err := validate.OptionalToolParam[string](request, "endTime"); err != nil {
+			if resourceIds, ok, err := validate.OptionalToolArrayParam[string](request, "resourceIds"); err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			} else if ok {
-				parsedTime, err := time.Parse(time.RFC3339, endTimeStr)
-				if err != nil {
-					return mcp.NewToolResultError(err.Error()), nil
-				}
-				endTimeParam := client.EndTimeParam(parsedTime)
-				params.EndTime = &endTimeParam
+				params.ResourceId = &resourceIds
 			}
 
-			if direction, ok, err := validate.OptionalToolParam[string](request, "direction"); err != nil {
+			if setting, ok, err := validate.OptionalToolParam[string](request, "setting"); err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			} else if ok {
-				directionParam := logsclient.LogDirection(direction)
-				params.Direction = &directionParam
+				params.Setting = &logsclient.LogStreamSettingFilter{logsclient.LogStreamSetting(setting)}
 			}
 
-			values, err := logRepo.ListLogLabelValues(ctx, params)
+			streams, err := logRepo.ListResourceLogStreams(ctx, params)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			respJSON, err := json.Marshal(values)
+			masked := make([]resourceLogStream, 0, len(streams))
+			for _, stream := range streams {
+				masked = append(masked, maskResourceLogStream(stream, nil))
+			}
+
+			respJSON, err := json.Marshal(masked)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			result := mcp.NewToolResultText(string(respJSON))
+			if len(streams) >= resourceLogStreamsLimit {
+				result.Content = append(result.Content, mcp.NewTextContent(fmt.Sprintf(
+					"Only the first %d log stream overrides are listed and more may exist; filter by resourceIds or setting to see the rest.",
+					resourceLogStreamsLimit)))
+			}
+			return result, nil
+		},
+	}
+}
+
+func updateResourceLogStream(logRepo *LogRepo) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("update_resource_log_stream",
+			mcp.WithDescription("Override the workspace log stream for one resource. "+
+				"With setting send, the resource's logs go to the given endpoint instead of the workspace's. "+
+				"With setting drop, the resource's logs are not streamed at all. "+
+				"Use delete_resource_log_stream to go back to the workspace log stream. "+
+				"The token is masked in the response."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           "Update resource log stream",
+				ReadOnlyHint:    pointers.From(false),
+				DestructiveHint: pointers.From(false),
+				IdempotentHint:  pointers.From(true),
+				OpenWorldHint:   pointers.From(false),
+			}),
+			mcp.WithString("resourceId",
+				mcp.Required(),
+				mcp.Description("The ID of the resource to configure. A resource is the id of a service, cron job, postgres, or key value instance."),
+			),
+			mcp.WithString("setting",
+				mcp.Required(),
+				mcp.Description("Whether to send the resource's logs to endpoint or drop them."),
+				mcp.Enum(string(logsclient.LogStreamSettingSend), string(logsclient.LogStreamSettingDrop)),
+			),
+			mcp.WithString("endpoint",
+				mcp.Description("The syslog endpoint to stream logs to, as host:port. Required when setting is send, and not allowed when it is drop."),
+			),
+			mcp.WithString("token",
+				mcp.Description("The token the log vendor uses to authenticate the stream, if it requires one. Not allowed when setting is drop."),
+			),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			resourceId, err := validate.RequiredToolParam[string](request, "resourceId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			ownerId, err := logRepo.resourceOwner(ctx, resourceId)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if err := validate.WorkspaceMatches(ctx, ownerId); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			setting, err := validate.RequiredToolParam[string](request, "setting")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			update := logsclient.LogStreamResourceUpdate{Setting: logsclient.LogStreamSetting(setting)}
+			if endpoint, ok, err := validate.OptionalToolParam[string](request, "endpoint"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok && endpoint != "" {
+				update.Endpoint = &endpoint
+			}
+			if token, ok, err := validate.OptionalToolParam[string](request, "token"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok && token != "" {
+				update.Token = &token
+			}
+
+			switch update.Setting {
+			case logsclient.LogStreamSettingSend:
+				if update.Endpoint == nil {
+					return mcp.NewToolResultError("endpoint is required when setting is send"), nil
+				}
+			case logsclient.LogStreamSettingDrop:
+				if update.Endpoint != nil || update.Token != nil {
+					return mcp.NewToolResultError("endpoint and token are not allowed when setting is drop"), nil
+				}
+			default:
+				return mcp.NewToolResultError(fmt.Sprintf("invalid setting: %s. Must be one of: send, drop", setting)), nil
+			}
+
+			stream, err := logRepo.UpdateResourceLogStream(ctx, resourceId, update)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			respJSON, err := json.Marshal(maskResourceLogStream(*stream, update.Token))
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
@@ -434,3 +972,42 @@ func listLogLabelValues(logRepo *LogRepo) server.ServerTool {
 		},
 	}
 }
+
+func deleteResourceLogStream(logRepo *LogRepo) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("delete_resource_log_stream",
+			mcp.WithDescription("Remove a resource's log stream override, so its logs follow the workspace log stream again."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           "Delete resource log stream",
+				ReadOnlyHint:    pointers.From(false),
+				DestructiveHint: pointers.From(true),
+				IdempotentHint:  pointers.From(true),
+				OpenWorldHint:   pointers.From(false),
+			}),
+			mcp.WithString("resourceId",
+				mcp.Required(),
+				mcp.Description("The ID of the resource whose override to remove."),
+			),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			resourceId, err := validate.RequiredToolParam[string](request, "resourceId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			ownerId, err := logRepo.resourceOwner(ctx, resourceId)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if err := validate.WorkspaceMatches(ctx, ownerId); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if err := logRepo.DeleteResourceLogStream(ctx, resourceId); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("Log stream override for %s deleted. Its logs now follow the workspace log stream.", resourceId)), nil
+		},
+	}
+}
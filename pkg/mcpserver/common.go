@@ -61,6 +61,19 @@ func RegionEnumValues() []string {
 	)
 }
 
+func MaxmemoryPolicyEnumValues() []string {
+	return EnumValuesFromClientType(
+		client.Noeviction,
+		client.AllkeysLfu,
+		client.AllkeysLru,
+		client.AllkeysRandom,
+		client.VolatileLfu,
+		client.VolatileLru,
+		client.VolatileRandom,
+		client.VolatileTtl,
+	)
+}
+
 func ServicePlanEnumValues() []string {
 	return EnumValuesFromClientType(ValidServicePlanValues...)
 }
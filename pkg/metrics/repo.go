@@ -22,6 +22,7 @@ type MetricsClient interface {
 	GetMemoryLimitWithResponse(ctx context.Context, params *client.GetMemoryLimitParams, reqEditors ...client.RequestEditorFn) (*client.GetMemoryLimitResponse, error)
 	GetMemoryTargetWithResponse(ctx context.Context, params *client.GetMemoryTargetParams, reqEditors ...client.RequestEditorFn) (*client.GetMemoryTargetResponse, error)
 	GetBandwidthWithResponse(ctx context.Context, params *client.GetBandwidthParams, reqEditors ...client.RequestEditorFn) (*client.GetBandwidthResponse, error)
+	GetReplicationLagWithResponse(ctx context.Context, params *client.GetReplicationLagParams, reqEditors ...client.RequestEditorFn) (*client.GetReplicationLagResponse, error)
 }
 
 type Repo struct {
@@ -46,6 +47,7 @@ const (
 	MetricTypeMemoryLimit       MetricType = "memory_limit"
 	MetricTypeMemoryTarget      MetricType = "memory_target"
 	MetricTypeBandwidthUsage    MetricType = "bandwidth_usage"
+	MetricTypeReplicationLag    MetricType = "replication_lag"
 )
 
 type MetricsRequest struct {
@@ -128,6 +130,8 @@ func (r *Repo) fetchMetric(ctx context.Context, resourceId string, metricType Me
 		data, err = r.getMemoryTarget(ctx, resourceId, req)
 	case MetricTypeBandwidthUsage:
 		data, err = r.getBandwidthUsage(ctx, resourceId, req)
+	case MetricTypeReplicationLag:
+		data, err = r.getReplicationLag(ctx, resourceId, req)
 	default:
 		return MetricData{}, fmt.Errorf("unsupported metric type: %s", metricType)
 	}
@@ -513,3 +517,34 @@ func (r *Repo) getBandwidthUsage(ctx context.Context, resourceId string, req Met
 
 	return *resp.JSON200, nil
 }
+
+func (r *Repo) getReplicationLag(ctx context.Context, resourceId string, req MetricsRequest) (metricstypes.TimeSeriesCollection, error) {
+	params := &client.GetReplicationLagParams{
+		StartTime: req.StartTime,
+		EndTime:   req.EndTime,
+	}
+
+	if req.Resolution != nil {
+		resolutionParam := metricstypes.ResolutionParam(*req.Resolution)
+		params.ResolutionSeconds = &resolutionParam
+	}
+
+	// Replication lag is reported against the primary Postgres instance
+	postgresResource := metricstypes.PostgresResourceQueryParam(resourceId)
+	params.Resource = &postgresResource
+
+	resp, err := r.client.GetReplicationLagWithResponse(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get replication lag metrics: %w", err)
+	}
+
+	if resp.StatusCode() != 200 {
+		return nil, fmt.Errorf("replication lag metrics API returned status %d", resp.StatusCode())
+	}
+
+	if resp.JSON200 == nil {
+		return metricstypes.TimeSeriesCollection{}, nil
+	}
+
+	return *resp.JSON200, nil
+}
@@ -78,6 +78,11 @@ func (m *MockClientWithResponses) GetBandwidthWithResponse(ctx context.Context,
 	return args.Get(0).(*client.GetBandwidthResponse), args.Error(1)
 }
 
+func (m *MockClientWithResponses) GetReplicationLagWithResponse(ctx context.Context, params *client.GetReplicationLagParams, reqEditors ...client.RequestEditorFn) (*client.GetReplicationLagResponse, error) {
+	args := m.Called(ctx, params, reqEditors)
+	return args.Get(0).(*client.GetReplicationLagResponse), args.Error(1)
+}
+
 // MetricsTestSuite provides shared setup and utilities for metrics tests
 type MetricsTestSuite struct {
 	suite.Suite
@@ -250,6 +255,17 @@ func NewMockBandwidthResponse(value float32) *client.GetBandwidthResponse {
 	}
 }
 
+func NewMockReplicationLagResponse(value float32) *client.GetReplicationLagResponse {
+	return &client.GetReplicationLagResponse{
+		HTTPResponse: &http.Response{StatusCode: 200},
+		JSON200: &metricstypes.TimeSeriesCollection{{
+			Unit:   "seconds",
+			Labels: []metricstypes.Label{{Field: "instance", Value: "dpg-123-replica"}},
+			Values: []metricstypes.TimeSeriesValue{{Timestamp: testTimestamp, Value: value}},
+		}},
+	}
+}
+
 func NewMockErrorResponse(statusCode int) *http.Response {
 	return &http.Response{StatusCode: statusCode}
 }
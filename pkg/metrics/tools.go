@@ -26,8 +26,8 @@ func getMetrics(metricsRepo *Repo) server.ServerTool {
 	return server.ServerTool{
 		Tool: mcp.NewTool("get_metrics",
 			mcp.WithDescription("Get performance metrics for any Render resource (services, Postgres databases, key-value stores). "+
-				"Supports CPU usage/limits/targets, memory usage/limits/targets, service instance counts, HTTP request counts and response time metrics, bandwidth usage metrics, database active connection counts for debugging, capacity planning, and performance optimization. "+
-				"Returns time-series data with timestamps and values for the specified time range. "+
+				"Supports CPU usage/limits/targets, memory usage/limits/targets, service instance counts, HTTP request counts and response time metrics, bandwidth usage metrics, database active connection counts, Postgres read replica replication lag for debugging, capacity planning, and performance optimization. "+
+				"Returns time-series data with timestamps and values for the specified time range, along with the absolute time range that was queried. "+
 				"HTTP metrics support filtering by host and path for more granular analysis. "+
 				"Limits and targets help understand resource constraints and autoscaling thresholds. "+
 				"Metrics may be empty if the metric is not valid for the given resource."),
@@ -47,6 +47,7 @@ func getMetrics(metricsRepo *Repo) server.ServerTool {
 					"CPU usage/limits/targets, memory usage/limits/targets, and instance count metrics are available for all resources. "+
 					"HTTP request counts and response time metrics, and bandwidth usage metrics are only available for services. "+
 					"Active connection metrics are only available for databases and key-value stores. "+
+					"Replication lag metrics are only available for Postgres databases with read replicas; pass the primary's ID. "+
 					"Limits show resource constraints, targets show autoscaling thresholds."),
 				mcp.Items(map[string]interface{}{
 					"type": "string",
@@ -56,17 +57,17 @@ func getMetrics(metricsRepo *Repo) server.ServerTool {
 						string(MetricTypeInstanceCount), string(MetricTypeHTTPLatency),
 						string(MetricTypeCPULimit), string(MetricTypeCPUTarget),
 						string(MetricTypeMemoryLimit), string(MetricTypeMemoryTarget),
-						string(MetricTypeBandwidthUsage),
+						string(MetricTypeBandwidthUsage), string(MetricTypeReplicationLag),
 					},
 				}),
 			),
 			mcp.WithString("startTime",
-				mcp.Description("Start time for metrics query in RFC3339 format (e.g., '2024-01-01T12:00:00Z'). "+
-					"Defaults to 1 hour ago. "+
+				mcp.Description("Start time for metrics query. "+validate.TimeFormatsDescription+" "+
+					"Defaults to 1 hour before endTime. "+
 					"The start time must be within the last 30 days."),
 			),
 			mcp.WithString("endTime",
-				mcp.Description("End time for metrics query in RFC3339 format (e.g., '2024-01-01T13:00:00Z'). "+
+				mcp.Description("End time for metrics query. "+validate.TimeFormatsDescription+" "+
 					"Defaults to the current time. "+
 					"The end time must be within the last 30 days."),
 			),
@@ -135,10 +136,10 @@ func getMetrics(metricsRepo *Repo) server.ServerTool {
 
 				metricType := MetricType(mtStr)
 				switch metricType {
-				case MetricTypeCPUUsage, MetricTypeMemoryUsage, MetricTypeHTTPRequestCount, MetricTypeActiveConnections, MetricTypeInstanceCount, MetricTypeHTTPLatency, MetricTypeCPULimit, MetricTypeCPUTarget, MetricTypeMemoryLimit, MetricTypeMemoryTarget, MetricTypeBandwidthUsage:
+				case MetricTypeCPUUsage, MetricTypeMemoryUsage, MetricTypeHTTPRequestCount, MetricTypeActiveConnections, MetricTypeInstanceCount, MetricTypeHTTPLatency, MetricTypeCPULimit, MetricTypeCPUTarget, MetricTypeMemoryLimit, MetricTypeMemoryTarget, MetricTypeBandwidthUsage, MetricTypeReplicationLag:
 					metricTypes = append(metricTypes, metricType)
 				default:
-					return mcp.NewToolResultError(fmt.Sprintf("invalid metric type: %s. Must be one of: cpu_usage, memory_usage, http_request_count, active_connections, instance_count, http_latency, cpu_limit, cpu_target, memory_limit, memory_target, bandwidth_usage", mtStr)), nil
+					return mcp.NewToolResultError(fmt.Sprintf("invalid metric type: %s. Must be one of: cpu_usage, memory_usage, http_request_count, active_connections, instance_count, http_latency, cpu_limit, cpu_target, memory_limit, memory_target, bandwidth_usage, replication_lag", mtStr)), nil
 				}
 			}
 
@@ -152,27 +153,11 @@ func getMetrics(metricsRepo *Repo) server.ServerTool {
 			}
 
 			// Parse optional time parameters
-			if startTimeStr, ok, err := validate.OptionalToolParam[string](request, "startTime"); err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("startTime parameter error: %s", err.Error())), nil
-			} else if ok {
-				parsedTime, err := time.Parse(time.RFC3339, startTimeStr)
-				if err != nil {
-					return mcp.NewToolResultError(fmt.Sprintf("invalid startTime format, expected RFC3339: %s", err.Error())), nil
-				}
-				startTimeParam := client.StartTimeParam(parsedTime)
-				metricsRequest.StartTime = &startTimeParam
-			}
-
-			if endTimeStr, ok, err := validate.OptionalToolParam[string](request, "endTime"); err != nil {
-				return mcp.NewToolResultError(fmt.Sprintf("endTime parameter error: %s", err.Error())), nil
-			} else if ok {
-				parsedTime, err := time.Parse(time.RFC3339, endTimeStr)
-				if err != nil {
-					return mcp.NewToolResultError(fmt.Sprintf("invalid endTime format, expected RFC3339: %s", err.Error())), nil
-				}
-				endTimeParam := client.EndTimeParam(parsedTime)
-				metricsRequest.EndTime = &endTimeParam
+			timeRange, err := validate.TimeRangeParams(request, time.Now())
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
+			metricsRequest.StartTime, metricsRequest.EndTime = timeRange.Start, timeRange.End
 
 			if resolution, ok, err := validate.OptionalToolParam[float64](request, "resolution"); err != nil {
 				return mcp.NewToolResultError(fmt.Sprintf("resolution parameter error: %s", err.Error())), nil
@@ -130,6 +130,15 @@ func (s *MetricsIntegrationSuite) TestSuccessfulMetricsFetching() {
 			},
 			value: 1048576,
 		},
+		{
+			name:       "Replication lag success",
+			metricType: MetricTypeReplicationLag,
+			setupMock: func() {
+				s.mockClient.On("GetReplicationLagWithResponse", mock.Anything, mock.Anything, mock.Anything).
+					Return(NewMockReplicationLagResponse(2.5), nil)
+			},
+			value: 2.5,
+		},
 	}
 
 	for _, tt := range tests {
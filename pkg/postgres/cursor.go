@@ -0,0 +1,464 @@
+package postgres
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/render-oss/render-mcp-server/pkg/authn"
+	"github.com/render-oss/render-mcp-server/pkg/session"
+)
+
+const (
+	// cursorTTL is how long an open cursor waits for its next fetch before it
+	// is closed and its connection returned to the pool.
+	cursorTTL = 5 * time.Minute
+	// cursorFetchBatch is how many rows are fetched from the server at a time
+	// while filling a page, so that a small maxBytes doesn't pull in rows that
+	// won't be returned.
+	cursorFetchBatch = 100
+	// maxCursorsPerPostgres bounds the cursors one caller keeps open on a
+	// single Postgres instance. Each holds a pooled connection, so this
+	// leaves connections free for other tools.
+	maxCursorsPerPostgres = maxConnsPerPostgres / 2
+
+	cursorName        = "mcp_query_cursor"
+	cursorTokenPrefix = "pgc_"
+	cursorValuePrefix = "postgres_cursor:"
+)
+
+var errCursorNotFound = errors.New("cursor not found or expired; run the query again with `paginate`")
+
+// cursorState is what the session store keeps for an open cursor. Only the
+// session that opened a cursor can fetch from it.
+type cursorState struct {
+	PostgresID string       `json:"postgresId"`
+	Format     resultFormat `json:"format"`
+	MaxRows    int          `json:"maxRows"`
+	MaxBytes   int          `json:"maxBytes"`
+}
+
+// sizedRow is a fetched row and its size as rendered in the cursor's format.
+type sizedRow struct {
+	values []any
+	size   int
+}
+
+// openCursor is a server-side cursor and the transaction and connection it
+// lives in. Rows fetched past the end of a page are kept for the next one.
+type openCursor struct {
+	mu sync.Mutex
+
+	tx             pgx.Tx
+	typeMap        *pgtype.Map
+	release        func()
+	credentialHash string
+	format         resultFormat
+
+	columns   []queryColumn
+	pending   []sizedRow
+	exhausted bool
+	closed    bool
+	lastUsed  time.Time
+	timer     *time.Timer
+}
+
+// cursorKey identifies the cursors that count against one caller's
+// maxCursorsPerPostgres.
+type cursorKey struct {
+	postgresID     string
+	credentialHash string
+}
+
+// cursorRegistry holds the cursors open on this server. A cursor is bound to
+// its connection, so fetches must reach the server that opened it.
+type cursorRegistry struct {
+	ttl       time.Duration
+	maxPerKey int
+
+	mu      sync.Mutex
+	cursors map[string]*openCursor
+	// reserved counts the cursors being opened or open for each key.
+	reserved map[cursorKey]int
+}
+
+func newCursorRegistry() *cursorRegistry {
+	return &cursorRegistry{
+		ttl:       cursorTTL,
+		maxPerKey: maxCursorsPerPostgres,
+		cursors:   map[string]*openCursor{},
+		reserved:  map[cursorKey]int{},
+	}
+}
+
+// open declares a cursor for sqlQuery on conn and returns its first page. If
+// more rows remain, the cursor stays open and the page carries a token for
+// fetching the rest; the cursor then owns conn and calls release once it is
+// closed. Otherwise release is called before returning.
+func (r *cursorRegistry) open(
+	ctx context.Context,
+	conn *pgx.Conn,
+	release func(),
+	postgresId, sqlQuery string,
+	params []any,
+	limits queryLimits,
+	format resultFormat,
+) (*queryResult, error) {
+	if len(splitStatements(sqlQuery)) > 1 {
+		release()
+		return nil, errors.New("paginate only supports a single statement")
+	}
+
+	key := cursorKey{postgresID: postgresId, credentialHash: credentialHash(ctx)}
+	if err := r.reserve(key); err != nil {
+		release()
+		return nil, err
+	}
+	releaseConn := release
+	release = func() {
+		r.unreserve(key)
+		releaseConn()
+	}
+
+	// The idle timeout outlives the cursor slightly, so the server only steps
+	// in if this process goes away without closing it.
+	tx, err := beginReadOnlyTx(ctx, conn, limits.StatementTimeout, r.ttl+time.Minute)
+	if err != nil {
+		release()
+		return nil, err
+	}
+
+	c := &openCursor{
+		tx:             tx,
+		typeMap:        conn.TypeMap(),
+		release:        release,
+		credentialHash: key.credentialHash,
+		format:         format,
+	}
+
+	sqlQuery = strings.TrimRight(strings.TrimSpace(sqlQuery), ";")
+	args, err := bindQueryParams(ctx, tx, sqlQuery, params)
+	if err != nil {
+		c.close()
+		return nil, err
+	}
+	if _, err := execStatement(ctx, tx, fmt.Sprintf("DECLARE %s NO SCROLL CURSOR FOR %s", cursorName, sqlQuery), args); err != nil {
+		c.close()
+		return nil, fmt.Errorf("error executing query: %w", err)
+	}
+
+	result, err := c.fetchPage(ctx, limits)
+	if err != nil || c.done() {
+		c.close()
+		return result, err
+	}
+
+	token, err := newCursorToken()
+	if err != nil {
+		c.close()
+		return nil, err
+	}
+	state := cursorState{
+		PostgresID: postgresId,
+		Format:     format,
+		MaxRows:    limits.MaxRows,
+		MaxBytes:   limits.MaxBytes,
+	}
+	if err := session.FromContext(ctx).SetValue(ctx, cursorValuePrefix+token, encodeCursorState(state), r.ttl); err != nil {
+		c.close()
+		return nil, fmt.Errorf("error saving cursor: %w", err)
+	}
+
+	r.mu.Lock()
+	r.cursors[token] = c
+	r.mu.Unlock()
+	r.keepAlive(token, c, result)
+	return result, nil
+}
+
+// reserve claims one of key's cursor slots, which is handed back when the
+// cursor's connection is released.
+func (r *cursorRegistry) reserve(key cursorKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.reserved[key] >= r.maxPerKey {
+		return fmt.Errorf("too many open cursors on Postgres instance %s; fetch the remaining pages or close "+
+			"a cursor with fetch_render_postgres_cursor and `close`, or run the query without `paginate`", key.postgresID)
+	}
+	r.reserved[key]++
+	return nil
+}
+
+func (r *cursorRegistry) unreserve(key cursorKey) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.reserved[key]--
+	if r.reserved[key] <= 0 {
+		delete(r.reserved, key)
+	}
+}
+
+// fetch returns the next page of the cursor identified by token. maxRows and
+// maxBytes override the page limits the cursor was opened with when non-zero.
+func (r *cursorRegistry) fetch(ctx context.Context, token string, maxRows, maxBytes int) (*queryResult, resultFormat, error) {
+	state, c, err := r.lookup(ctx, token)
+	if err != nil {
+		return nil, "", err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.closed {
+		return nil, "", errCursorNotFound
+	}
+	c.timer.Stop()
+
+	limits := queryLimits{MaxRows: state.MaxRows, MaxBytes: state.MaxBytes}
+	if maxRows > 0 {
+		limits.MaxRows = maxRows
+	}
+	if maxBytes > 0 {
+		limits.MaxBytes = maxBytes
+	}
+
+	result, err := c.fetchPage(ctx, limits)
+	if err != nil || c.done() {
+		r.forget(ctx, token)
+		c.closeLocked()
+		return result, state.Format, err
+	}
+
+	if err := session.FromContext(ctx).SetValue(ctx, cursorValuePrefix+token, encodeCursorState(state), r.ttl); err != nil {
+		r.forget(ctx, token)
+		c.closeLocked()
+		return nil, "", fmt.Errorf("error saving cursor: %w", err)
+	}
+	r.keepAliveLocked(token, c, result)
+	return result, state.Format, nil
+}
+
+// closeCursor closes the cursor identified by token before it is exhausted.
+func (r *cursorRegistry) closeCursor(ctx context.Context, token string) error {
+	_, c, err := r.lookup(ctx, token)
+	if err != nil {
+		return err
+	}
+	r.forget(ctx, token)
+	c.close()
+	return nil
+}
+
+// lookup finds an open cursor, checking that it belongs to the caller's
+// session and was opened with the caller's credentials.
+func (r *cursorRegistry) lookup(ctx context.Context, token string) (cursorState, *openCursor, error) {
+	var state cursorState
+	raw, err := session.FromContext(ctx).GetValue(ctx, cursorValuePrefix+token)
+	if errors.Is(err, session.ErrValueNotFound) {
+		return state, nil, errCursorNotFound
+	} else if err != nil {
+		return state, nil, fmt.Errorf("error loading cursor: %w", err)
+	}
+	if err := json.Unmarshal([]byte(raw), &state); err != nil {
+		return state, nil, fmt.Errorf("error loading cursor: %w", err)
+	}
+
+	r.mu.Lock()
+	c, ok := r.cursors[token]
+	r.mu.Unlock()
+	if !ok {
+		_ = session.FromContext(ctx).DeleteValue(ctx, cursorValuePrefix+token)
+		return state, nil, errors.New("cursor is no longer open on this server; run the query again with `paginate`")
+	}
+	if c.credentialHash != credentialHash(ctx) {
+		return state, nil, errCursorNotFound
+	}
+	return state, c, nil
+}
+
+func (r *cursorRegistry) forget(ctx context.Context, token string) {
+	r.mu.Lock()
+	delete(r.cursors, token)
+	r.mu.Unlock()
+	_ = session.FromContext(ctx).DeleteValue(ctx, cursorValuePrefix+token)
+}
+
+func (r *cursorRegistry) keepAlive(token string, c *openCursor, result *queryResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	r.keepAliveLocked(token, c, result)
+}
+
+// keepAliveLocked restarts the cursor's expiry and points result at it.
+func (r *cursorRegistry) keepAliveLocked(token string, c *openCursor, result *queryResult) {
+	c.lastUsed = time.Now()
+	expiresAt := c.lastUsed.Add(r.ttl)
+	result.NextCursor = token
+	result.CursorExpiresAt = &expiresAt
+
+	if c.timer == nil {
+		c.timer = time.AfterFunc(r.ttl, func() { r.expire(token) })
+	} else {
+		c.timer.Reset(r.ttl)
+	}
+}
+
+func (r *cursorRegistry) expire(token string) {
+	r.mu.Lock()
+	c, ok := r.cursors[token]
+	r.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// A fetch may have renewed the cursor while this timer was firing
+	if c.closed || time.Since(c.lastUsed) < r.ttl {
+		return
+	}
+
+	r.mu.Lock()
+	delete(r.cursors, token)
+	r.mu.Unlock()
+	c.closeLocked()
+}
+
+// fetchPage returns up to limits.MaxRows rows within limits.MaxBytes. It reads
+// one row past the page when it can, so that the last page is recognized as
+// such.
+func (c *openCursor) fetchPage(ctx context.Context, limits queryLimits) (*queryResult, error) {
+	pendingBytes := 0
+	for _, row := range c.pending {
+		pendingBytes += row.size
+	}
+
+	for !c.exhausted && len(c.pending) <= limits.MaxRows && pendingBytes <= limits.MaxBytes {
+		want := min(limits.MaxRows+1-len(c.pending), cursorFetchBatch)
+		rows, err := c.tx.Query(ctx, fmt.Sprintf("FETCH FORWARD %d FROM %s", want, cursorName))
+		if err != nil {
+			return nil, fmt.Errorf("error fetching rows: %w", err)
+		}
+		fetched, err := c.readRows(rows)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range fetched {
+			pendingBytes += row.size
+		}
+		c.pending = append(c.pending, fetched...)
+		if len(fetched) < want {
+			c.exhausted = true
+		}
+	}
+
+	page, rest, err := takePage(c.pending, limits)
+	if err != nil {
+		return nil, err
+	}
+	c.pending = rest
+	return &queryResult{
+		Columns:  c.columns,
+		Rows:     page,
+		RowCount: len(page),
+	}, nil
+}
+
+func (c *openCursor) readRows(rows pgx.Rows) ([]sizedRow, error) {
+	defer rows.Close()
+
+	if c.columns == nil {
+		c.columns = queryColumns(rows.FieldDescriptions(), c.typeMap)
+	}
+
+	var fetched []sizedRow
+	for rows.Next() {
+		values, err := rows.Values()
+		if err != nil {
+			return nil, fmt.Errorf("error reading row values: %w", err)
+		}
+		row := jsonValues(values)
+		size, err := encodedRowSize(c.format, c.columns, row)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding row: %w", err)
+		}
+		fetched = append(fetched, sizedRow{values: row, size: size})
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error fetching rows: %w", err)
+	}
+	return fetched, nil
+}
+
+func (c *openCursor) done() bool {
+	return c.exhausted && len(c.pending) == 0
+}
+
+func (c *openCursor) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closeLocked()
+}
+
+func (c *openCursor) closeLocked() {
+	if c.closed {
+		return
+	}
+	c.closed = true
+	if c.timer != nil {
+		c.timer.Stop()
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	_ = c.tx.Rollback(ctx) // Closes the cursor along with the transaction
+	c.release()
+}
+
+// takePage splits the rows that fit within limits off the front of rows. A
+// page always makes progress, so a single row larger than maxBytes is an
+// error rather than an empty page.
+func takePage(rows []sizedRow, limits queryLimits) ([][]any, []sizedRow, error) {
+	page := [][]any{}
+	pageBytes := 0
+	for len(page) < len(rows) && len(page) < limits.MaxRows {
+		row := rows[len(page)]
+		if pageBytes+row.size > limits.MaxBytes {
+			if len(page) == 0 {
+				return nil, nil, fmt.Errorf("the next row is %d bytes, larger than maxBytes (%d); "+
+					"raise maxBytes or select fewer columns", row.size, limits.MaxBytes)
+			}
+			break
+		}
+		pageBytes += row.size
+		page = append(page, row.values)
+	}
+	return page, rows[len(page):], nil
+}
+
+func newCursorToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("error generating cursor token: %w", err)
+	}
+	return cursorTokenPrefix + hex.EncodeToString(b), nil
+}
+
+func credentialHash(ctx context.Context) string {
+	sum := sha256.Sum256([]byte(authn.APITokenFromContext(ctx)))
+	return hex.EncodeToString(sum[:])
+}
+
+func encodeCursorState(state cursorState) string {
+	// cursorState only holds strings and ints, so marshaling can't fail
+	b, _ := json.Marshal(state)
+	return string(b)
+}
@@ -0,0 +1,213 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/render-oss/render-mcp-server/pkg/authn"
+	"github.com/render-oss/render-mcp-server/pkg/session"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeCursorTx serves FETCH statements from an in-memory result set.
+type fakeCursorTx struct {
+	pgx.Tx
+	source     *fakeRows
+	fetches    []int
+	rolledBack bool
+}
+
+func (tx *fakeCursorTx) Query(_ context.Context, sql string, _ ...any) (pgx.Rows, error) {
+	var n int
+	if _, err := fmt.Sscanf(sql, "FETCH FORWARD %d", &n); err != nil {
+		return nil, err
+	}
+	tx.fetches = append(tx.fetches, n)
+
+	remaining := tx.source.values[tx.source.next:]
+	if n > len(remaining) {
+		n = len(remaining)
+	}
+	tx.source.next += n
+	return &fakeRows{fields: tx.source.fields, values: remaining[:n]}, nil
+}
+
+func (tx *fakeCursorTx) Rollback(context.Context) error {
+	tx.rolledBack = true
+	return nil
+}
+
+func cursorTestContext(t *testing.T, token string) context.Context {
+	t.Helper()
+	t.Setenv("RENDER_CONFIG_PATH", filepath.Join(t.TempDir(), "mcp-server.yaml"))
+	ctx := session.ContextWithStdioSession(context.Background())
+	return authn.ContextWithAPIToken(ctx, token)
+}
+
+// registerFakeCursor opens a cursor over rowCount rows the way
+// cursorRegistry.open does, minus the database round trips.
+func registerFakeCursor(t *testing.T, ctx context.Context, r *cursorRegistry, rowCount int, limits queryLimits) (*fakeCursorTx, *bool, *queryResult) {
+	t.Helper()
+	tx := &fakeCursorTx{source: newFakeRows(rowCount)}
+	released := false
+	c := &openCursor{
+		tx:             tx,
+		typeMap:        pgtype.NewMap(),
+		release:        func() { released = true },
+		credentialHash: credentialHash(ctx),
+		format:         formatJSONRows,
+	}
+	t.Cleanup(c.close)
+
+	result, err := c.fetchPage(ctx, limits)
+	require.NoError(t, err)
+
+	token, err := newCursorToken()
+	require.NoError(t, err)
+	state := cursorState{PostgresID: "dpg-1", Format: formatJSONRows, MaxRows: limits.MaxRows, MaxBytes: limits.MaxBytes}
+	require.NoError(t, session.FromContext(ctx).SetValue(ctx, cursorValuePrefix+token, encodeCursorState(state), r.ttl))
+
+	r.mu.Lock()
+	r.cursors[token] = c
+	r.mu.Unlock()
+	r.keepAlive(token, c, result)
+	return tx, &released, result
+}
+
+func TestCursorFetchesPagesUntilExhausted(t *testing.T) {
+	ctx := cursorTestContext(t, "token-a")
+	r := newCursorRegistry()
+	limits := queryLimits{MaxRows: 2, MaxBytes: defaultMaxBytes}
+
+	tx, released, first := registerFakeCursor(t, ctx, r, 5, limits)
+	require.NotEmpty(t, first.NextCursor)
+	require.NotNil(t, first.CursorExpiresAt)
+	assert.Equal(t, 2, first.RowCount)
+	assert.Equal(t, []int{3}, tx.fetches, "one row past the page is read ahead")
+
+	second, format, err := r.fetch(ctx, first.NextCursor, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, formatJSONRows, format)
+	assert.Equal(t, []any{int32(2), "row"}, second.Rows[0])
+	assert.Equal(t, first.NextCursor, second.NextCursor)
+	assert.False(t, *released)
+
+	last, _, err := r.fetch(ctx, first.NextCursor, 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 1, last.RowCount)
+	assert.Empty(t, last.NextCursor)
+	assert.True(t, tx.rolledBack)
+	assert.True(t, *released)
+
+	_, _, err = r.fetch(ctx, first.NextCursor, 0, 0)
+	assert.ErrorIs(t, err, errCursorNotFound)
+}
+
+func TestCursorRejectsOtherCredentials(t *testing.T) {
+	ctx := cursorTestContext(t, "token-a")
+	r := newCursorRegistry()
+	_, _, first := registerFakeCursor(t, ctx, r, 5, queryLimits{MaxRows: 2, MaxBytes: defaultMaxBytes})
+
+	otherCtx := authn.ContextWithAPIToken(ctx, "token-b")
+	_, _, err := r.fetch(otherCtx, first.NextCursor, 0, 0)
+	assert.ErrorIs(t, err, errCursorNotFound)
+
+	_, _, err = r.fetch(ctx, "pgc_unknown", 0, 0)
+	assert.ErrorIs(t, err, errCursorNotFound)
+}
+
+func TestCursorClose(t *testing.T) {
+	ctx := cursorTestContext(t, "token-a")
+	r := newCursorRegistry()
+	tx, released, first := registerFakeCursor(t, ctx, r, 5, queryLimits{MaxRows: 2, MaxBytes: defaultMaxBytes})
+
+	require.NoError(t, r.closeCursor(ctx, first.NextCursor))
+	assert.True(t, tx.rolledBack)
+	assert.True(t, *released)
+
+	_, err := session.FromContext(ctx).GetValue(ctx, cursorValuePrefix+first.NextCursor)
+	assert.ErrorIs(t, err, session.ErrValueNotFound)
+}
+
+func TestCursorExpires(t *testing.T) {
+	ctx := cursorTestContext(t, "token-a")
+	r := newCursorRegistry()
+	r.ttl = 10 * time.Millisecond
+	tx, released, first := registerFakeCursor(t, ctx, r, 5, queryLimits{MaxRows: 2, MaxBytes: defaultMaxBytes})
+
+	r.mu.Lock()
+	c := r.cursors[first.NextCursor]
+	r.mu.Unlock()
+
+	assert.Eventually(t, func() bool {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		return c.closed
+	}, time.Second, 5*time.Millisecond)
+	r.mu.Lock()
+	assert.NotContains(t, r.cursors, first.NextCursor)
+	r.mu.Unlock()
+	assert.True(t, tx.rolledBack)
+	assert.True(t, *released)
+}
+
+func TestCursorOpenLeavesConnectionsForOtherTools(t *testing.T) {
+	ctx := cursorTestContext(t, "token-a")
+	r := newCursorRegistry()
+	key := cursorKey{postgresID: "dpg-1", credentialHash: credentialHash(ctx)}
+	for range maxCursorsPerPostgres {
+		require.NoError(t, r.reserve(key))
+	}
+	assert.Less(t, maxCursorsPerPostgres, maxConnsPerPostgres)
+
+	released := false
+	_, err := r.open(ctx, nil, func() { released = true }, "dpg-1", "SELECT 1", nil, defaultQueryLimits(), formatJSONRows)
+	assert.ErrorContains(t, err, "too many open cursors")
+	assert.True(t, released)
+
+	// Other databases and other callers have their own slots
+	require.NoError(t, r.reserve(cursorKey{postgresID: "dpg-2", credentialHash: key.credentialHash}))
+	require.NoError(t, r.reserve(cursorKey{postgresID: "dpg-1", credentialHash: credentialHash(authn.ContextWithAPIToken(ctx, "token-b"))}))
+
+	r.unreserve(key)
+	require.NoError(t, r.reserve(key))
+}
+
+func TestCursorOpenRejectsMultipleStatements(t *testing.T) {
+	ctx := cursorTestContext(t, "token-a")
+	r := newCursorRegistry()
+
+	released := false
+	_, err := r.open(ctx, nil, func() { released = true }, "dpg-1", "SELECT 1; COMMIT; DROP TABLE users", nil,
+		defaultQueryLimits(), formatJSONRows)
+	assert.ErrorContains(t, err, "paginate only supports a single statement")
+	assert.True(t, released)
+	assert.Empty(t, r.reserved)
+}
+
+func TestTakePage(t *testing.T) {
+	rows := []sizedRow{
+		{values: []any{1}, size: 10},
+		{values: []any{2}, size: 10},
+		{values: []any{3}, size: 10},
+	}
+
+	page, rest, err := takePage(rows, queryLimits{MaxRows: 10, MaxBytes: 25})
+	require.NoError(t, err)
+	assert.Equal(t, [][]any{{1}, {2}}, page)
+	assert.Len(t, rest, 1)
+
+	page, rest, err = takePage(rows, queryLimits{MaxRows: 1, MaxBytes: 100})
+	require.NoError(t, err)
+	assert.Equal(t, [][]any{{1}}, page)
+	assert.Len(t, rest, 2)
+
+	_, _, err = takePage(rows, queryLimits{MaxRows: 10, MaxBytes: 5})
+	require.Error(t, err, "a page must make progress")
+}
@@ -0,0 +1,617 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	metricstypes "github.com/render-oss/render-mcp-server/pkg/client/metrics"
+)
+
+const (
+	severityCritical = "critical"
+	severityWarning  = "warning"
+	severityInfo     = "info"
+)
+
+var severityRank = map[string]int{
+	severityCritical: 0,
+	severityWarning:  1,
+	severityInfo:     2,
+}
+
+const (
+	// connectionsWarningRatio and connectionsCriticalRatio are the shares of
+	// max_connections in use at which connection pressure is reported.
+	connectionsWarningRatio  = 0.75
+	connectionsCriticalRatio = 0.9
+	// longTransactionAge is how long a transaction must have been open to be
+	// reported, and longTransactionCriticalAge when it becomes critical.
+	longTransactionAge         = 5 * time.Minute
+	longTransactionCriticalAge = time.Hour
+	// deadTupleMinCount and deadTupleRatio decide when a table has enough dead
+	// rows to be considered bloated.
+	deadTupleMinCount = 10000
+	deadTupleRatio    = 0.2
+	// indexBloatRatio and indexBloatMinBytes decide when an index is estimated
+	// to be bloated enough to be worth a REINDEX.
+	indexBloatRatio    = 0.5
+	indexBloatMinBytes = 10 << 20
+	// cacheHitRatioTarget is the buffer cache hit ratio below which the
+	// instance probably needs more memory. cacheMinBlocks skips the check on
+	// instances that have barely been used since stats were reset.
+	cacheHitRatioTarget = 0.99
+	cacheMinBlocks      = 10000
+	// slowQueryMeanMs is the mean execution time above which a statement from
+	// pg_stat_statements is called out as slow.
+	slowQueryMeanMs = 1000
+	// diagnosticRowLimit caps the rows each check reports.
+	diagnosticRowLimit = 20
+	// topQueryCount is how many statements from pg_stat_statements are reported.
+	topQueryCount = 5
+	// activeConnectionsWindow is how far back the active_connections metric
+	// is summarized.
+	activeConnectionsWindow = time.Hour
+)
+
+type diagnosticFinding struct {
+	Severity string `json:"severity"`
+	Check    string `json:"check"`
+	Summary  string `json:"summary"`
+	Details  any    `json:"details,omitempty"`
+}
+
+type diagnosisReport struct {
+	Findings          []diagnosticFinding       `json:"findings"`
+	ActiveConnections *activeConnectionsSummary `json:"activeConnections,omitempty"`
+	Errors            []string                  `json:"errors,omitempty"`
+
+	maxConnections int
+}
+
+type activeConnectionsSummary struct {
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+	Latest float32   `json:"latest"`
+	Peak   float32   `json:"peak"`
+}
+
+type activityRow struct {
+	State string `json:"state"`
+	Count int    `json:"count"`
+}
+
+type blockingRow struct {
+	BlockedPID    int32   `json:"blockedPid"`
+	BlockingPID   int32   `json:"blockingPid"`
+	WaitSeconds   float64 `json:"waitSeconds"`
+	BlockedQuery  string  `json:"blockedQuery"`
+	BlockingQuery string  `json:"blockingQuery"`
+	BlockingState string  `json:"blockingState"`
+}
+
+type longTransactionRow struct {
+	PID        int32   `json:"pid"`
+	State      string  `json:"state"`
+	AgeSeconds float64 `json:"ageSeconds"`
+	Query      string  `json:"query"`
+}
+
+type deadTupleRow struct {
+	Schema     string     `json:"schema"`
+	Table      string     `json:"table"`
+	LiveTuples int64      `json:"liveTuples"`
+	DeadTuples int64      `json:"deadTuples"`
+	LastVacuum *time.Time `json:"lastVacuum,omitempty"`
+}
+
+type indexSizeRow struct {
+	Schema        string `json:"schema"`
+	Table         string `json:"table"`
+	Index         string `json:"index"`
+	ActualBytes   int64  `json:"actualBytes"`
+	ExpectedBytes int64  `json:"expectedBytes"`
+}
+
+type cacheStats struct {
+	HeapHit  int64
+	HeapRead int64
+	IdxHit   int64
+	IdxRead  int64
+}
+
+type topQueryRow struct {
+	Query   string  `json:"query"`
+	Calls   int64   `json:"calls"`
+	TotalMs float64 `json:"totalMs"`
+	MeanMs  float64 `json:"meanMs"`
+	Rows    int64   `json:"rows"`
+}
+
+const activitySQL = `
+SELECT COALESCE(state, 'unknown'), count(*)::int
+FROM pg_stat_activity
+WHERE backend_type = 'client backend'
+GROUP BY 1
+ORDER BY 2 DESC`
+
+const blockingLocksSQL = `
+SELECT blocked.pid, blocking.pid,
+	COALESCE(extract(epoch FROM now() - blocked.query_start), 0)::float8,
+	left(blocked.query, 300), left(blocking.query, 300), COALESCE(blocking.state, '')
+FROM pg_stat_activity blocked
+CROSS JOIN LATERAL unnest(pg_blocking_pids(blocked.pid)) AS b(pid)
+JOIN pg_stat_activity blocking ON blocking.pid = b.pid
+ORDER BY 3 DESC
+LIMIT $1`
+
+const longTransactionsSQL = `
+SELECT pid, COALESCE(state, ''), extract(epoch FROM now() - xact_start)::float8, left(query, 300)
+FROM pg_stat_activity
+WHERE xact_start IS NOT NULL
+	AND pid <> pg_backend_pid()
+	AND backend_type = 'client backend'
+	AND now() - xact_start > make_interval(secs => $1)
+ORDER BY xact_start
+LIMIT $2`
+
+const deadTuplesSQL = `
+SELECT schemaname, relname, n_live_tup, n_dead_tup, GREATEST(last_vacuum, last_autovacuum)
+FROM pg_stat_user_tables
+WHERE n_dead_tup >= $1
+ORDER BY n_dead_tup DESC
+LIMIT $2`
+
+// indexSizesSQL estimates how large each btree index would be if tightly
+// packed, from the average width of its key columns in pg_stats. Indexes on
+// expressions or unanalyzed columns have no width and are skipped.
+const indexSizesSQL = `
+WITH idx AS (
+	SELECT n.nspname AS schema, t.relname AS table_name, i.relname AS index_name,
+		i.reltuples, i.relpages,
+		(SELECT sum(s.avg_width) FROM pg_attribute a
+			JOIN pg_stats s ON s.schemaname = n.nspname AND s.tablename = t.relname AND s.attname = a.attname
+			WHERE a.attrelid = i.oid AND a.attnum > 0) AS key_width
+	FROM pg_index x
+	JOIN pg_class i ON i.oid = x.indexrelid
+	JOIN pg_class t ON t.oid = x.indrelid
+	JOIN pg_namespace n ON n.oid = i.relnamespace
+	JOIN pg_am am ON am.oid = i.relam
+	WHERE am.amname = 'btree' AND i.relpages > 0
+		AND n.nspname NOT IN ('pg_catalog', 'information_schema') AND n.nspname NOT LIKE 'pg\_toast%'
+)
+SELECT schema, table_name, index_name,
+	relpages::bigint * current_setting('block_size')::bigint,
+	(ceil(reltuples * (12 + key_width) / ((current_setting('block_size')::int - 40) * 0.9))
+		* current_setting('block_size')::bigint)::bigint
+FROM idx
+WHERE key_width IS NOT NULL AND reltuples > 0
+	AND relpages::bigint * current_setting('block_size')::bigint >= $1
+ORDER BY relpages DESC
+LIMIT $2`
+
+const cacheStatsSQL = `
+SELECT
+	(SELECT COALESCE(sum(heap_blks_hit), 0)::bigint FROM pg_statio_user_tables),
+	(SELECT COALESCE(sum(heap_blks_read), 0)::bigint FROM pg_statio_user_tables),
+	(SELECT COALESCE(sum(idx_blks_hit), 0)::bigint FROM pg_statio_user_indexes),
+	(SELECT COALESCE(sum(idx_blks_read), 0)::bigint FROM pg_statio_user_indexes)`
+
+const topQueriesSQL = `
+SELECT left(query, 300), calls, total_exec_time, mean_exec_time, rows
+FROM %s.pg_stat_statements
+WHERE dbid = (SELECT oid FROM pg_database WHERE datname = current_database())
+ORDER BY total_exec_time DESC
+LIMIT $1`
+
+type diagnosticCheck struct {
+	name string
+	run  func(ctx context.Context, tx pgx.Tx, report *diagnosisReport) ([]diagnosticFinding, error)
+}
+
+var diagnosticChecks = []diagnosticCheck{
+	{name: "connections", run: checkConnections},
+	{name: "blocking_locks", run: checkBlockingLocks},
+	{name: "long_transactions", run: checkLongTransactions},
+	{name: "table_bloat", run: checkTableBloat},
+	{name: "index_bloat", run: checkIndexBloat},
+	{name: "cache_hit_ratio", run: checkCacheHitRatio},
+	{name: "top_queries", run: checkTopQueries},
+}
+
+// runDiagnostics runs each diagnostic check through tx. Each check runs in
+// its own savepoint, so one failing, for example on a missing privilege, does
+// not prevent the others from reporting.
+func runDiagnostics(ctx context.Context, tx pgx.Tx) *diagnosisReport {
+	report := &diagnosisReport{Findings: []diagnosticFinding{}}
+	for _, check := range diagnosticChecks {
+		findings, err := runDiagnosticCheck(ctx, tx, report, check)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("%s: %s", check.name, err.Error()))
+			continue
+		}
+		report.Findings = append(report.Findings, findings...)
+	}
+	return report
+}
+
+func runDiagnosticCheck(ctx context.Context, tx pgx.Tx, report *diagnosisReport, check diagnosticCheck) ([]diagnosticFinding, error) {
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = savepoint.Rollback(ctx) }()
+	return check.run(ctx, savepoint, report)
+}
+
+func checkConnections(ctx context.Context, tx pgx.Tx, report *diagnosisReport) ([]diagnosticFinding, error) {
+	var maxConnections int
+	if err := tx.QueryRow(ctx, "SELECT current_setting('max_connections')::int").Scan(&maxConnections); err != nil {
+		return nil, err
+	}
+	report.maxConnections = maxConnections
+
+	rows, err := queryCatalog(ctx, tx, activitySQL, nil, func(row pgx.CollectableRow) (activityRow, error) {
+		var r activityRow
+		err := row.Scan(&r.State, &r.Count)
+		return r, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return connectionFindings(rows, maxConnections), nil
+}
+
+func checkBlockingLocks(ctx context.Context, tx pgx.Tx, _ *diagnosisReport) ([]diagnosticFinding, error) {
+	rows, err := queryCatalog(ctx, tx, blockingLocksSQL, []any{diagnosticRowLimit}, func(row pgx.CollectableRow) (blockingRow, error) {
+		var r blockingRow
+		err := row.Scan(&r.BlockedPID, &r.BlockingPID, &r.WaitSeconds, &r.BlockedQuery, &r.BlockingQuery, &r.BlockingState)
+		return r, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return blockingFindings(rows), nil
+}
+
+func checkLongTransactions(ctx context.Context, tx pgx.Tx, _ *diagnosisReport) ([]diagnosticFinding, error) {
+	rows, err := queryCatalog(ctx, tx, longTransactionsSQL, []any{longTransactionAge.Seconds(), diagnosticRowLimit},
+		func(row pgx.CollectableRow) (longTransactionRow, error) {
+			var r longTransactionRow
+			err := row.Scan(&r.PID, &r.State, &r.AgeSeconds, &r.Query)
+			return r, err
+		})
+	if err != nil {
+		return nil, err
+	}
+	return longTransactionFindings(rows), nil
+}
+
+func checkTableBloat(ctx context.Context, tx pgx.Tx, _ *diagnosisReport) ([]diagnosticFinding, error) {
+	rows, err := queryCatalog(ctx, tx, deadTuplesSQL, []any{deadTupleMinCount, diagnosticRowLimit},
+		func(row pgx.CollectableRow) (deadTupleRow, error) {
+			var r deadTupleRow
+			err := row.Scan(&r.Schema, &r.Table, &r.LiveTuples, &r.DeadTuples, &r.LastVacuum)
+			return r, err
+		})
+	if err != nil {
+		return nil, err
+	}
+	return tableBloatFindings(rows), nil
+}
+
+func checkIndexBloat(ctx context.Context, tx pgx.Tx, _ *diagnosisReport) ([]diagnosticFinding, error) {
+	rows, err := queryCatalog(ctx, tx, indexSizesSQL, []any{indexBloatMinBytes, diagnosticRowLimit * 5},
+		func(row pgx.CollectableRow) (indexSizeRow, error) {
+			var r indexSizeRow
+			err := row.Scan(&r.Schema, &r.Table, &r.Index, &r.ActualBytes, &r.ExpectedBytes)
+			return r, err
+		})
+	if err != nil {
+		return nil, err
+	}
+	return indexBloatFindings(rows), nil
+}
+
+func checkCacheHitRatio(ctx context.Context, tx pgx.Tx, _ *diagnosisReport) ([]diagnosticFinding, error) {
+	var stats cacheStats
+	err := tx.QueryRow(ctx, cacheStatsSQL).Scan(&stats.HeapHit, &stats.HeapRead, &stats.IdxHit, &stats.IdxRead)
+	if err != nil {
+		return nil, err
+	}
+	return cacheHitFindings(stats), nil
+}
+
+func checkTopQueries(ctx context.Context, tx pgx.Tx, _ *diagnosisReport) ([]diagnosticFinding, error) {
+	var schema string
+	err := tx.QueryRow(ctx, `
+		SELECT n.nspname FROM pg_extension e
+		JOIN pg_namespace n ON n.oid = e.extnamespace
+		WHERE e.extname = 'pg_stat_statements'`).Scan(&schema)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return []diagnosticFinding{{
+			Severity: severityInfo,
+			Check:    "top_queries",
+			Summary: "The pg_stat_statements extension is not installed, so per-query statistics are unavailable. " +
+				"Run CREATE EXTENSION pg_stat_statements to start collecting them.",
+		}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sql := fmt.Sprintf(topQueriesSQL, pgx.Identifier{schema}.Sanitize())
+	rows, err := queryCatalog(ctx, tx, sql, []any{topQueryCount}, func(row pgx.CollectableRow) (topQueryRow, error) {
+		var r topQueryRow
+		err := row.Scan(&r.Query, &r.Calls, &r.TotalMs, &r.MeanMs, &r.Rows)
+		return r, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return topQueryFindings(rows), nil
+}
+
+func connectionFindings(rows []activityRow, maxConnections int) []diagnosticFinding {
+	total := 0
+	byState := map[string]int{}
+	for _, r := range rows {
+		total += r.Count
+		byState[r.State] = r.Count
+	}
+
+	details := map[string]any{
+		"total":          total,
+		"maxConnections": maxConnections,
+		"byState":        byState,
+	}
+	ratio := 0.0
+	if maxConnections > 0 {
+		ratio = float64(total) / float64(maxConnections)
+	}
+
+	finding := diagnosticFinding{
+		Severity: severityInfo,
+		Check:    "connections",
+		Summary:  fmt.Sprintf("%d of %d connections in use.", total, maxConnections),
+		Details:  details,
+	}
+	switch {
+	case ratio >= connectionsCriticalRatio:
+		finding.Severity = severityCritical
+		finding.Summary += " The instance is close to refusing new connections; use a connection pooler or close idle clients."
+	case ratio >= connectionsWarningRatio:
+		finding.Severity = severityWarning
+		finding.Summary += " Connection usage is high; consider a connection pooler."
+	}
+
+	findings := []diagnosticFinding{finding}
+	if idle := byState["idle in transaction"] + byState["idle in transaction (aborted)"]; idle > 0 {
+		findings = append(findings, diagnosticFinding{
+			Severity: severityWarning,
+			Check:    "connections",
+			Summary: fmt.Sprintf("%d connections are idle in a transaction. "+
+				"They hold locks and prevent vacuum from cleaning up; check the application for unfinished transactions.", idle),
+		})
+	}
+	return findings
+}
+
+func blockingFindings(rows []blockingRow) []diagnosticFinding {
+	if len(rows) == 0 {
+		return nil
+	}
+	blocked := map[int32]bool{}
+	for _, r := range rows {
+		blocked[r.BlockedPID] = true
+	}
+	return []diagnosticFinding{{
+		Severity: severityCritical,
+		Check:    "blocking_locks",
+		Summary: fmt.Sprintf("%d sessions are waiting on locks held by other sessions; the longest has waited %s.",
+			len(blocked), formatSeconds(rows[0].WaitSeconds)),
+		Details: rows,
+	}}
+}
+
+func longTransactionFindings(rows []longTransactionRow) []diagnosticFinding {
+	if len(rows) == 0 {
+		return nil
+	}
+	oldest := rows[0]
+	for _, r := range rows[1:] {
+		if r.AgeSeconds > oldest.AgeSeconds {
+			oldest = r
+		}
+	}
+
+	severity := severityWarning
+	if oldest.AgeSeconds >= longTransactionCriticalAge.Seconds() {
+		severity = severityCritical
+	}
+	return []diagnosticFinding{{
+		Severity: severity,
+		Check:    "long_transactions",
+		Summary: fmt.Sprintf("%d transactions have been open for more than %s; the oldest (pid %d, %s) for %s. "+
+			"Long transactions hold locks and keep vacuum from removing dead rows.",
+			len(rows), longTransactionAge, oldest.PID, oldest.State, formatSeconds(oldest.AgeSeconds)),
+		Details: rows,
+	}}
+}
+
+func tableBloatFindings(rows []deadTupleRow) []diagnosticFinding {
+	var bloated []deadTupleRow
+	for _, r := range rows {
+		total := r.LiveTuples + r.DeadTuples
+		if r.DeadTuples >= deadTupleMinCount && total > 0 && float64(r.DeadTuples)/float64(total) >= deadTupleRatio {
+			bloated = append(bloated, r)
+		}
+	}
+	if len(bloated) == 0 {
+		return nil
+	}
+
+	names := make([]string, 0, len(bloated))
+	for _, r := range bloated {
+		names = append(names, r.Schema+"."+r.Table)
+	}
+	return []diagnosticFinding{{
+		Severity: severityWarning,
+		Check:    "table_bloat",
+		Summary: fmt.Sprintf("%d tables have at least %.0f%% dead rows: %s. "+
+			"Autovacuum may be falling behind; consider running VACUUM or tuning autovacuum for these tables.",
+			len(bloated), deadTupleRatio*100, strings.Join(names, ", ")),
+		Details: bloated,
+	}}
+}
+
+func indexBloatFindings(rows []indexSizeRow) []diagnosticFinding {
+	type bloatedIndex struct {
+		indexSizeRow
+		EstimatedBloatRatio float64 `json:"estimatedBloatRatio"`
+	}
+	var bloated []bloatedIndex
+	for _, r := range rows {
+		if r.ActualBytes < indexBloatMinBytes || r.ExpectedBytes >= r.ActualBytes {
+			continue
+		}
+		ratio := 1 - float64(r.ExpectedBytes)/float64(r.ActualBytes)
+		if ratio >= indexBloatRatio {
+			bloated = append(bloated, bloatedIndex{indexSizeRow: r, EstimatedBloatRatio: ratio})
+		}
+	}
+	if len(bloated) == 0 {
+		return nil
+	}
+	if len(bloated) > diagnosticRowLimit {
+		bloated = bloated[:diagnosticRowLimit]
+	}
+
+	names := make([]string, 0, len(bloated))
+	for _, r := range bloated {
+		names = append(names, r.Schema+"."+r.Index)
+	}
+	return []diagnosticFinding{{
+		Severity: severityWarning,
+		Check:    "index_bloat",
+		Summary: fmt.Sprintf("%d indexes are estimated to be more than %.0f%% bloated: %s. "+
+			"This is a rough estimate; REINDEX CONCURRENTLY rebuilds an index without blocking writes.",
+			len(bloated), indexBloatRatio*100, strings.Join(names, ", ")),
+		Details: bloated,
+	}}
+}
+
+func cacheHitFindings(stats cacheStats) []diagnosticFinding {
+	var findings []diagnosticFinding
+	for _, c := range []struct {
+		kind      string
+		hit, read int64
+	}{
+		{"table", stats.HeapHit, stats.HeapRead},
+		{"index", stats.IdxHit, stats.IdxRead},
+	} {
+		if c.hit+c.read < cacheMinBlocks {
+			continue
+		}
+		ratio := float64(c.hit) / float64(c.hit+c.read)
+		finding := diagnosticFinding{
+			Severity: severityInfo,
+			Check:    "cache_hit_ratio",
+			Summary:  fmt.Sprintf("The %s cache hit ratio is %.2f%%.", c.kind, ratio*100),
+			Details:  map[string]any{"ratio": ratio, "blocksHit": c.hit, "blocksRead": c.read},
+		}
+		if ratio < cacheHitRatioTarget {
+			finding.Severity = severityWarning
+			finding.Summary += fmt.Sprintf(" This is below %.0f%%, so queries often read from disk; "+
+				"the working set may not fit in memory and a larger plan could help.", cacheHitRatioTarget*100)
+		}
+		findings = append(findings, finding)
+	}
+	return findings
+}
+
+func topQueryFindings(rows []topQueryRow) []diagnosticFinding {
+	if len(rows) == 0 {
+		return nil
+	}
+	finding := diagnosticFinding{
+		Severity: severityInfo,
+		Check:    "top_queries",
+		Summary:  fmt.Sprintf("The %d statements with the highest total execution time, from pg_stat_statements.", len(rows)),
+		Details:  rows,
+	}
+	slow := 0
+	for _, r := range rows {
+		if r.MeanMs >= slowQueryMeanMs {
+			slow++
+		}
+	}
+	if slow > 0 {
+		finding.Severity = severityWarning
+		finding.Summary += fmt.Sprintf(" %d of them average over %dms per call; "+
+			"use explain_postgres_query to investigate.", slow, slowQueryMeanMs)
+	}
+	return []diagnosticFinding{finding}
+}
+
+// summarizeActiveConnections reduces the active_connections metric to its
+// latest and peak values.
+func summarizeActiveConnections(start, end time.Time, data metricstypes.TimeSeriesCollection) *activeConnectionsSummary {
+	var (
+		summary = &activeConnectionsSummary{Start: start, End: end}
+		latest  time.Time
+		found   bool
+	)
+	for _, series := range data {
+		for _, v := range series.Values {
+			found = true
+			if v.Value > summary.Peak {
+				summary.Peak = v.Value
+			}
+			if !v.Timestamp.Before(latest) {
+				latest = v.Timestamp
+				summary.Latest = v.Value
+			}
+		}
+	}
+	if !found {
+		return nil
+	}
+	return summary
+}
+
+func activeConnectionsFindings(summary *activeConnectionsSummary, maxConnections int) []diagnosticFinding {
+	if summary == nil || maxConnections <= 0 {
+		return nil
+	}
+	ratio := float64(summary.Peak) / float64(maxConnections)
+	if ratio < connectionsWarningRatio {
+		return nil
+	}
+	severity := severityWarning
+	if ratio >= connectionsCriticalRatio {
+		severity = severityCritical
+	}
+	return []diagnosticFinding{{
+		Severity: severity,
+		Check:    "active_connections",
+		Summary: fmt.Sprintf("Active connections peaked at %.0f of %d between %s and %s.",
+			summary.Peak, maxConnections, summary.Start.Format(time.RFC3339), summary.End.Format(time.RFC3339)),
+	}}
+}
+
+// prioritizeFindings orders findings from most to least severe, keeping the
+// order checks ran in within each severity.
+func prioritizeFindings(findings []diagnosticFinding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		return severityRank[findings[i].Severity] < severityRank[findings[j].Severity]
+	})
+}
+
+func formatSeconds(seconds float64) string {
+	return (time.Duration(seconds) * time.Second).String()
+}
@@ -0,0 +1,109 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	metricstypes "github.com/render-oss/render-mcp-server/pkg/client/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnectionFindings(t *testing.T) {
+	findings := connectionFindings([]activityRow{
+		{State: "active", Count: 50},
+		{State: "idle", Count: 38},
+		{State: "idle in transaction", Count: 3},
+	}, 100)
+
+	require.Len(t, findings, 2)
+	assert.Equal(t, severityCritical, findings[0].Severity)
+	assert.Contains(t, findings[0].Summary, "91 of 100")
+	assert.Equal(t, severityWarning, findings[1].Severity)
+	assert.Contains(t, findings[1].Summary, "3 connections are idle in a transaction")
+
+	findings = connectionFindings([]activityRow{{State: "active", Count: 5}}, 100)
+	require.Len(t, findings, 1)
+	assert.Equal(t, severityInfo, findings[0].Severity)
+}
+
+func TestLongTransactionFindings(t *testing.T) {
+	assert.Empty(t, longTransactionFindings(nil))
+
+	findings := longTransactionFindings([]longTransactionRow{
+		{PID: 10, State: "active", AgeSeconds: 600},
+		{PID: 11, State: "idle in transaction", AgeSeconds: 7200},
+	})
+	require.Len(t, findings, 1)
+	assert.Equal(t, severityCritical, findings[0].Severity)
+	assert.Contains(t, findings[0].Summary, "pid 11")
+	assert.Contains(t, findings[0].Summary, "2h0m0s")
+}
+
+func TestBloatFindings(t *testing.T) {
+	tables := tableBloatFindings([]deadTupleRow{
+		{Schema: "public", Table: "events", LiveTuples: 50000, DeadTuples: 40000},
+		{Schema: "public", Table: "users", LiveTuples: 1000000, DeadTuples: 20000},
+	})
+	require.Len(t, tables, 1)
+	assert.Contains(t, tables[0].Summary, "public.events")
+	assert.NotContains(t, tables[0].Summary, "public.users")
+
+	indexes := indexBloatFindings([]indexSizeRow{
+		{Schema: "public", Index: "events_pkey", ActualBytes: 100 << 20, ExpectedBytes: 20 << 20},
+		{Schema: "public", Index: "users_pkey", ActualBytes: 100 << 20, ExpectedBytes: 80 << 20},
+		{Schema: "public", Index: "tiny_idx", ActualBytes: 1 << 20, ExpectedBytes: 1 << 10},
+	})
+	require.Len(t, indexes, 1)
+	assert.Contains(t, indexes[0].Summary, "public.events_pkey")
+	assert.Contains(t, indexes[0].Summary, "1 indexes")
+}
+
+func TestCacheHitFindings(t *testing.T) {
+	assert.Empty(t, cacheHitFindings(cacheStats{HeapHit: 10, HeapRead: 10}), "too few blocks to judge")
+
+	findings := cacheHitFindings(cacheStats{HeapHit: 90000, HeapRead: 10000, IdxHit: 999900, IdxRead: 100})
+	require.Len(t, findings, 2)
+	assert.Equal(t, severityWarning, findings[0].Severity)
+	assert.Contains(t, findings[0].Summary, "table cache hit ratio is 90.00%")
+	assert.Equal(t, severityInfo, findings[1].Severity)
+}
+
+func TestActiveConnectionsSummary(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	assert.Nil(t, summarizeActiveConnections(start, end, nil))
+
+	summary := summarizeActiveConnections(start, end, metricstypes.TimeSeriesCollection{{
+		Values: []metricstypes.TimeSeriesValue{
+			{Timestamp: start.Add(10 * time.Minute), Value: 20},
+			{Timestamp: start.Add(30 * time.Minute), Value: 95},
+			{Timestamp: start.Add(50 * time.Minute), Value: 40},
+		},
+	}})
+	require.NotNil(t, summary)
+	assert.Equal(t, float32(40), summary.Latest)
+	assert.Equal(t, float32(95), summary.Peak)
+
+	findings := activeConnectionsFindings(summary, 100)
+	require.Len(t, findings, 1)
+	assert.Equal(t, severityCritical, findings[0].Severity)
+	assert.Empty(t, activeConnectionsFindings(summary, 1000))
+}
+
+func TestPrioritizeFindings(t *testing.T) {
+	findings := []diagnosticFinding{
+		{Severity: severityInfo, Check: "connections"},
+		{Severity: severityWarning, Check: "table_bloat"},
+		{Severity: severityCritical, Check: "blocking_locks"},
+		{Severity: severityWarning, Check: "index_bloat"},
+	}
+
+	prioritizeFindings(findings)
+
+	var checks []string
+	for _, f := range findings {
+		checks = append(checks, f.Check)
+	}
+	assert.Equal(t, []string{"blocking_locks", "table_bloat", "index_bloat", "connections"}, checks)
+}
@@ -0,0 +1,601 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// writesEnabledEnv enables the execute_render_postgres tool.
+	writesEnabledEnv = "RENDER_MCP_POSTGRES_WRITES_ENABLED"
+	// allowDDLEnv additionally lets execute_render_postgres run schema
+	// changes, including DROP and TRUNCATE.
+	allowDDLEnv = "RENDER_MCP_POSTGRES_ALLOW_DDL"
+)
+
+// statementKind is the class of a SQL statement, which decides whether
+// execute_render_postgres may run it.
+type statementKind string
+
+const (
+	statementRead  statementKind = "read"
+	statementWrite statementKind = "write"
+	statementDDL   statementKind = "ddl"
+	// statementDestructive covers DROP and TRUNCATE, which remove whole
+	// objects or tables of data rather than rows.
+	statementDestructive statementKind = "destructive"
+	// statementTransaction covers transaction control, which would interfere
+	// with the transaction statements are run in.
+	statementTransaction statementKind = "transaction"
+	// statementOther covers everything that isn't recognized, such as COPY,
+	// DO, CALL and SET, whose effects can't be judged from the statement.
+	statementOther statementKind = "other"
+)
+
+var statementKindsByKeyword = map[string]statementKind{
+	"SELECT":    statementRead,
+	"VALUES":    statementRead,
+	"TABLE":     statementRead,
+	"SHOW":      statementRead,
+	"INSERT":    statementWrite,
+	"UPDATE":    statementWrite,
+	"DELETE":    statementWrite,
+	"MERGE":     statementWrite,
+	"CREATE":    statementDDL,
+	"ALTER":     statementDDL,
+	"COMMENT":   statementDDL,
+	"GRANT":     statementDDL,
+	"REVOKE":    statementDDL,
+	"REINDEX":   statementDDL,
+	"CLUSTER":   statementDDL,
+	"REFRESH":   statementDDL,
+	"DROP":      statementDestructive,
+	"TRUNCATE":  statementDestructive,
+	"BEGIN":     statementTransaction,
+	"START":     statementTransaction,
+	"COMMIT":    statementTransaction,
+	"END":       statementTransaction,
+	"ROLLBACK":  statementTransaction,
+	"ABORT":     statementTransaction,
+	"SAVEPOINT": statementTransaction,
+	"RELEASE":   statementTransaction,
+	"PREPARE":   statementTransaction,
+}
+
+// writeConfig controls which statements execute_render_postgres accepts.
+type writeConfig struct {
+	Enabled  bool
+	AllowDDL bool
+}
+
+func writeConfigFromEnv() (writeConfig, error) {
+	enabled, err := boolEnv(writesEnabledEnv)
+	if err != nil {
+		return writeConfig{}, err
+	}
+	allowDDL, err := boolEnv(allowDDLEnv)
+	if err != nil {
+		return writeConfig{}, err
+	}
+	return writeConfig{Enabled: enabled, AllowDDL: allowDDL}, nil
+}
+
+// writeConfigOrDisabled reads the write configuration, leaving writes
+// disabled if it is invalid.
+func writeConfigOrDisabled() writeConfig {
+	config, err := writeConfigFromEnv()
+	if err != nil {
+		log.Printf("Postgres writes disabled: %v", err)
+		return writeConfig{}
+	}
+	return config
+}
+
+// boolEnv parses a boolean environment variable, returning false when unset.
+// Unrecognized values are an error so that a typo doesn't go unnoticed.
+func boolEnv(name string) (bool, error) {
+	switch strings.ToLower(strings.TrimSpace(os.Getenv(name))) {
+	case "":
+		return false, nil
+	case "true", "1", "yes":
+		return true, nil
+	case "false", "0", "no":
+		return false, nil
+	default:
+		return false, fmt.Errorf("%s must be true or false, got %q", name, os.Getenv(name))
+	}
+}
+
+// classifiedStatement is one statement of a script and its kind.
+type classifiedStatement struct {
+	SQL  string        `json:"sql"`
+	Kind statementKind `json:"kind"`
+}
+
+// checkStatements classifies each statement and rejects the script if any
+// statement isn't allowed under config.
+func checkStatements(statements []string, config writeConfig) ([]classifiedStatement, error) {
+	if len(statements) == 0 {
+		return nil, errors.New("no SQL statements to execute")
+	}
+
+	classified := make([]classifiedStatement, len(statements))
+	var blocked []string
+	for i, stmt := range statements {
+		kind := classifyStatement(stmt)
+		classified[i] = classifiedStatement{SQL: stmt, Kind: kind}
+
+		switch kind {
+		case statementWrite:
+		case statementRead:
+			// Reads can still have side effects through the functions they
+			// call, and query_render_postgres runs them read-only.
+			blocked = append(blocked, fmt.Sprintf("statement %d is a read, which is not supported; "+
+				"use query_render_postgres instead", i+1))
+		case statementDDL, statementDestructive:
+			if !config.AllowDDL {
+				blocked = append(blocked, fmt.Sprintf("statement %d is %s, which is disabled unless %s is set",
+					i+1, kind, allowDDLEnv))
+			}
+		default:
+			blocked = append(blocked, fmt.Sprintf("statement %d is %s, which is not supported", i+1, kind))
+		}
+	}
+
+	if len(blocked) > 0 {
+		return classified, fmt.Errorf("refusing to execute: %s", strings.Join(blocked, "; "))
+	}
+	return classified, nil
+}
+
+// classifyStatement decides the kind of a single statement from its
+// keywords. WITH queries are classified by their main statement, or as writes
+// when any of their CTEs modify data, and EXPLAIN ANALYZE is classified as the
+// statement it runs. SELECT INTO creates a table, so a query with a top-level
+// INTO is DDL.
+func classifyStatement(stmt string) statementKind {
+	tokens := statementTokens(stmt)
+	var first sqlToken
+	for _, tok := range tokens {
+		if tok.isWord() {
+			first = tok
+			break
+		}
+	}
+
+	switch first.upper() {
+	case "":
+		return statementOther
+	case "WITH":
+		return classifyWith(stmt, tokens)
+	case "SELECT":
+		if hasTopLevelInto(tokens) {
+			return statementDDL
+		}
+		return statementRead
+	case "EXPLAIN":
+		analyze := false
+		for _, tok := range tokens {
+			kw := tok.upper()
+			if !tok.isWord() || kw == "EXPLAIN" {
+				continue
+			}
+			if kw == "ANALYZE" {
+				analyze = true
+			}
+			if _, ok := statementKindsByKeyword[kw]; ok || kw == "WITH" {
+				if !analyze {
+					return statementRead
+				}
+				return classifyStatement(stmt[tok.pos:])
+			}
+		}
+		return statementOther
+	}
+
+	if kind, ok := statementKindsByKeyword[first.upper()]; ok {
+		return kind
+	}
+	return statementOther
+}
+
+// classifyWith classifies a WITH query by the statement after its CTE list.
+// CTEs can modify data even when the main statement is a SELECT, so a query
+// with an INSERT, UPDATE, DELETE or MERGE CTE is a write.
+func classifyWith(stmt string, tokens []sqlToken) statementKind {
+	write := false
+	var top []sqlToken
+	for i, tok := range tokens {
+		if tok.depth != 0 {
+			continue
+		}
+		top = append(top, tok)
+
+		// A parenthesis after AS or MATERIALIZED opens a CTE body
+		if tok.text != "(" || len(top) < 2 {
+			continue
+		}
+		if prev := top[len(top)-2].upper(); prev != "AS" && prev != "MATERIALIZED" {
+			continue
+		}
+		if i+1 < len(tokens) && classifyStatement(stmt[tokens[i+1].pos:tokenEnd(stmt, tokens, i)]) == statementWrite {
+			write = true
+		}
+	}
+
+	main := withMainStatement(top)
+	kind, ok := statementKindsByKeyword[main.upper()]
+	switch {
+	case !ok:
+		return statementOther
+	case write:
+		return statementWrite
+	case main.upper() == "SELECT":
+		return classifyStatement(stmt[main.pos:])
+	}
+	return kind
+}
+
+// tokenEnd returns the offset of the parenthesis that closes the one opened
+// by tokens[open].
+func tokenEnd(stmt string, tokens []sqlToken, open int) int {
+	for _, tok := range tokens[open+1:] {
+		if tok.text == ")" && tok.depth == tokens[open].depth {
+			return tok.pos
+		}
+	}
+	return len(stmt)
+}
+
+// withMainStatement finds the first word of the statement that follows the
+// CTE list of a WITH query, given the query's top-level tokens, in which each
+// parenthesized part is just its opening and closing parenthesis. It returns
+// the zero token if the CTE list can't be parsed.
+func withMainStatement(top []sqlToken) sqlToken {
+	at := func(i int) string {
+		if i < len(top) {
+			return top[i].upper()
+		}
+		return ""
+	}
+
+	i := 1 // Past WITH
+	if at(i) == "RECURSIVE" {
+		i++
+	}
+	for i < len(top) {
+		i++ // The CTE name
+		if at(i) == "(" {
+			i += 2 // Column names
+		}
+		if at(i) != "AS" {
+			return sqlToken{}
+		}
+		i++
+		if at(i) == "NOT" {
+			i++
+		}
+		if at(i) == "MATERIALIZED" {
+			i++
+		}
+		if at(i) != "(" || at(i+1) != ")" {
+			return sqlToken{}
+		}
+		i += 2
+
+		// SEARCH ... SET column and CYCLE ... USING column clauses
+		for at(i) == "SEARCH" || at(i) == "CYCLE" {
+			last := "SET"
+			if at(i) == "CYCLE" {
+				last = "USING"
+			}
+			for i < len(top) && at(i) != last {
+				i++
+			}
+			i += 2
+		}
+
+		if at(i) != "," {
+			break
+		}
+		i++
+	}
+	if i >= len(top) || !top[i].isWord() {
+		return sqlToken{}
+	}
+	return top[i]
+}
+
+// sqlToken is a bare word or one of "(", ")" and "," in a SQL statement,
+// along with its parenthesis depth and byte offset. Parentheses have the
+// depth outside them.
+type sqlToken struct {
+	text  string
+	depth int
+	pos   int
+}
+
+func (t sqlToken) isWord() bool {
+	return t.text != "" && isWordByte(t.text[0])
+}
+
+func (t sqlToken) upper() string {
+	return strings.ToUpper(t.text)
+}
+
+// statementTokens returns the tokens of stmt, skipping string literals,
+// quoted identifiers and comments.
+func statementTokens(stmt string) []sqlToken {
+	var tokens []sqlToken
+	scanSQL(stmt, func(tok sqlToken) {
+		tokens = append(tokens, tok)
+	}, nil)
+	return tokens
+}
+
+// statementKeywords returns the upper-cased bare words of stmt.
+func statementKeywords(stmt string) []string {
+	var keywords []string
+	for _, tok := range statementTokens(stmt) {
+		if tok.isWord() {
+			keywords = append(keywords, tok.upper())
+		}
+	}
+	return keywords
+}
+
+// hasTopLevelInto reports whether tokens have an INTO outside any
+// parentheses.
+func hasTopLevelInto(tokens []sqlToken) bool {
+	for _, tok := range tokens {
+		if tok.depth == 0 && tok.upper() == "INTO" {
+			return true
+		}
+	}
+	return false
+}
+
+// splitStatements splits a script on top-level semicolons, ignoring those in
+// strings, quoted identifiers, dollar-quoted bodies and comments. Empty
+// statements are dropped.
+func splitStatements(script string) []string {
+	var statements []string
+	start := 0
+	add := func(end int) {
+		if stmt := strings.TrimSpace(script[start:end]); stmt != "" && len(statementKeywords(stmt)) > 0 {
+			statements = append(statements, stmt)
+		}
+	}
+	scanSQL(script, nil, func(i int) {
+		add(i)
+		start = i + 1
+	})
+	add(len(script))
+	return statements
+}
+
+// scanSQL walks a SQL script, calling onToken for each bare word, parenthesis
+// and comma, and onSemicolon with the offset of each top-level semicolon.
+func scanSQL(sql string, onToken func(sqlToken), onSemicolon func(int)) {
+	emit := func(text string, depth, pos int) {
+		if onToken != nil {
+			onToken(sqlToken{text: text, depth: depth, pos: pos})
+		}
+	}
+
+	i := 0
+	depth := 0
+	for i < len(sql) {
+		c := sql[i]
+		switch {
+		case c == '-' && strings.HasPrefix(sql[i:], "--"):
+			if end := strings.IndexByte(sql[i:], '\n'); end >= 0 {
+				i += end + 1
+			} else {
+				i = len(sql)
+			}
+		case c == '/' && strings.HasPrefix(sql[i:], "/*"):
+			// Block comments nest in Postgres
+			depth := 0
+			for i < len(sql) {
+				if strings.HasPrefix(sql[i:], "/*") {
+					depth++
+					i += 2
+				} else if strings.HasPrefix(sql[i:], "*/") {
+					depth--
+					i += 2
+					if depth == 0 {
+						break
+					}
+				} else {
+					i++
+				}
+			}
+		case c == '\'':
+			// E'' strings allow backslash escapes; '' is an escaped quote in both kinds
+			escapes := i > 0 && (sql[i-1] == 'E' || sql[i-1] == 'e') && (i == 1 || !isWordByte(sql[i-2]))
+			i = skipQuoted(sql, i, '\'', escapes)
+		case c == '"':
+			i = skipQuoted(sql, i, '"', false)
+		case c == '$':
+			// Identifiers can contain $, so a$x$ is a name rather than the
+			// start of a dollar quote.
+			if i > 0 && (isWordByte(sql[i-1]) || sql[i-1] == '$') {
+				i++
+			} else if tag, ok := dollarQuoteTag(sql[i:]); ok {
+				if end := strings.Index(sql[i+len(tag):], tag); end >= 0 {
+					i += len(tag) + end + len(tag)
+				} else {
+					i = len(sql)
+				}
+			} else {
+				i++
+			}
+		case c == ';':
+			if onSemicolon != nil {
+				onSemicolon(i)
+			}
+			depth = 0
+			i++
+		case c == '(':
+			emit("(", depth, i)
+			depth++
+			i++
+		case c == ')':
+			depth = max(depth-1, 0)
+			emit(")", depth, i)
+			i++
+		case c == ',':
+			emit(",", depth, i)
+			i++
+		case isWordByte(c) && !isDigit(c):
+			start := i
+			for i < len(sql) && (isWordByte(sql[i]) || sql[i] == '$') {
+				i++
+			}
+			// A word directly followed by a quote is a string prefix such as E''
+			if !(i < len(sql) && sql[i] == '\'' && i-start == 1) {
+				emit(sql[start:i], depth, start)
+			}
+		default:
+			i++
+		}
+	}
+}
+
+func skipQuoted(sql string, i int, quote byte, backslashEscapes bool) int {
+	i++
+	for i < len(sql) {
+		switch {
+		case backslashEscapes && sql[i] == '\\':
+			i += 2
+		case sql[i] == quote && i+1 < len(sql) && sql[i+1] == quote:
+			i += 2
+		case sql[i] == quote:
+			return i + 1
+		default:
+			i++
+		}
+	}
+	return i
+}
+
+// dollarQuoteTag returns the opening tag, such as $$ or $body$, if s starts
+// with one.
+func dollarQuoteTag(s string) (string, bool) {
+	for j := 1; j < len(s); j++ {
+		if s[j] == '$' {
+			return s[:j+1], true
+		}
+		if !isWordByte(s[j]) || (j == 1 && isDigit(s[j])) {
+			// $1 is a parameter, not a tag
+			return "", false
+		}
+	}
+	return "", false
+}
+
+func isWordByte(c byte) bool {
+	return c == '_' || isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c >= 0x80
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// runStatements runs each statement in tx. Statements are run one at a time
+// over the extended protocol, so a statement the splitter got wrong fails
+// rather than running whatever else is in its string.
+func runStatements(ctx context.Context, tx pgx.Tx, statements []classifiedStatement, params []any) ([]statementResult, error) {
+	results := make([]statementResult, 0, len(statements))
+	for i, stmt := range statements {
+		args, err := bindQueryParams(ctx, tx, stmt.SQL, params)
+		if err != nil {
+			return nil, fmt.Errorf("statement %d: %w", i+1, err)
+		}
+		tag, err := execStatement(ctx, tx, stmt.SQL, args)
+		if err != nil {
+			return nil, fmt.Errorf("statement %d failed, nothing was committed: %w", i+1, err)
+		}
+		command, _, _ := strings.Cut(tag.String(), " ")
+		results = append(results, statementResult{
+			classifiedStatement: stmt,
+			Command:             command,
+			RowsAffected:        tag.RowsAffected(),
+		})
+	}
+	return results, nil
+}
+
+// statementResult reports what a statement did.
+type statementResult struct {
+	classifiedStatement
+	Command      string `json:"command"`
+	RowsAffected int64  `json:"rowsAffected"`
+}
+
+type executeResult struct {
+	Committed  bool              `json:"committed"`
+	Statements []statementResult `json:"statements"`
+	Message    string            `json:"message"`
+}
+
+// executeStatements runs statements in a single read-write transaction. The
+// transaction is committed only when commit is set; otherwise it is rolled
+// back after every statement has run, which reports what the statements would
+// change without changing anything. params apply to a single statement only.
+func executeStatements(
+	ctx context.Context,
+	conn *pgx.Conn,
+	statements []classifiedStatement,
+	params []any,
+	statementTimeout time.Duration,
+	commit bool,
+) (*executeResult, error) {
+	if len(params) > 0 && len(statements) > 1 {
+		return nil, errors.New("params can only be used with a single statement")
+	}
+
+	tx, err := conn.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error beginning transaction: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx) // A no-op once the transaction is committed
+	}()
+
+	timeoutMs := statementTimeout.Milliseconds()
+	if _, err := tx.Exec(ctx, fmt.Sprintf("SET LOCAL statement_timeout = %d", timeoutMs)); err != nil {
+		return nil, fmt.Errorf("error setting statement timeout: %w", err)
+	}
+	if _, err := tx.Exec(ctx, fmt.Sprintf("SET LOCAL idle_in_transaction_session_timeout = %d", timeoutMs)); err != nil {
+		return nil, fmt.Errorf("error setting idle transaction timeout: %w", err)
+	}
+
+	results, err := runStatements(ctx, tx, statements, params)
+	if err != nil {
+		return nil, err
+	}
+	result := &executeResult{Statements: results}
+
+	if !commit {
+		result.Message = "Dry run: the statements ran and were rolled back, so nothing was changed. " +
+			"Show the user the affected row counts and ask them to confirm, then call execute_render_postgres " +
+			"again with `confirm` set to true. Counts may differ if the data changes in between."
+		return result, nil
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("error committing transaction: %w", err)
+	}
+	result.Committed = true
+	result.Message = "The statements were committed."
+	return result, nil
+}
@@ -0,0 +1,150 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSplitStatements(t *testing.T) {
+	script := `
+		UPDATE users SET note = 'a;b' WHERE id = 1;
+		-- a comment; with a semicolon
+		INSERT INTO "odd;table" VALUES (E'it\'s;');
+		CREATE FUNCTION f() RETURNS int AS $body$ SELECT 1; $body$ LANGUAGE sql;
+		/* outer /* nested; */ still comment; */ DELETE FROM t WHERE x = $1;
+		;
+	`
+
+	assert.Equal(t, []string{
+		"UPDATE users SET note = 'a;b' WHERE id = 1",
+		"-- a comment; with a semicolon\n\t\tINSERT INTO \"odd;table\" VALUES (E'it\\'s;')",
+		"CREATE FUNCTION f() RETURNS int AS $body$ SELECT 1; $body$ LANGUAGE sql",
+		"/* outer /* nested; */ still comment; */ DELETE FROM t WHERE x = $1",
+	}, splitStatements(script))
+
+	assert.Empty(t, splitStatements("  ; -- only a comment\n"))
+
+	// Identifiers can contain $, so these aren't dollar quotes hiding the DROP
+	assert.Equal(t, []string{
+		"INSERT INTO t SELECT 1 AS a$x$ WHERE false",
+		"DROP TABLE users",
+		"SELECT 1 AS a$x$",
+	}, splitStatements("INSERT INTO t SELECT 1 AS a$x$ WHERE false; DROP TABLE users; SELECT 1 AS a$x$"))
+}
+
+func TestClassifyStatement(t *testing.T) {
+	tests := []struct {
+		sql  string
+		want statementKind
+	}{
+		{"SELECT * FROM users", statementRead},
+		{"update users set name = 'x'", statementWrite},
+		{"INSERT INTO t VALUES ('DROP TABLE t')", statementWrite},
+		{"WITH old AS (SELECT id FROM t) DELETE FROM t USING old WHERE t.id = old.id", statementWrite},
+		{"WITH x AS (SELECT 1) SELECT * FROM x", statementRead},
+		{"SELECT id, name INTO archived_users FROM users", statementDDL},
+		{"WITH x AS (SELECT 1) SELECT * INTO t FROM x", statementDDL},
+		{"WITH x AS (SELECT id FROM t) SELECT * FROM x FOR UPDATE", statementRead},
+		{"WITH update AS (SELECT 1), y (n) AS NOT MATERIALIZED (SELECT 2) SELECT * FROM update, y", statementRead},
+		{"WITH d AS (DELETE FROM t RETURNING *) SELECT * FROM d", statementWrite},
+		{"WITH x AS MATERIALIZED (SELECT 1) UPDATE t SET a = 1 FROM x", statementWrite},
+		{"WITH RECURSIVE r (n) AS (SELECT 1 UNION ALL SELECT n + 1 FROM r) SEARCH DEPTH FIRST BY n SET ord SELECT * FROM r", statementRead},
+		{"WITH x AS (SELECT 1) CALL p()", statementOther},
+		{"EXPLAIN ANALYZE WITH x AS (SELECT 1) SELECT * INTO t FROM x", statementDDL},
+		{"SELECT * FROM t WHERE id IN (SELECT id FROM u) AND note = 'into'", statementRead},
+		{"EXPLAIN DELETE FROM t", statementRead},
+		{"EXPLAIN ANALYZE DELETE FROM t", statementWrite},
+		{"EXPLAIN (ANALYZE, BUFFERS) SELECT 1", statementRead},
+		{"CREATE INDEX ON t (x)", statementDDL},
+		{"ALTER TABLE t ADD COLUMN y int", statementDDL},
+		{"DROP TABLE t", statementDestructive},
+		{"truncate t", statementDestructive},
+		{"BEGIN", statementTransaction},
+		{"COMMIT", statementTransaction},
+		{"DO $$ BEGIN DELETE FROM t; END $$", statementOther},
+		{"COPY t FROM STDIN", statementOther},
+		{"SET search_path = other", statementOther},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, classifyStatement(tt.sql), tt.sql)
+	}
+}
+
+func TestCheckStatements(t *testing.T) {
+	statements := []string{"UPDATE t SET x = 1", "DROP TABLE u"}
+
+	_, err := checkStatements(statements, writeConfig{Enabled: true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "statement 2 is destructive")
+	assert.Contains(t, err.Error(), allowDDLEnv)
+
+	classified, err := checkStatements(statements, writeConfig{Enabled: true, AllowDDL: true})
+	require.NoError(t, err)
+	assert.Equal(t, []classifiedStatement{
+		{SQL: "UPDATE t SET x = 1", Kind: statementWrite},
+		{SQL: "DROP TABLE u", Kind: statementDestructive},
+	}, classified)
+
+	_, err = checkStatements([]string{"COMMIT"}, writeConfig{Enabled: true, AllowDDL: true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not supported")
+
+	_, err = checkStatements([]string{"SELECT pg_terminate_backend(123)"}, writeConfig{Enabled: true, AllowDDL: true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "statement 1 is a read")
+
+	_, err = checkStatements([]string{"SELECT * INTO copy FROM t"}, writeConfig{Enabled: true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "statement 1 is ddl")
+
+	script := "INSERT INTO t SELECT 1 AS a$x$ WHERE false; DROP TABLE users; SELECT 1 AS a$x$"
+	_, err = checkStatements(splitStatements(script), writeConfig{Enabled: true})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "statement 2 is destructive")
+
+	_, err = checkStatements(nil, writeConfig{Enabled: true})
+	require.Error(t, err)
+}
+
+func TestWriteConfigFromEnv(t *testing.T) {
+	t.Setenv(writesEnabledEnv, "")
+	t.Setenv(allowDDLEnv, "")
+	config, err := writeConfigFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, writeConfig{}, config)
+
+	t.Setenv(writesEnabledEnv, "true")
+	config, err = writeConfigFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, writeConfig{Enabled: true}, config)
+
+	t.Setenv(allowDDLEnv, "1")
+	config, err = writeConfigFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, writeConfig{Enabled: true, AllowDDL: true}, config)
+
+	t.Setenv(writesEnabledEnv, "ture")
+	_, err = writeConfigFromEnv()
+	require.Error(t, err)
+	assert.Equal(t, writeConfig{}, writeConfigOrDisabled())
+}
+
+func TestRunStatementsUsesExtendedProtocol(t *testing.T) {
+	tx := &recordingTx{}
+	statements := []classifiedStatement{
+		{SQL: "UPDATE t SET x = 1", Kind: statementWrite},
+		{SQL: "DELETE FROM t; DROP TABLE users", Kind: statementWrite},
+	}
+
+	_, err := runStatements(context.Background(), tx, statements, nil)
+	require.NoError(t, err)
+
+	require.Len(t, tx.args, 2)
+	for _, args := range tx.args {
+		assert.Equal(t, []any{pgx.QueryExecModeDescribeExec}, args)
+	}
+}
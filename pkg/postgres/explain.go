@@ -0,0 +1,317 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	// largeTableRows is the estimated table size above which a sequential scan
+	// is worth pointing out.
+	largeTableRows = 10000
+	// misestimateFactor is how far actual rows must differ from the planner's
+	// estimate, in either direction, to be reported.
+	misestimateFactor = 10
+	// misestimateMinRows ignores misestimates on nodes that only return a
+	// handful of rows, where the ratio is noisy and rarely matters.
+	misestimateMinRows = 100
+	// costliestNodeCount is how many of the most expensive nodes are reported.
+	costliestNodeCount = 3
+)
+
+const (
+	highlightSeqScan     = "seq_scan_large_table"
+	highlightMisestimate = "row_misestimate"
+	highlightCostliest   = "costliest_node"
+)
+
+// rawPlanNode is a node of the plan tree emitted by EXPLAIN (FORMAT JSON).
+type rawPlanNode struct {
+	NodeType        string         `json:"Node Type"`
+	Schema          string         `json:"Schema"`
+	RelationName    string         `json:"Relation Name"`
+	IndexName       string         `json:"Index Name"`
+	JoinType        string         `json:"Join Type"`
+	Filter          string         `json:"Filter"`
+	IndexCond       string         `json:"Index Cond"`
+	HashCond        string         `json:"Hash Cond"`
+	MergeCond       string         `json:"Merge Cond"`
+	TotalCost       float64        `json:"Total Cost"`
+	PlanRows        float64        `json:"Plan Rows"`
+	ActualRows      *float64       `json:"Actual Rows"`
+	ActualLoops     *float64       `json:"Actual Loops"`
+	ActualTotalTime *float64       `json:"Actual Total Time"`
+	Plans           []*rawPlanNode `json:"Plans"`
+}
+
+type rawExplain struct {
+	Plan          *rawPlanNode `json:"Plan"`
+	PlanningTime  *float64     `json:"Planning Time"`
+	ExecutionTime *float64     `json:"Execution Time"`
+}
+
+type planNode struct {
+	ID           int         `json:"id"`
+	NodeType     string      `json:"nodeType"`
+	Schema       string      `json:"schema,omitempty"`
+	Relation     string      `json:"relation,omitempty"`
+	Index        string      `json:"index,omitempty"`
+	JoinType     string      `json:"joinType,omitempty"`
+	Condition    string      `json:"condition,omitempty"`
+	Filter       string      `json:"filter,omitempty"`
+	TotalCost    float64     `json:"totalCost"`
+	PlanRows     float64     `json:"planRows"`
+	ActualRows   *float64    `json:"actualRows,omitempty"`
+	Loops        *float64    `json:"loops,omitempty"`
+	ActualTimeMs *float64    `json:"actualTimeMs,omitempty"`
+	Children     []*planNode `json:"children,omitempty"`
+}
+
+type planHighlight struct {
+	NodeID  int    `json:"nodeId"`
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+}
+
+type planSummary struct {
+	Analyzed        bool            `json:"analyzed"`
+	TotalCost       float64         `json:"totalCost"`
+	PlanningTimeMs  *float64        `json:"planningTimeMs,omitempty"`
+	ExecutionTimeMs *float64        `json:"executionTimeMs,omitempty"`
+	Highlights      []planHighlight `json:"highlights"`
+	Plan            *planNode       `json:"plan"`
+}
+
+// explainQuery runs EXPLAIN for sqlQuery through tx and summarizes the plan.
+// With analyze set the query is executed, so callers must only pass a
+// read-only transaction. VERBOSE makes the plan name each relation's schema,
+// so sizes are looked up for the right table regardless of search_path.
+func explainQuery(ctx context.Context, tx pgx.Tx, sqlQuery string, analyze bool) (*planSummary, error) {
+	options := "VERBOSE, FORMAT JSON"
+	if analyze {
+		options = "ANALYZE, VERBOSE, BUFFERS, FORMAT JSON"
+	}
+	sqlQuery = strings.TrimRight(strings.TrimSpace(sqlQuery), ";")
+
+	var raw []byte
+	if err := tx.QueryRow(ctx, fmt.Sprintf("EXPLAIN (%s) %s", options, sqlQuery)).Scan(&raw); err != nil {
+		return nil, fmt.Errorf("error explaining query: %w", err)
+	}
+
+	explain, err := parseExplain(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	tableRows := map[string]int64{}
+	for _, relation := range seqScanRelations(explain.Plan) {
+		var estimate int64
+		err := tx.QueryRow(ctx,
+			"SELECT reltuples::bigint FROM pg_class WHERE oid = to_regclass("+
+				"CASE WHEN $1 = '' THEN '' ELSE quote_ident($1) || '.' END || quote_ident($2))",
+			relation.Schema, relation.Name,
+		).Scan(&estimate)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error estimating size of %s: %w", relation, err)
+		}
+		tableRows[relation.String()] = estimate
+	}
+
+	return summarizePlan(explain, analyze, tableRows), nil
+}
+
+func parseExplain(raw []byte) (*rawExplain, error) {
+	var explains []rawExplain
+	if err := json.Unmarshal(raw, &explains); err != nil {
+		return nil, fmt.Errorf("error parsing query plan: %w", err)
+	}
+	if len(explains) != 1 || explains[0].Plan == nil {
+		return nil, errors.New("error parsing query plan: expected a single plan")
+	}
+	return &explains[0], nil
+}
+
+// relationName is a relation in a plan. Schema is only empty for plans
+// explained without VERBOSE.
+type relationName struct {
+	Schema string
+	Name   string
+}
+
+func (r relationName) String() string {
+	if r.Schema == "" {
+		return r.Name
+	}
+	return r.Schema + "." + r.Name
+}
+
+func seqScanRelations(root *rawPlanNode) []relationName {
+	seen := map[relationName]bool{}
+	var relations []relationName
+	var walk func(n *rawPlanNode)
+	walk = func(n *rawPlanNode) {
+		relation := relationName{Schema: n.Schema, Name: n.RelationName}
+		if n.NodeType == "Seq Scan" && n.RelationName != "" && !seen[relation] {
+			seen[relation] = true
+			relations = append(relations, relation)
+		}
+		for _, child := range n.Plans {
+			walk(child)
+		}
+	}
+	walk(root)
+	return relations
+}
+
+// summarizePlan condenses a plan tree and points out the nodes most likely to
+// explain a slow query. tableRows holds estimated sizes of sequentially scanned
+// tables, keyed by their schema-qualified names.
+func summarizePlan(explain *rawExplain, analyzed bool, tableRows map[string]int64) *planSummary {
+	summary := &planSummary{
+		Analyzed:        analyzed,
+		TotalCost:       explain.Plan.TotalCost,
+		PlanningTimeMs:  explain.PlanningTime,
+		ExecutionTimeMs: explain.ExecutionTime,
+		Highlights:      []planHighlight{},
+	}
+
+	type nodeCost struct {
+		node      *planNode
+		exclusive float64
+	}
+	var costs []nodeCost
+	nextID := 0
+
+	var condense func(raw *rawPlanNode) *planNode
+	condense = func(raw *rawPlanNode) *planNode {
+		node := &planNode{
+			ID:           nextID,
+			NodeType:     raw.NodeType,
+			Schema:       raw.Schema,
+			Relation:     raw.RelationName,
+			Index:        raw.IndexName,
+			JoinType:     raw.JoinType,
+			Condition:    firstNonEmpty(raw.IndexCond, raw.HashCond, raw.MergeCond),
+			Filter:       raw.Filter,
+			TotalCost:    raw.TotalCost,
+			PlanRows:     raw.PlanRows,
+			ActualRows:   raw.ActualRows,
+			Loops:        raw.ActualLoops,
+			ActualTimeMs: raw.ActualTotalTime,
+		}
+		nextID++
+
+		relation := relationName{Schema: raw.Schema, Name: raw.RelationName}.String()
+		if raw.NodeType == "Seq Scan" {
+			if rows, ok := tableRows[relation]; ok && rows >= largeTableRows {
+				summary.Highlights = append(summary.Highlights, planHighlight{
+					NodeID: node.ID,
+					Kind:   highlightSeqScan,
+					Message: fmt.Sprintf("Sequential scan on %s, which has about %d rows. "+
+						"An index on the filtered columns may help.", relation, rows),
+				})
+			}
+		}
+
+		if raw.ActualRows != nil {
+			if factor, ok := misestimate(raw.PlanRows, *raw.ActualRows); ok {
+				summary.Highlights = append(summary.Highlights, planHighlight{
+					NodeID: node.ID,
+					Kind:   highlightMisestimate,
+					Message: fmt.Sprintf("%s estimated %.0f rows but returned %.0f (%.0fx off). "+
+						"Table statistics may be stale; consider running ANALYZE on the table.",
+						describeNode(raw.NodeType, relation), raw.PlanRows, *raw.ActualRows, factor),
+				})
+			}
+		}
+
+		// Cost and time are cumulative, so a node's own share is what's left
+		// after subtracting its children.
+		exclusive := exclusiveMetric(raw, analyzed)
+		for _, child := range raw.Plans {
+			node.Children = append(node.Children, condense(child))
+		}
+		costs = append(costs, nodeCost{node: node, exclusive: exclusive})
+		return node
+	}
+	summary.Plan = condense(explain.Plan)
+
+	sort.SliceStable(costs, func(i, j int) bool { return costs[i].exclusive > costs[j].exclusive })
+	for i := 0; i < len(costs) && i < costliestNodeCount; i++ {
+		if costs[i].exclusive <= 0 {
+			break
+		}
+		unit := "cost"
+		if analyzed {
+			unit = "ms"
+		}
+		summary.Highlights = append(summary.Highlights, planHighlight{
+			NodeID:  costs[i].node.ID,
+			Kind:    highlightCostliest,
+			Message: fmt.Sprintf("%s accounts for %.2f %s on its own", describeNode(costs[i].node.NodeType, relationName{Schema: costs[i].node.Schema, Name: costs[i].node.Relation}.String()), costs[i].exclusive, unit),
+		})
+	}
+
+	return summary
+}
+
+func exclusiveMetric(raw *rawPlanNode, analyzed bool) float64 {
+	if analyzed && raw.ActualTotalTime != nil {
+		total := *raw.ActualTotalTime * loops(raw)
+		for _, child := range raw.Plans {
+			if child.ActualTotalTime != nil {
+				total -= *child.ActualTotalTime * loops(child)
+			}
+		}
+		return math.Max(total, 0)
+	}
+
+	total := raw.TotalCost
+	for _, child := range raw.Plans {
+		total -= child.TotalCost
+	}
+	return math.Max(total, 0)
+}
+
+func loops(raw *rawPlanNode) float64 {
+	if raw.ActualLoops == nil || *raw.ActualLoops == 0 {
+		return 1
+	}
+	return *raw.ActualLoops
+}
+
+// misestimate reports how far off the planner's row estimate was, if it's far
+// enough off to matter. Both values are per loop, as EXPLAIN reports them.
+func misestimate(planRows, actualRows float64) (float64, bool) {
+	if math.Max(planRows, actualRows) < misestimateMinRows {
+		return 0, false
+	}
+	factor := math.Max(planRows, actualRows) / math.Max(math.Min(planRows, actualRows), 1)
+	return factor, factor >= misestimateFactor
+}
+
+func describeNode(nodeType, relation string) string {
+	if relation != "" {
+		return fmt.Sprintf("%s on %s", nodeType, relation)
+	}
+	return nodeType
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
@@ -0,0 +1,130 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const analyzedPlanJSON = `[
+  {
+    "Plan": {
+      "Node Type": "Hash Join",
+      "Join Type": "Inner",
+      "Total Cost": 2500.0,
+      "Plan Rows": 50,
+      "Actual Rows": 4000,
+      "Actual Loops": 1,
+      "Actual Total Time": 120.0,
+      "Hash Cond": "(orders.user_id = users.id)",
+      "Plans": [
+        {
+          "Node Type": "Seq Scan",
+          "Schema": "billing",
+          "Relation Name": "orders",
+          "Total Cost": 2000.0,
+          "Plan Rows": 90000,
+          "Actual Rows": 90000,
+          "Actual Loops": 1,
+          "Actual Total Time": 100.0,
+          "Filter": "(status = 'open'::text)"
+        },
+        {
+          "Node Type": "Hash",
+          "Total Cost": 20.0,
+          "Plan Rows": 10,
+          "Actual Rows": 10,
+          "Actual Loops": 1,
+          "Actual Total Time": 1.0,
+          "Plans": [
+            {
+              "Node Type": "Index Scan",
+              "Schema": "public",
+              "Relation Name": "users",
+              "Index Name": "users_pkey",
+              "Total Cost": 20.0,
+              "Plan Rows": 10,
+              "Actual Rows": 10,
+              "Actual Loops": 1,
+              "Actual Total Time": 1.0,
+              "Index Cond": "(id < 10)"
+            }
+          ]
+        }
+      ]
+    },
+    "Planning Time": 0.5,
+    "Execution Time": 121.0
+  }
+]`
+
+func TestSummarizePlan(t *testing.T) {
+	explain, err := parseExplain([]byte(analyzedPlanJSON))
+	require.NoError(t, err)
+	assert.Equal(t, []relationName{{Schema: "billing", Name: "orders"}}, seqScanRelations(explain.Plan))
+
+	summary := summarizePlan(explain, true, map[string]int64{"billing.orders": 250000, "orders": 1})
+
+	assert.True(t, summary.Analyzed)
+	assert.Equal(t, 121.0, *summary.ExecutionTimeMs)
+
+	require.NotNil(t, summary.Plan)
+	assert.Equal(t, 0, summary.Plan.ID)
+	assert.Equal(t, "(orders.user_id = users.id)", summary.Plan.Condition)
+	require.Len(t, summary.Plan.Children, 2)
+	assert.Equal(t, 1, summary.Plan.Children[0].ID)
+	indexScan := summary.Plan.Children[1].Children[0]
+	assert.Equal(t, 3, indexScan.ID)
+	assert.Equal(t, "users_pkey", indexScan.Index)
+
+	byKind := map[string][]planHighlight{}
+	for _, h := range summary.Highlights {
+		byKind[h.Kind] = append(byKind[h.Kind], h)
+	}
+
+	require.Len(t, byKind[highlightSeqScan], 1)
+	assert.Equal(t, 1, byKind[highlightSeqScan][0].NodeID)
+	assert.Contains(t, byKind[highlightSeqScan][0].Message, "billing.orders")
+
+	require.Len(t, byKind[highlightMisestimate], 1)
+	assert.Equal(t, 0, byKind[highlightMisestimate][0].NodeID)
+
+	// The seq scan takes 100ms of its own; the join adds 19ms on top of its children
+	require.NotEmpty(t, byKind[highlightCostliest])
+	assert.Equal(t, 1, byKind[highlightCostliest][0].NodeID)
+	assert.Equal(t, 0, byKind[highlightCostliest][1].NodeID)
+}
+
+func TestSummarizePlanSmallSeqScan(t *testing.T) {
+	explain, err := parseExplain([]byte(`[{"Plan": {"Node Type": "Seq Scan", "Relation Name": "flags", "Total Cost": 1.5, "Plan Rows": 12}}]`))
+	require.NoError(t, err)
+
+	summary := summarizePlan(explain, false, map[string]int64{"flags": 12})
+
+	assert.False(t, summary.Analyzed)
+	require.Len(t, summary.Highlights, 1)
+	assert.Equal(t, highlightCostliest, summary.Highlights[0].Kind)
+}
+
+func TestMisestimate(t *testing.T) {
+	tests := []struct {
+		name     string
+		planRows float64
+		actual   float64
+		expected bool
+	}{
+		{name: "Accurate estimate", planRows: 1000, actual: 1200, expected: false},
+		{name: "Underestimate", planRows: 10, actual: 5000, expected: true},
+		{name: "Overestimate", planRows: 5000, actual: 10, expected: true},
+		{name: "Zero actual rows", planRows: 1000, actual: 0, expected: true},
+		{name: "Small counts are ignored", planRows: 1, actual: 50, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ok := misestimate(tt.planRows, tt.actual)
+			assert.Equal(t, tt.expected, ok)
+		})
+	}
+}
@@ -0,0 +1,280 @@
+package postgres
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/render-oss/render-mcp-server/pkg/validate"
+)
+
+// resultFormat selects how query results are rendered for the agent.
+type resultFormat string
+
+const (
+	// formatJSONRows renders each row as an object keyed by column name.
+	formatJSONRows resultFormat = "json_rows"
+	// formatColumnar renders the columns once, followed by one array of
+	// values per column.
+	formatColumnar resultFormat = "columnar"
+	// formatCSV renders a CSV document with a header row.
+	formatCSV resultFormat = "csv"
+	// formatMarkdown renders a Markdown table.
+	formatMarkdown resultFormat = "markdown"
+)
+
+var resultFormats = []string{
+	string(formatJSONRows),
+	string(formatColumnar),
+	string(formatCSV),
+	string(formatMarkdown),
+}
+
+func resultFormatFromRequest(request mcp.CallToolRequest) (resultFormat, error) {
+	format, ok, err := validate.OptionalToolParam[string](request, "format")
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return formatJSONRows, nil
+	}
+	for _, f := range resultFormats {
+		if format == f {
+			return resultFormat(format), nil
+		}
+	}
+	return "", fmt.Errorf("invalid format %q, must be one of: %s", format, strings.Join(resultFormats, ", "))
+}
+
+type jsonRowsResult struct {
+	Columns         []queryColumn    `json:"columns"`
+	Rows            []map[string]any `json:"rows"`
+	RowCount        int              `json:"rowCount"`
+	Truncated       bool             `json:"truncated"`
+	TruncatedReason string           `json:"truncatedReason,omitempty"`
+	NextCursor      string           `json:"nextCursor,omitempty"`
+	CursorExpiresAt *time.Time       `json:"cursorExpiresAt,omitempty"`
+	Target          *queryTarget     `json:"target,omitempty"`
+}
+
+type columnarResult struct {
+	Columns         []queryColumn `json:"columns"`
+	Values          [][]any       `json:"values"`
+	RowCount        int           `json:"rowCount"`
+	Truncated       bool          `json:"truncated"`
+	TruncatedReason string        `json:"truncatedReason,omitempty"`
+	NextCursor      string        `json:"nextCursor,omitempty"`
+	CursorExpiresAt *time.Time    `json:"cursorExpiresAt,omitempty"`
+	Target          *queryTarget  `json:"target,omitempty"`
+}
+
+// tabularResultMeta accompanies CSV and Markdown output, which have no room
+// for column types or the truncation flag.
+type tabularResultMeta struct {
+	Columns         []queryColumn `json:"columns"`
+	RowCount        int           `json:"rowCount"`
+	Truncated       bool          `json:"truncated"`
+	TruncatedReason string        `json:"truncatedReason,omitempty"`
+	NextCursor      string        `json:"nextCursor,omitempty"`
+	CursorExpiresAt *time.Time    `json:"cursorExpiresAt,omitempty"`
+	Target          *queryTarget  `json:"target,omitempty"`
+}
+
+// encodedRowSize is how many bytes a row adds to the rendered result. It is
+// what maxBytes is measured against.
+func encodedRowSize(format resultFormat, columns []queryColumn, row []any) (int, error) {
+	switch format {
+	case formatColumnar:
+		b, err := json.Marshal(row)
+		return len(b), err
+	case formatCSV:
+		s, err := csvLines([][]string{textValues(row)})
+		return len(s), err
+	case formatMarkdown:
+		return len(markdownRow(markdownValues(row))), nil
+	default:
+		b, err := json.Marshal(rowMap(columns, row))
+		return len(b), err
+	}
+}
+
+// renderQueryResult renders result as a tool result in the given format.
+// JSON formats produce a single document. CSV and Markdown produce the table
+// followed by a JSON document with the column types and truncation flag.
+func renderQueryResult(result *queryResult, format resultFormat) (*mcp.CallToolResult, error) {
+	switch format {
+	case formatColumnar:
+		values := make([][]any, len(result.Columns))
+		for i := range values {
+			values[i] = make([]any, 0, len(result.Rows))
+			for _, row := range result.Rows {
+				values[i] = append(values[i], row[i])
+			}
+		}
+		return jsonToolResult(columnarResult{
+			Columns:         result.Columns,
+			Values:          values,
+			RowCount:        result.RowCount,
+			Truncated:       result.Truncated,
+			TruncatedReason: result.TruncatedReason,
+			NextCursor:      result.NextCursor,
+			CursorExpiresAt: result.CursorExpiresAt,
+			Target:          result.Target,
+		})
+	case formatCSV, formatMarkdown:
+		var table string
+		if format == formatCSV {
+			lines := [][]string{columnNames(result.Columns)}
+			for _, row := range result.Rows {
+				lines = append(lines, textValues(row))
+			}
+			var err error
+			if table, err = csvLines(lines); err != nil {
+				return nil, err
+			}
+		} else {
+			table = markdownTable(result)
+		}
+
+		metaJSON, err := json.Marshal(tabularResultMeta{
+			Columns:         result.Columns,
+			RowCount:        result.RowCount,
+			Truncated:       result.Truncated,
+			TruncatedReason: result.TruncatedReason,
+			NextCursor:      result.NextCursor,
+			CursorExpiresAt: result.CursorExpiresAt,
+			Target:          result.Target,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return &mcp.CallToolResult{
+			Content: []mcp.Content{
+				mcp.NewTextContent(table),
+				mcp.NewTextContent(string(metaJSON)),
+			},
+		}, nil
+	default:
+		rows := make([]map[string]any, 0, len(result.Rows))
+		for _, row := range result.Rows {
+			rows = append(rows, rowMap(result.Columns, row))
+		}
+		return jsonToolResult(jsonRowsResult{
+			Columns:         result.Columns,
+			Rows:            rows,
+			RowCount:        result.RowCount,
+			Truncated:       result.Truncated,
+			TruncatedReason: result.TruncatedReason,
+			NextCursor:      result.NextCursor,
+			CursorExpiresAt: result.CursorExpiresAt,
+			Target:          result.Target,
+		})
+	}
+}
+
+func jsonToolResult(v any) (*mcp.CallToolResult, error) {
+	respJSON, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return mcp.NewToolResultText(string(respJSON)), nil
+}
+
+func rowMap(columns []queryColumn, row []any) map[string]any {
+	m := make(map[string]any, len(columns))
+	for i, col := range columns {
+		m[col.Name] = row[i]
+	}
+	return m
+}
+
+func columnNames(columns []queryColumn) []string {
+	names := make([]string, len(columns))
+	for i, col := range columns {
+		names[i] = col.Name
+	}
+	return names
+}
+
+func csvLines(lines [][]string) (string, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.WriteAll(lines); err != nil {
+		return "", fmt.Errorf("error writing CSV: %w", err)
+	}
+	return buf.String(), nil
+}
+
+func markdownTable(result *queryResult) string {
+	var b strings.Builder
+	names := columnNames(result.Columns)
+	for i, name := range names {
+		names[i] = markdownEscape(name)
+	}
+	b.WriteString(markdownRow(names))
+
+	separators := make([]string, len(names))
+	for i := range separators {
+		separators[i] = "---"
+	}
+	b.WriteString(markdownRow(separators))
+
+	for _, row := range result.Rows {
+		b.WriteString(markdownRow(markdownValues(row)))
+	}
+	return b.String()
+}
+
+func markdownRow(cells []string) string {
+	return "| " + strings.Join(cells, " | ") + " |\n"
+}
+
+func markdownValues(row []any) []string {
+	cells := make([]string, len(row))
+	for i, v := range row {
+		if v == nil {
+			cells[i] = "NULL"
+			continue
+		}
+		cells[i] = markdownEscape(textValue(v))
+	}
+	return cells
+}
+
+var markdownEscaper = strings.NewReplacer(`|`, `\|`, "\r\n", "<br>", "\n", "<br>", "\r", "<br>")
+
+func markdownEscape(s string) string {
+	return markdownEscaper.Replace(s)
+}
+
+func textValues(row []any) []string {
+	values := make([]string, len(row))
+	for i, v := range row {
+		values[i] = textValue(v)
+	}
+	return values
+}
+
+// textValue renders a value for CSV and Markdown. Strings are used as is,
+// NULL becomes empty, and everything else is rendered as it would be in JSON.
+func textValue(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	}
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprint(v)
+	}
+	var s string
+	if json.Unmarshal(b, &s) == nil {
+		return s
+	}
+	return string(b)
+}
@@ -0,0 +1,135 @@
+package postgres
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testQueryResult() *queryResult {
+	return &queryResult{
+		Columns: []queryColumn{
+			{Name: "id", TypeOID: pgtype.Int4OID, TypeName: "int4"},
+			{Name: "note", TypeOID: pgtype.TextOID, TypeName: "text"},
+		},
+		Rows: [][]any{
+			{int32(1), "plain"},
+			{int32(2), "a|b, \"quoted\"\nnext line"},
+			{int32(3), nil},
+		},
+		RowCount:        3,
+		Truncated:       true,
+		TruncatedReason: truncatedByMaxRows,
+	}
+}
+
+func resultTexts(t *testing.T, result *mcp.CallToolResult) []string {
+	t.Helper()
+	var texts []string
+	for _, content := range result.Content {
+		text, ok := content.(mcp.TextContent)
+		require.True(t, ok)
+		texts = append(texts, text.Text)
+	}
+	return texts
+}
+
+func TestRenderQueryResultJSONRows(t *testing.T) {
+	rendered, err := renderQueryResult(testQueryResult(), formatJSONRows)
+	require.NoError(t, err)
+
+	texts := resultTexts(t, rendered)
+	require.Len(t, texts, 1)
+	assert.JSONEq(t, `{
+		"columns": [{"name":"id","typeOid":23,"typeName":"int4"},{"name":"note","typeOid":25,"typeName":"text"}],
+		"rows": [{"id":1,"note":"plain"},{"id":2,"note":"a|b, \"quoted\"\nnext line"},{"id":3,"note":null}],
+		"rowCount": 3,
+		"truncated": true,
+		"truncatedReason": "maxRows"
+	}`, texts[0])
+}
+
+func TestRenderQueryResultColumnar(t *testing.T) {
+	rendered, err := renderQueryResult(testQueryResult(), formatColumnar)
+	require.NoError(t, err)
+
+	texts := resultTexts(t, rendered)
+	require.Len(t, texts, 1)
+	var doc columnarResult
+	require.NoError(t, json.Unmarshal([]byte(texts[0]), &doc))
+	assert.Len(t, doc.Columns, 2)
+	assert.Equal(t, [][]any{
+		{float64(1), float64(2), float64(3)},
+		{"plain", "a|b, \"quoted\"\nnext line", nil},
+	}, doc.Values)
+	assert.True(t, doc.Truncated)
+}
+
+func TestRenderQueryResultCSV(t *testing.T) {
+	rendered, err := renderQueryResult(testQueryResult(), formatCSV)
+	require.NoError(t, err)
+
+	texts := resultTexts(t, rendered)
+	require.Len(t, texts, 2)
+	assert.Equal(t, "id,note\n1,plain\n2,\"a|b, \"\"quoted\"\"\nnext line\"\n3,\n", texts[0])
+	assert.JSONEq(t, `{
+		"columns": [{"name":"id","typeOid":23,"typeName":"int4"},{"name":"note","typeOid":25,"typeName":"text"}],
+		"rowCount": 3,
+		"truncated": true,
+		"truncatedReason": "maxRows"
+	}`, texts[1])
+}
+
+func TestRenderQueryResultMarkdown(t *testing.T) {
+	rendered, err := renderQueryResult(testQueryResult(), formatMarkdown)
+	require.NoError(t, err)
+
+	texts := resultTexts(t, rendered)
+	require.Len(t, texts, 2)
+	assert.Equal(t, "| id | note |\n"+
+		"| --- | --- |\n"+
+		"| 1 | plain |\n"+
+		"| 2 | a\\|b, \"quoted\"<br>next line |\n"+
+		"| 3 | NULL |\n", texts[0])
+}
+
+func TestCollectRowsMeasuresChosenFormat(t *testing.T) {
+	// Each row is "N,row\n" in CSV, 6 bytes, but 21 bytes as a JSON object
+	limits := queryLimits{MaxRows: defaultMaxRows, MaxBytes: 20}
+
+	csvResult, err := collectRows(newFakeRows(5), pgtype.NewMap(), limits, formatCSV)
+	require.NoError(t, err)
+	assert.Equal(t, 3, csvResult.RowCount)
+
+	jsonResult, err := collectRows(newFakeRows(5), pgtype.NewMap(), limits, formatJSONRows)
+	require.NoError(t, err)
+	assert.Equal(t, 0, jsonResult.RowCount)
+	assert.Equal(t, truncatedByMaxBytes, jsonResult.TruncatedReason)
+}
+
+func TestTextValue(t *testing.T) {
+	assert.Equal(t, "", textValue(nil))
+	assert.Equal(t, "42", textValue(int64(42)))
+	assert.Equal(t, "true", textValue(true))
+	assert.Equal(t, `{"a":[1,2]}`, textValue(map[string]any{"a": []int{1, 2}}))
+}
+
+func TestResultFormatFromRequest(t *testing.T) {
+	request := mcp.CallToolRequest{}
+	format, err := resultFormatFromRequest(request)
+	require.NoError(t, err)
+	assert.Equal(t, formatJSONRows, format)
+
+	request.Params.Arguments = map[string]any{"format": "csv"}
+	format, err = resultFormatFromRequest(request)
+	require.NoError(t, err)
+	assert.Equal(t, formatCSV, format)
+
+	request.Params.Arguments = map[string]any{"format": "xml"}
+	_, err = resultFormatFromRequest(request)
+	require.Error(t, err)
+}
@@ -0,0 +1,139 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// queryParamsFromRequest reads the optional params array. It doesn't use
+// validate.OptionalToolArrayParam because null is a valid parameter value.
+func queryParamsFromRequest(request mcp.CallToolRequest) ([]any, error) {
+	raw, ok := request.GetArguments()["params"]
+	if !ok || raw == nil {
+		return nil, nil
+	}
+	params, ok := raw.([]any)
+	if !ok {
+		return nil, errors.New("parameter params is not a valid array")
+	}
+	return params, nil
+}
+
+// bindQueryParams asks Postgres which types the placeholders in sqlQuery
+// have and converts the JSON params to match.
+func bindQueryParams(ctx context.Context, tx pgx.Tx, sqlQuery string, params []any) ([]any, error) {
+	if len(params) == 0 {
+		return nil, nil
+	}
+	sd, err := tx.Conn().PgConn().Prepare(ctx, "", sqlQuery, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error preparing query: %w", err)
+	}
+	return queryArgs(params, sd.ParamOIDs)
+}
+
+// queryArgs converts JSON values to query arguments for placeholders of the
+// given types. Every value is sent in Postgres text format and parsed by the
+// server as the placeholder's type, so for example the JSON number 42 works
+// for integer, numeric and text placeholders alike. JSON arrays become
+// Postgres arrays and objects become JSON text, except for json and jsonb
+// placeholders, which take any value as JSON.
+func queryArgs(params []any, paramOIDs []uint32) ([]any, error) {
+	if len(params) != len(paramOIDs) {
+		return nil, fmt.Errorf("query has %d parameter placeholders but %d params were given",
+			len(paramOIDs), len(params))
+	}
+
+	args := make([]any, len(params))
+	for i, param := range params {
+		if param == nil {
+			continue
+		}
+
+		var (
+			arg string
+			err error
+		)
+		switch paramOIDs[i] {
+		case pgtype.JSONOID, pgtype.JSONBOID:
+			arg, err = jsonParamText(param)
+		default:
+			arg, err = paramText(param)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("param $%d: %w", i+1, err)
+		}
+		args[i] = arg
+	}
+	return args, nil
+}
+
+// jsonParamText encodes a param for a json or jsonb placeholder. Strings are
+// passed through so that callers can send a JSON document as text.
+func jsonParamText(param any) (string, error) {
+	if s, ok := param.(string); ok {
+		return s, nil
+	}
+	b, err := json.Marshal(param)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+func paramText(param any) (string, error) {
+	switch v := param.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	case []any:
+		return arrayLiteral(v)
+	case map[string]any:
+		return jsonParamText(v)
+	default:
+		return "", fmt.Errorf("unsupported value of type %T", param)
+	}
+}
+
+// arrayLiteral renders a JSON array as a Postgres array literal such as
+// {1,2,"three",NULL}.
+func arrayLiteral(values []any) (string, error) {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, value := range values {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		switch v := value.(type) {
+		case nil:
+			b.WriteString("NULL")
+		case []any:
+			nested, err := arrayLiteral(v)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(nested)
+		default:
+			text, err := paramText(v)
+			if err != nil {
+				return "", err
+			}
+			b.WriteByte('"')
+			b.WriteString(strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(text))
+			b.WriteByte('"')
+		}
+	}
+	b.WriteByte('}')
+	return b.String(), nil
+}
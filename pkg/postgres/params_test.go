@@ -0,0 +1,77 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryArgs(t *testing.T) {
+	params := []any{
+		"alice",
+		float64(42),
+		1.5,
+		true,
+		nil,
+		[]any{float64(1), "two \"quoted\"", nil},
+		map[string]any{"plan": "pro"},
+		map[string]any{"plan": "pro"},
+		`{"raw": true}`,
+	}
+	oids := []uint32{
+		pgtype.TextOID,
+		pgtype.Int4OID,
+		pgtype.NumericOID,
+		pgtype.BoolOID,
+		pgtype.Int8OID,
+		pgtype.TextArrayOID,
+		pgtype.TextOID,
+		pgtype.JSONBOID,
+		pgtype.JSONOID,
+	}
+
+	args, err := queryArgs(params, oids)
+	require.NoError(t, err)
+	assert.Equal(t, []any{
+		"alice",
+		"42",
+		"1.5",
+		"true",
+		nil,
+		`{"1","two \"quoted\"",NULL}`,
+		`{"plan":"pro"}`,
+		`{"plan":"pro"}`,
+		`{"raw": true}`,
+	}, args)
+}
+
+func TestQueryArgsJSONArray(t *testing.T) {
+	args, err := queryArgs([]any{[]any{float64(1), "a"}}, []uint32{pgtype.JSONBOID})
+	require.NoError(t, err)
+	assert.Equal(t, []any{`[1,"a"]`}, args, "arrays for jsonb placeholders stay JSON")
+
+	args, err = queryArgs([]any{[]any{[]any{float64(1)}, []any{float64(2)}}}, []uint32{pgtype.Int4ArrayOID})
+	require.NoError(t, err)
+	assert.Equal(t, []any{`{{"1"},{"2"}}`}, args)
+}
+
+func TestQueryArgsCountMismatch(t *testing.T) {
+	_, err := queryArgs([]any{"a"}, []uint32{pgtype.TextOID, pgtype.TextOID})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "2 parameter placeholders but 1 params")
+}
+
+func TestQueryParamsFromRequest(t *testing.T) {
+	request := mcp.CallToolRequest{}
+	request.Params.Arguments = map[string]any{"params": []any{"a", nil}}
+	params, err := queryParamsFromRequest(request)
+	require.NoError(t, err)
+	assert.Equal(t, []any{"a", nil}, params)
+
+	request.Params.Arguments = map[string]any{"params": "a"}
+	_, err = queryParamsFromRequest(request)
+	require.Error(t, err)
+}
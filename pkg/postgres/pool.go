@@ -0,0 +1,322 @@
+package postgres
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/render-oss/render-mcp-server/pkg/authn"
+)
+
+const (
+	// maxConnsPerPostgres bounds the connections one caller holds open to a
+	// single Postgres instance.
+	maxConnsPerPostgres = 4
+	// maxTotalConns bounds the connections held open across the whole server.
+	maxTotalConns = 50
+	// connIdleTimeout is how long an unused connection is kept before it is
+	// closed.
+	connIdleTimeout = 5 * time.Minute
+	// connResetTimeout bounds resetting a connection's session state before
+	// it goes back to the pool.
+	connResetTimeout = 10 * time.Second
+)
+
+// poolableConn is the part of *pgx.Conn the pool relies on. It lets tests
+// exercise the pool without a database.
+type poolableConn interface {
+	Close(ctx context.Context) error
+	IsClosed() bool
+	Ping(ctx context.Context) error
+}
+
+// poolKey identifies the connections that may be shared. The credential hash
+// covers both the caller's API token and the connection string, so different
+// tokens never share connections and rotated credentials are not reused.
+type poolKey struct {
+	postgresID     string
+	credentialHash string
+}
+
+func newPoolKey(ctx context.Context, postgresID, connString string) poolKey {
+	h := sha256.New()
+	h.Write([]byte(authn.APITokenFromContext(ctx)))
+	h.Write([]byte{0})
+	h.Write([]byte(connString))
+	return poolKey{postgresID: postgresID, credentialHash: hex.EncodeToString(h.Sum(nil))}
+}
+
+type idleConn[C poolableConn] struct {
+	conn     C
+	lastUsed time.Time
+}
+
+// connPool reuses database connections across tool calls. Connections are
+// checked out with Acquire and must be handed back with Release.
+type connPool[C poolableConn] struct {
+	connect func(ctx context.Context, connString string) (C, error)
+	// reset clears the session state a tool left on a connection, such as
+	// settings, advisory locks and temp tables, before it is reused.
+	reset func(ctx context.Context, conn C) error
+	now   func() time.Time
+
+	maxPerKey   int
+	maxTotal    int
+	idleTimeout time.Duration
+
+	mu         sync.Mutex
+	idle       map[poolKey][]idleConn[C]
+	open       map[poolKey]int
+	totalOpen  int
+	evictTimer *time.Timer
+}
+
+func newConnPool[C poolableConn](connect func(ctx context.Context, connString string) (C, error)) *connPool[C] {
+	return &connPool[C]{
+		connect:     connect,
+		now:         time.Now,
+		maxPerKey:   maxConnsPerPostgres,
+		maxTotal:    maxTotalConns,
+		idleTimeout: connIdleTimeout,
+		idle:        map[poolKey][]idleConn[C]{},
+		open:        map[poolKey]int{},
+	}
+}
+
+func newPgxConnPool() *connPool[*pgx.Conn] {
+	pool := newConnPool(func(ctx context.Context, connString string) (*pgx.Conn, error) {
+		config, err := pgx.ParseConfig(connString)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing connection string: %w", err)
+		}
+		conn, err := pgx.ConnectConfig(ctx, config)
+		if err != nil {
+			return nil, fmt.Errorf("error connecting to database: %w", err)
+		}
+		return conn, nil
+	})
+	pool.reset = resetPgxConn
+	return pool
+}
+
+// resetPgxConn runs DISCARD ALL on conn. That also drops the prepared
+// statements pgx has cached, so its caches are cleared to match. DISCARD ALL
+// fails inside a transaction, so a connection left in one is not reused.
+func resetPgxConn(ctx context.Context, conn *pgx.Conn) error {
+	if _, err := conn.PgConn().Exec(ctx, "DISCARD ALL").ReadAll(); err != nil {
+		return err
+	}
+	return conn.DeallocateAll(ctx)
+}
+
+// acquirePostgresConn looks up the connection details for postgresId and
+// checks out a pooled connection to it. The returned func hands the
+// connection back to the pool.
+func acquirePostgresConn(ctx context.Context, postgresRepo *Repo, conns *connPool[*pgx.Conn], postgresId string) (*pgx.Conn, func(), error) {
+	connectionInfo, err := postgresRepo.GetPostgresConnectionInfo(ctx, postgresId)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	connString := connectionInfo.ExternalConnectionString
+	key := newPoolKey(ctx, postgresId, connString)
+	conn, err := conns.Acquire(ctx, key, connString)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return conn, func() { conns.Release(context.Background(), key, conn) }, nil
+}
+
+// Acquire returns an idle connection for key if one is still usable, and
+// otherwise opens a new one.
+func (p *connPool[C]) Acquire(ctx context.Context, key poolKey, connString string) (C, error) {
+	for {
+		conn, ok, err := p.takeIdleOrReserve(ctx, key)
+		if err != nil {
+			var zero C
+			return zero, err
+		}
+		if !ok {
+			break
+		}
+		if !conn.IsClosed() && conn.Ping(ctx) == nil {
+			return conn, nil
+		}
+		// The server dropped the connection while it sat idle
+		p.discard(ctx, key, conn)
+	}
+
+	conn, err := p.connect(ctx, connString)
+	if err != nil {
+		p.unreserve(key)
+		var zero C
+		return zero, err
+	}
+	return conn, nil
+}
+
+// Release resets conn and returns it to the pool. Connections that were
+// closed while in use, for example because the query's context was canceled,
+// or that can't be reset are dropped.
+func (p *connPool[C]) Release(ctx context.Context, key poolKey, conn C) {
+	if conn.IsClosed() {
+		p.discard(ctx, key, conn)
+		return
+	}
+	if p.reset != nil {
+		resetCtx, cancel := context.WithTimeout(ctx, connResetTimeout)
+		err := p.reset(resetCtx, conn)
+		cancel()
+		if err != nil {
+			p.discard(ctx, key, conn)
+			return
+		}
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.idle[key] = append(p.idle[key], idleConn[C]{conn: conn, lastUsed: p.now()})
+	p.scheduleEvictionLocked()
+}
+
+// takeIdleOrReserve pops an idle connection for key, or reserves a slot for a
+// new one when there is none. It returns false when the caller should dial.
+func (p *connPool[C]) takeIdleOrReserve(ctx context.Context, key poolKey) (C, bool, error) {
+	var zero C
+	p.mu.Lock()
+	expired := p.removeExpiredLocked()
+	defer func() {
+		closeAll(ctx, expired)
+	}()
+	defer p.mu.Unlock()
+
+	if conns := p.idle[key]; len(conns) > 0 {
+		last := conns[len(conns)-1]
+		p.idle[key] = conns[:len(conns)-1]
+		return last.conn, true, nil
+	}
+
+	if p.open[key] >= p.maxPerKey {
+		return zero, false, fmt.Errorf("too many concurrent queries to Postgres instance %s; "+
+			"wait for running queries to finish and try again", key.postgresID)
+	}
+	if p.totalOpen >= p.maxTotal {
+		victim, ok := p.removeOldestIdleLocked()
+		if !ok {
+			return zero, false, fmt.Errorf("the server has too many open Postgres connections; try again shortly")
+		}
+		expired = append(expired, victim)
+	}
+
+	p.open[key]++
+	p.totalOpen++
+	return zero, false, nil
+}
+
+func (p *connPool[C]) unreserve(key poolKey) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.decrementLocked(key)
+}
+
+func (p *connPool[C]) discard(ctx context.Context, key poolKey, conn C) {
+	_ = conn.Close(ctx)
+	p.unreserve(key)
+}
+
+func (p *connPool[C]) decrementLocked(key poolKey) {
+	p.open[key]--
+	p.totalOpen--
+	if p.open[key] <= 0 {
+		delete(p.open, key)
+	}
+}
+
+// removeExpiredLocked drops idle connections past the idle timeout from the
+// pool and returns them so they can be closed without holding the lock.
+func (p *connPool[C]) removeExpiredLocked() []C {
+	var expired []C
+	cutoff := p.now().Add(-p.idleTimeout)
+	for key, conns := range p.idle {
+		kept := conns[:0]
+		for _, ic := range conns {
+			if ic.lastUsed.Before(cutoff) {
+				expired = append(expired, ic.conn)
+				p.decrementLocked(key)
+			} else {
+				kept = append(kept, ic)
+			}
+		}
+		if len(kept) == 0 {
+			delete(p.idle, key)
+		} else {
+			p.idle[key] = kept
+		}
+	}
+	return expired
+}
+
+// removeOldestIdleLocked drops the least recently used idle connection from
+// the pool to make room under the server-wide cap.
+func (p *connPool[C]) removeOldestIdleLocked() (C, bool) {
+	var (
+		oldestKey poolKey
+		oldestIdx = -1
+		oldest    time.Time
+	)
+	for key, conns := range p.idle {
+		for i, ic := range conns {
+			if oldestIdx == -1 || ic.lastUsed.Before(oldest) {
+				oldestKey, oldestIdx, oldest = key, i, ic.lastUsed
+			}
+		}
+	}
+
+	var zero C
+	if oldestIdx == -1 {
+		return zero, false
+	}
+
+	conns := p.idle[oldestKey]
+	victim := conns[oldestIdx].conn
+	conns = append(conns[:oldestIdx], conns[oldestIdx+1:]...)
+	if len(conns) == 0 {
+		delete(p.idle, oldestKey)
+	} else {
+		p.idle[oldestKey] = conns
+	}
+	p.decrementLocked(oldestKey)
+	return victim, true
+}
+
+// scheduleEvictionLocked arranges for idle connections to be closed even if
+// no further queries arrive. The timer only runs while connections are idle.
+func (p *connPool[C]) scheduleEvictionLocked() {
+	if p.evictTimer != nil {
+		return
+	}
+	p.evictTimer = time.AfterFunc(p.idleTimeout, p.evictIdle)
+}
+
+func (p *connPool[C]) evictIdle() {
+	p.mu.Lock()
+	p.evictTimer = nil
+	expired := p.removeExpiredLocked()
+	if len(p.idle) > 0 {
+		p.scheduleEvictionLocked()
+	}
+	p.mu.Unlock()
+
+	closeAll(context.Background(), expired)
+}
+
+func closeAll[C poolableConn](ctx context.Context, conns []C) {
+	for _, conn := range conns {
+		_ = conn.Close(ctx)
+	}
+}
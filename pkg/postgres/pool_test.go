@@ -0,0 +1,197 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/render-oss/render-mcp-server/pkg/authn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeConn struct {
+	id      int
+	closed  bool
+	pingErr error
+}
+
+func (c *fakeConn) Close(context.Context) error { c.closed = true; return nil }
+func (c *fakeConn) IsClosed() bool              { return c.closed }
+func (c *fakeConn) Ping(context.Context) error  { return c.pingErr }
+
+func newFakeConnPool(t *testing.T) (*connPool[*fakeConn], *time.Time) {
+	t.Helper()
+	dialed := 0
+	pool := newConnPool(func(context.Context, string) (*fakeConn, error) {
+		dialed++
+		return &fakeConn{id: dialed}, nil
+	})
+	now := time.Now()
+	pool.now = func() time.Time { return now }
+	t.Cleanup(func() {
+		pool.mu.Lock()
+		defer pool.mu.Unlock()
+		if pool.evictTimer != nil {
+			pool.evictTimer.Stop()
+		}
+	})
+	return pool, &now
+}
+
+func tokenContext(token string) context.Context {
+	return authn.ContextWithAPIToken(context.Background(), token)
+}
+
+func TestConnPoolReusesConnectionsPerToken(t *testing.T) {
+	pool, _ := newFakeConnPool(t)
+	ctx := tokenContext("token-a")
+	key := newPoolKey(ctx, "dpg-1", "postgres://db")
+
+	first, err := pool.Acquire(ctx, key, "postgres://db")
+	require.NoError(t, err)
+	pool.Release(ctx, key, first)
+
+	second, err := pool.Acquire(ctx, key, "postgres://db")
+	require.NoError(t, err)
+	assert.Same(t, first, second)
+	pool.Release(ctx, key, second)
+
+	otherCtx := tokenContext("token-b")
+	otherKey := newPoolKey(otherCtx, "dpg-1", "postgres://db")
+	assert.NotEqual(t, key, otherKey)
+
+	other, err := pool.Acquire(otherCtx, otherKey, "postgres://db")
+	require.NoError(t, err)
+	assert.NotSame(t, first, other)
+}
+
+func TestConnPoolKeyChangesWithConnectionString(t *testing.T) {
+	ctx := tokenContext("token-a")
+	assert.NotEqual(t,
+		newPoolKey(ctx, "dpg-1", "postgres://user:old@db"),
+		newPoolKey(ctx, "dpg-1", "postgres://user:new@db"),
+	)
+}
+
+func TestConnPoolPerPostgresCap(t *testing.T) {
+	pool, _ := newFakeConnPool(t)
+	pool.maxPerKey = 2
+	ctx := tokenContext("token-a")
+	key := newPoolKey(ctx, "dpg-1", "postgres://db")
+
+	_, err := pool.Acquire(ctx, key, "postgres://db")
+	require.NoError(t, err)
+	held, err := pool.Acquire(ctx, key, "postgres://db")
+	require.NoError(t, err)
+
+	_, err = pool.Acquire(ctx, key, "postgres://db")
+	require.Error(t, err)
+
+	pool.Release(ctx, key, held)
+	_, err = pool.Acquire(ctx, key, "postgres://db")
+	require.NoError(t, err)
+}
+
+func TestConnPoolTotalCapEvictsOldestIdle(t *testing.T) {
+	pool, now := newFakeConnPool(t)
+	pool.maxTotal = 2
+	ctx := tokenContext("token-a")
+	keyA := newPoolKey(ctx, "dpg-a", "postgres://a")
+	keyB := newPoolKey(ctx, "dpg-b", "postgres://b")
+	keyC := newPoolKey(ctx, "dpg-c", "postgres://c")
+
+	connA, err := pool.Acquire(ctx, keyA, "postgres://a")
+	require.NoError(t, err)
+	pool.Release(ctx, keyA, connA)
+	*now = now.Add(time.Second)
+
+	connB, err := pool.Acquire(ctx, keyB, "postgres://b")
+	require.NoError(t, err)
+
+	// Only connA is idle, so it makes room for connC
+	_, err = pool.Acquire(ctx, keyC, "postgres://c")
+	require.NoError(t, err)
+	assert.True(t, connA.closed)
+	assert.Equal(t, 2, pool.totalOpen)
+
+	// Nothing is idle now, so a fourth database is refused
+	_, err = pool.Acquire(ctx, keyA, "postgres://a")
+	require.Error(t, err)
+
+	pool.Release(ctx, keyB, connB)
+}
+
+func TestConnPoolEvictsIdleConnections(t *testing.T) {
+	pool, now := newFakeConnPool(t)
+	ctx := tokenContext("token-a")
+	key := newPoolKey(ctx, "dpg-1", "postgres://db")
+
+	first, err := pool.Acquire(ctx, key, "postgres://db")
+	require.NoError(t, err)
+	pool.Release(ctx, key, first)
+
+	*now = now.Add(connIdleTimeout + time.Second)
+	pool.evictIdle()
+
+	assert.True(t, first.closed)
+	assert.Equal(t, 0, pool.totalOpen)
+
+	second, err := pool.Acquire(ctx, key, "postgres://db")
+	require.NoError(t, err)
+	assert.NotSame(t, first, second)
+}
+
+func TestConnPoolDropsBrokenConnections(t *testing.T) {
+	pool, _ := newFakeConnPool(t)
+	ctx := tokenContext("token-a")
+	key := newPoolKey(ctx, "dpg-1", "postgres://db")
+
+	closedInUse, err := pool.Acquire(ctx, key, "postgres://db")
+	require.NoError(t, err)
+	closedInUse.closed = true
+	pool.Release(ctx, key, closedInUse)
+	assert.Equal(t, 0, pool.totalOpen)
+
+	deadIdle, err := pool.Acquire(ctx, key, "postgres://db")
+	require.NoError(t, err)
+	pool.Release(ctx, key, deadIdle)
+	deadIdle.pingErr = errors.New("connection reset")
+
+	fresh, err := pool.Acquire(ctx, key, "postgres://db")
+	require.NoError(t, err)
+	assert.NotSame(t, deadIdle, fresh)
+	assert.True(t, deadIdle.closed)
+	assert.Equal(t, 1, pool.totalOpen)
+}
+
+func TestConnPoolResetsConnectionsOnRelease(t *testing.T) {
+	pool, _ := newFakeConnPool(t)
+	var reset []*fakeConn
+	pool.reset = func(_ context.Context, conn *fakeConn) error {
+		reset = append(reset, conn)
+		if conn.id == 2 {
+			return errors.New("cannot run inside a transaction block")
+		}
+		return nil
+	}
+	ctx := tokenContext("token-a")
+	key := newPoolKey(ctx, "dpg-1", "postgres://db")
+
+	first, err := pool.Acquire(ctx, key, "postgres://db")
+	require.NoError(t, err)
+	second, err := pool.Acquire(ctx, key, "postgres://db")
+	require.NoError(t, err)
+	pool.Release(ctx, key, first)
+	pool.Release(ctx, key, second)
+
+	assert.Equal(t, []*fakeConn{first, second}, reset)
+	assert.False(t, first.closed)
+	assert.True(t, second.closed)
+	assert.Equal(t, 1, pool.totalOpen)
+
+	again, err := pool.Acquire(ctx, key, "postgres://db")
+	require.NoError(t, err)
+	assert.Same(t, first, again)
+}
@@ -0,0 +1,288 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// defaultProfileSampleRows and maxProfileSampleRows bound the example
+	// rows a profile includes.
+	defaultProfileSampleRows = 5
+	maxProfileSampleRows     = 50
+	// maxProfileColumns caps how many columns are profiled, which keeps the
+	// aggregate query and the response a reasonable size for wide tables.
+	maxProfileColumns = 100
+	// profileExactRowLimit is the estimated row count above which column
+	// statistics are computed over a TABLESAMPLE instead of the whole table.
+	profileExactRowLimit = 1_000_000
+	// profileSampleTarget is roughly how many rows such a sample covers.
+	profileSampleTarget = 100_000
+	// profileValueChars truncates min and max values, which for text columns
+	// can be arbitrarily long.
+	profileValueChars = 100
+)
+
+// orderableTypeCategories are the pg_type categories whose types have min and
+// max aggregates: numeric, date/time, string, timespan and enum types.
+var orderableTypeCategories = []string{"N", "D", "S", "T", "E"}
+
+// profileKinds are the relation kinds that can be profiled. Views are
+// excluded since profiling them would run their query several times.
+var profileKinds = map[string]bool{"r": true, "p": true, "m": true}
+
+type tableProfile struct {
+	Schema            string `json:"schema"`
+	Table             string `json:"table"`
+	Kind              string `json:"kind"`
+	RowCount          int64  `json:"rowCount"`
+	RowCountEstimated bool   `json:"rowCountEstimated,omitempty"`
+	// SampledRows is set when column statistics were computed over a sample
+	// of the table rather than every row.
+	SampledRows      int64            `json:"sampledRows,omitempty"`
+	Columns          []columnProfile  `json:"columns"`
+	ColumnsTruncated bool             `json:"columnsTruncated,omitempty"`
+	SampleRows       []map[string]any `json:"sampleRows"`
+	Notes            []string         `json:"notes,omitempty"`
+}
+
+type columnProfile struct {
+	Name             string   `json:"name"`
+	Type             string   `json:"type"`
+	NullRatio        *float64 `json:"nullRatio,omitempty"`
+	DistinctEstimate *float64 `json:"distinctEstimate,omitempty"`
+	Min              *string  `json:"min,omitempty"`
+	Max              *string  `json:"max,omitempty"`
+
+	orderable bool
+}
+
+const profileRelationSQL = `
+SELECT c.relkind::text, c.reltuples::bigint
+FROM pg_class c
+JOIN pg_namespace n ON n.oid = c.relnamespace
+WHERE n.nspname = $1 AND c.relname = $2`
+
+const profileColumnsSQL = `
+SELECT a.attname, format_type(a.atttypid, a.atttypmod), t.typcategory::text = ANY($3::text[])
+FROM pg_attribute a
+JOIN pg_class c ON c.oid = a.attrelid
+JOIN pg_namespace n ON n.oid = c.relnamespace
+JOIN pg_type t ON t.oid = a.atttypid
+WHERE n.nspname = $1 AND c.relname = $2 AND a.attnum > 0 AND NOT a.attisdropped
+ORDER BY a.attnum`
+
+// Inherited statistics cover child tables too, matching what a query on the
+// parent returns, so they're preferred when both exist.
+const profileStatsSQL = `
+SELECT DISTINCT ON (attname) attname, n_distinct
+FROM pg_stats
+WHERE schemaname = $1 AND tablename = $2
+ORDER BY attname, inherited DESC`
+
+type columnStatsRow struct {
+	Name      string
+	NDistinct float32
+}
+
+// profileTable profiles schema.table through tx. Column statistics come from
+// a single aggregate query over the table, or over a sample of it when the
+// table is large, and distinct counts come from pg_stats.
+func profileTable(ctx context.Context, tx pgx.Tx, typeMap *pgtype.Map, schema, table string, sampleRows int) (*tableProfile, error) {
+	var kind string
+	var estimatedRows int64
+	err := tx.QueryRow(ctx, profileRelationSQL, schema, table).Scan(&kind, &estimatedRows)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("table %s.%s does not exist", schema, table)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error looking up table: %w", err)
+	}
+	if !profileKinds[kind] {
+		return nil, fmt.Errorf("%s.%s is a %s; only tables and materialized views can be profiled",
+			schema, table, relationKinds[kind])
+	}
+
+	profile := &tableProfile{Schema: schema, Table: table, Kind: relationKinds[kind], SampleRows: []map[string]any{}}
+
+	profile.Columns, err = queryCatalog(ctx, tx, profileColumnsSQL, []any{schema, table, orderableTypeCategories},
+		func(row pgx.CollectableRow) (columnProfile, error) {
+			var c columnProfile
+			err := row.Scan(&c.Name, &c.Type, &c.orderable)
+			return c, err
+		})
+	if err != nil {
+		return nil, fmt.Errorf("error listing columns: %w", err)
+	}
+	if len(profile.Columns) > maxProfileColumns {
+		profile.Columns = profile.Columns[:maxProfileColumns]
+		profile.ColumnsTruncated = true
+	}
+
+	relation := pgx.Identifier{schema, table}.Sanitize()
+	percent := profileSamplePercent(estimatedRows)
+	if err := profileColumns(ctx, tx, profile, relation, percent); err != nil {
+		return nil, err
+	}
+	if percent > 0 {
+		profile.RowCount = estimatedRows
+		profile.RowCountEstimated = true
+		profile.Notes = append(profile.Notes, fmt.Sprintf("The table has about %d rows, so the row count is the "+
+			"planner's estimate and null ratios, min and max were computed over a %g%% sample of %d rows.",
+			estimatedRows, percent, profile.SampledRows))
+	}
+
+	stats, err := queryCatalog(ctx, tx, profileStatsSQL, []any{schema, table},
+		func(row pgx.CollectableRow) (columnStatsRow, error) {
+			var s columnStatsRow
+			err := row.Scan(&s.Name, &s.NDistinct)
+			return s, err
+		})
+	if err != nil {
+		return nil, fmt.Errorf("error reading pg_stats: %w", err)
+	}
+	byName := make(map[string]float32, len(stats))
+	for _, s := range stats {
+		byName[s.Name] = s.NDistinct
+	}
+	applyColumnStats(profile, byName)
+
+	if sampleRows > 0 {
+		rows, err := tx.Query(ctx, fmt.Sprintf("SELECT * FROM %s LIMIT %d", relation, sampleRows))
+		if err != nil {
+			return nil, fmt.Errorf("error sampling rows: %w", err)
+		}
+		result, err := collectRows(rows, typeMap, queryLimits{MaxRows: sampleRows, MaxBytes: defaultMaxBytes}, formatJSONRows)
+		if err != nil {
+			return nil, fmt.Errorf("error sampling rows: %w", err)
+		}
+		for _, row := range result.Rows {
+			profile.SampleRows = append(profile.SampleRows, rowMap(result.Columns, row))
+		}
+		if result.Truncated {
+			profile.Notes = append(profile.Notes, fmt.Sprintf("Only %d sample rows fit in %d bytes.",
+				result.RowCount, defaultMaxBytes))
+		}
+	}
+
+	return profile, nil
+}
+
+// profileColumns fills in the row count, null ratios and min and max values.
+// min and max aren't defined for every type in an orderable category, so if
+// the query fails it is retried in a fresh savepoint without them.
+func profileColumns(ctx context.Context, tx pgx.Tx, profile *tableProfile, relation string, percent float64) error {
+	err := runProfileAggregate(ctx, tx, profile, relation, percent, true)
+	if err == nil {
+		return nil
+	}
+	if retryErr := runProfileAggregate(ctx, tx, profile, relation, percent, false); retryErr != nil {
+		return fmt.Errorf("error profiling columns: %w", retryErr)
+	}
+	for i := range profile.Columns {
+		profile.Columns[i].Min, profile.Columns[i].Max = nil, nil
+	}
+	profile.Notes = append(profile.Notes, fmt.Sprintf("min and max were skipped: %s", err.Error()))
+	return nil
+}
+
+func runProfileAggregate(ctx context.Context, tx pgx.Tx, profile *tableProfile, relation string, percent float64, withMinMax bool) error {
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = savepoint.Rollback(ctx)
+	}()
+
+	var rowCount int64
+	nonNull := make([]int64, len(profile.Columns))
+	dest := []any{&rowCount}
+	for i := range profile.Columns {
+		dest = append(dest, &nonNull[i])
+		if withMinMax && profile.Columns[i].orderable {
+			dest = append(dest, &profile.Columns[i].Min, &profile.Columns[i].Max)
+		}
+	}
+
+	sql := profileAggregateSQL(relation, profile.Columns, percent, withMinMax)
+	if err := savepoint.QueryRow(ctx, sql).Scan(dest...); err != nil {
+		return err
+	}
+
+	profile.RowCount = rowCount
+	if percent > 0 {
+		profile.SampledRows = rowCount
+	}
+	for i := range profile.Columns {
+		if rowCount > 0 {
+			ratio := float64(rowCount-nonNull[i]) / float64(rowCount)
+			profile.Columns[i].NullRatio = &ratio
+		}
+	}
+	return nil
+}
+
+// profileAggregateSQL builds a query returning the row count followed by, for
+// each column, its non-null count and, for orderable columns when withMinMax
+// is set, its min and max as text.
+func profileAggregateSQL(relation string, columns []columnProfile, percent float64, withMinMax bool) string {
+	exprs := []string{"count(*)"}
+	for _, c := range columns {
+		col := pgx.Identifier{c.Name}.Sanitize()
+		exprs = append(exprs, fmt.Sprintf("count(%s)", col))
+		if withMinMax && c.orderable {
+			exprs = append(exprs,
+				fmt.Sprintf("left(min(%s)::text, %d)", col, profileValueChars),
+				fmt.Sprintf("left(max(%s)::text, %d)", col, profileValueChars))
+		}
+	}
+
+	sql := fmt.Sprintf("SELECT %s FROM %s", strings.Join(exprs, ", "), relation)
+	if percent > 0 {
+		sql += fmt.Sprintf(" TABLESAMPLE SYSTEM (%g)", percent)
+	}
+	return sql
+}
+
+// profileSamplePercent returns the TABLESAMPLE percentage to use for a table
+// with estimatedRows rows, or 0 to scan the whole table.
+func profileSamplePercent(estimatedRows int64) float64 {
+	if estimatedRows <= profileExactRowLimit {
+		return 0
+	}
+	percent := 100 * float64(profileSampleTarget) / float64(estimatedRows)
+	// Round up to two significant digits so the note stays readable
+	scale := math.Pow(10, math.Floor(math.Log10(percent))-1)
+	return math.Ceil(percent/scale) * scale
+}
+
+// applyColumnStats sets distinct estimates from pg_stats. A negative
+// n_distinct is a fraction of the row count, used by ANALYZE when the number
+// of distinct values grows with the table.
+func applyColumnStats(profile *tableProfile, nDistinct map[string]float32) {
+	missing := 0
+	for i := range profile.Columns {
+		n, ok := nDistinct[profile.Columns[i].Name]
+		if !ok {
+			missing++
+			continue
+		}
+		distinct := float64(n)
+		if distinct < 0 {
+			distinct = math.Round(-distinct * float64(profile.RowCount))
+		}
+		profile.Columns[i].DistinctEstimate = &distinct
+	}
+	if missing > 0 {
+		profile.Notes = append(profile.Notes, fmt.Sprintf("pg_stats has no entry for %d of %d columns, so their "+
+			"distinct counts are unknown. Statistics appear once the table has been analyzed.",
+			missing, len(profile.Columns)))
+	}
+}
@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfileAggregateSQL(t *testing.T) {
+	columns := []columnProfile{
+		{Name: "id", Type: "bigint", orderable: true},
+		{Name: "Payload", Type: "jsonb"},
+	}
+
+	assert.Equal(t,
+		`SELECT count(*), count("id"), left(min("id")::text, 100), left(max("id")::text, 100), count("Payload") `+
+			`FROM "public"."events"`,
+		profileAggregateSQL(`"public"."events"`, columns, 0, true))
+
+	assert.Equal(t,
+		`SELECT count(*), count("id"), count("Payload") FROM "public"."events" TABLESAMPLE SYSTEM (2.5)`,
+		profileAggregateSQL(`"public"."events"`, columns, 2.5, false))
+}
+
+func TestProfileSamplePercent(t *testing.T) {
+	assert.Zero(t, profileSamplePercent(-1), "tables that were never analyzed are scanned")
+	assert.Zero(t, profileSamplePercent(profileExactRowLimit))
+	assert.InDelta(t, 5.0, profileSamplePercent(2_000_000), 1e-9)
+	assert.InDelta(t, 0.034, profileSamplePercent(300_000_000), 1e-9)
+}
+
+func TestApplyColumnStats(t *testing.T) {
+	profile := &tableProfile{
+		RowCount: 1000,
+		Columns:  []columnProfile{{Name: "status"}, {Name: "email"}, {Name: "notes"}},
+	}
+
+	applyColumnStats(profile, map[string]float32{"status": 4, "email": -0.5})
+
+	require.NotNil(t, profile.Columns[0].DistinctEstimate)
+	assert.Equal(t, 4.0, *profile.Columns[0].DistinctEstimate)
+	require.NotNil(t, profile.Columns[1].DistinctEstimate)
+	assert.Equal(t, 500.0, *profile.Columns[1].DistinctEstimate, "negative n_distinct is a fraction of the rows")
+	assert.Nil(t, profile.Columns[2].DistinctEstimate)
+	require.Len(t, profile.Notes, 1)
+	assert.Contains(t, profile.Notes[0], "no entry for 1 of 3 columns")
+}
@@ -0,0 +1,228 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	defaultMaxRows = 100
+	maxRowsLimit   = 10000
+
+	defaultMaxBytes = 64 * 1024
+	maxBytesLimit   = 1024 * 1024
+
+	defaultStatementTimeout = 30 * time.Second
+	maxStatementTimeout     = 5 * time.Minute
+)
+
+const (
+	truncatedByMaxRows  = "maxRows"
+	truncatedByMaxBytes = "maxBytes"
+)
+
+// queryLimits bound how much work a query may do and how much of its result is
+// returned to the agent.
+type queryLimits struct {
+	MaxRows          int
+	MaxBytes         int
+	StatementTimeout time.Duration
+}
+
+func defaultQueryLimits() queryLimits {
+	return queryLimits{
+		MaxRows:          defaultMaxRows,
+		MaxBytes:         defaultMaxBytes,
+		StatementTimeout: defaultStatementTimeout,
+	}
+}
+
+type queryColumn struct {
+	Name     string `json:"name"`
+	TypeOID  uint32 `json:"typeOid"`
+	TypeName string `json:"typeName"`
+}
+
+// queryResult holds collected rows as values in column order. It is rendered
+// for the agent by renderQueryResult.
+type queryResult struct {
+	Columns         []queryColumn
+	Rows            [][]any
+	RowCount        int
+	Truncated       bool
+	TruncatedReason string
+	// NextCursor and CursorExpiresAt are set when the rows are a page of a
+	// cursor that has more to fetch.
+	NextCursor      string
+	CursorExpiresAt *time.Time
+	// Target is set when the query ran against a read replica.
+	Target *queryTarget
+}
+
+// withReadOnlyTx runs fn inside a READ ONLY transaction that is always rolled
+// back. The statement and idle-in-transaction timeouts are set locally so
+// they don't outlive the transaction.
+func withReadOnlyTx(ctx context.Context, conn *pgx.Conn, statementTimeout time.Duration, fn func(tx pgx.Tx) error) error {
+	tx, err := beginReadOnlyTx(ctx, conn, statementTimeout, statementTimeout)
+	if err != nil {
+		return err
+	}
+
+	// Make sure we roll back the transaction; nothing run here should be committed
+	defer func() {
+		_ = tx.Rollback(ctx) // Ignore error from rollback, as the transaction might already be aborted
+	}()
+
+	return fn(tx)
+}
+
+// beginReadOnlyTx begins a READ ONLY transaction with local statement and
+// idle-in-transaction timeouts. The caller must roll it back.
+func beginReadOnlyTx(ctx context.Context, conn *pgx.Conn, statementTimeout, idleTimeout time.Duration) (pgx.Tx, error) {
+	tx, err := conn.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+	if err != nil {
+		return nil, fmt.Errorf("error beginning transaction: %w", err)
+	}
+
+	if _, err := tx.Exec(ctx, fmt.Sprintf("SET LOCAL statement_timeout = %d", statementTimeout.Milliseconds())); err != nil {
+		_ = tx.Rollback(ctx)
+		return nil, fmt.Errorf("error setting statement timeout: %w", err)
+	}
+	if _, err := tx.Exec(ctx, fmt.Sprintf("SET LOCAL idle_in_transaction_session_timeout = %d", idleTimeout.Milliseconds())); err != nil {
+		_ = tx.Rollback(ctx)
+		return nil, fmt.Errorf("error setting idle transaction timeout: %w", err)
+	}
+
+	return tx, nil
+}
+
+// execStatement runs a single caller-supplied statement. pgx's Exec switches
+// to the simple protocol when there are no args, and the simple protocol runs
+// every statement in the string, which would let "SELECT 1; COMMIT; ..."
+// escape the transaction. Query with an explicit mode always uses the
+// extended protocol, which rejects more than one statement.
+func execStatement(ctx context.Context, tx pgx.Tx, sql string, args []any) (pgconn.CommandTag, error) {
+	rows, err := tx.Query(ctx, sql, append([]any{pgx.QueryExecModeDescribeExec}, args...)...)
+	if err != nil {
+		return pgconn.CommandTag{}, err
+	}
+	rows.Close()
+	return rows.CommandTag(), rows.Err()
+}
+
+// runReadOnlyQuery runs sqlQuery with params in a read-only transaction and
+// collects its rows.
+func runReadOnlyQuery(ctx context.Context, conn *pgx.Conn, sqlQuery string, params []any, limits queryLimits, format resultFormat) (*queryResult, error) {
+	var result *queryResult
+	err := withReadOnlyTx(ctx, conn, limits.StatementTimeout, func(tx pgx.Tx) error {
+		args, err := bindQueryParams(ctx, tx, sqlQuery, params)
+		if err != nil {
+			return err
+		}
+		rows, err := tx.Query(ctx, sqlQuery, args...)
+		if err != nil {
+			return fmt.Errorf("error executing query: %w", err)
+		}
+		result, err = collectRows(rows, conn.TypeMap(), limits, format)
+		return err
+	})
+	return result, err
+}
+
+// collectRows reads rows into a queryResult, stopping once either limit is
+// reached and flagging the result as truncated. Rows past a limit are not
+// kept, but closing rows still drains them from the server, so only the
+// statement timeout bounds how much a large result costs; use a cursor to
+// avoid reading it at all. MaxBytes is measured against the rows as rendered
+// in format.
+func collectRows(rows pgx.Rows, typeMap *pgtype.Map, limits queryLimits, format resultFormat) (*queryResult, error) {
+	defer rows.Close()
+
+	result := &queryResult{
+		Columns: queryColumns(rows.FieldDescriptions(), typeMap),
+		Rows:    [][]any{},
+	}
+
+	responseBytes := 0
+	for rows.Next() {
+		if len(result.Rows) >= limits.MaxRows {
+			result.Truncated = true
+			result.TruncatedReason = truncatedByMaxRows
+			break
+		}
+
+		values, err := rows.Values()
+		if err != nil {
+			return nil, fmt.Errorf("error reading row values: %w", err)
+		}
+
+		row := jsonValues(values)
+		rowBytes, err := encodedRowSize(format, result.Columns, row)
+		if err != nil {
+			return nil, fmt.Errorf("error encoding row: %w", err)
+		}
+		if responseBytes+rowBytes > limits.MaxBytes {
+			result.Truncated = true
+			result.TruncatedReason = truncatedByMaxBytes
+			break
+		}
+		responseBytes += rowBytes
+
+		result.Rows = append(result.Rows, row)
+	}
+
+	// Check for any errors encountered during iteration
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	result.RowCount = len(result.Rows)
+	return result, nil
+}
+
+func queryColumns(fieldDescriptions []pgconn.FieldDescription, typeMap *pgtype.Map) []queryColumn {
+	columns := make([]queryColumn, len(fieldDescriptions))
+	for i, fd := range fieldDescriptions {
+		columns[i] = queryColumn{
+			Name:     fd.Name,
+			TypeOID:  fd.DataTypeOID,
+			TypeName: typeName(typeMap, fd.DataTypeOID),
+		}
+	}
+	return columns
+}
+
+func typeName(typeMap *pgtype.Map, oid uint32) string {
+	if typeMap != nil {
+		if t, ok := typeMap.TypeForOID(oid); ok {
+			return t.Name
+		}
+	}
+	return "unknown"
+}
+
+func jsonValues(values []any) []any {
+	row := make([]any, len(values))
+	for i, v := range values {
+		row[i] = jsonValue(v)
+	}
+	return row
+}
+
+// jsonValue converts values returned by pgx into something that marshals
+// readably as JSON.
+func jsonValue(val any) any {
+	switch v := val.(type) {
+	case []byte:
+		// Convert byte arrays to string
+		return string(v)
+	default:
+		// For other types, use as-is
+		return v
+	}
+}
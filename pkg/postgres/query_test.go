@@ -0,0 +1,155 @@
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRows is an in-memory pgx.Rows for exercising row collection without a
+// database.
+type fakeRows struct {
+	fields []pgconn.FieldDescription
+	values [][]any
+	next   int
+	closed bool
+}
+
+var _ pgx.Rows = (*fakeRows)(nil)
+
+func (r *fakeRows) Close()                                       { r.closed = true }
+func (r *fakeRows) Err() error                                   { return nil }
+func (r *fakeRows) CommandTag() pgconn.CommandTag                { return pgconn.CommandTag{} }
+func (r *fakeRows) FieldDescriptions() []pgconn.FieldDescription { return r.fields }
+func (r *fakeRows) Scan(...any) error                            { return nil }
+func (r *fakeRows) RawValues() [][]byte                          { return nil }
+func (r *fakeRows) Conn() *pgx.Conn                              { return nil }
+
+func (r *fakeRows) Next() bool {
+	if r.closed || r.next >= len(r.values) {
+		return false
+	}
+	r.next++
+	return true
+}
+
+func (r *fakeRows) Values() ([]any, error) {
+	return r.values[r.next-1], nil
+}
+
+func newFakeRows(rowCount int) *fakeRows {
+	rows := &fakeRows{
+		fields: []pgconn.FieldDescription{
+			{Name: "id", DataTypeOID: pgtype.Int4OID},
+			{Name: "name", DataTypeOID: pgtype.TextOID},
+		},
+	}
+	for i := 0; i < rowCount; i++ {
+		rows.values = append(rows.values, []any{int32(i), []byte("row")})
+	}
+	return rows
+}
+
+func TestCollectRows(t *testing.T) {
+	tests := []struct {
+		name            string
+		rowCount        int
+		limits          queryLimits
+		expectedRows    int
+		expectedReason  string
+		expectTruncated bool
+	}{
+		{
+			name:         "All rows fit",
+			rowCount:     3,
+			limits:       defaultQueryLimits(),
+			expectedRows: 3,
+		},
+		{
+			name:         "Exactly max rows is not truncated",
+			rowCount:     2,
+			limits:       queryLimits{MaxRows: 2, MaxBytes: defaultMaxBytes},
+			expectedRows: 2,
+		},
+		{
+			name:            "Truncated by max rows",
+			rowCount:        5,
+			limits:          queryLimits{MaxRows: 2, MaxBytes: defaultMaxBytes},
+			expectedRows:    2,
+			expectTruncated: true,
+			expectedReason:  truncatedByMaxRows,
+		},
+		{
+			// Each row marshals to {"id":N,"name":"row"}, 21 bytes for a single digit id
+			name:            "Truncated by max bytes",
+			rowCount:        5,
+			limits:          queryLimits{MaxRows: defaultMaxRows, MaxBytes: 50},
+			expectedRows:    2,
+			expectTruncated: true,
+			expectedReason:  truncatedByMaxBytes,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := newFakeRows(tt.rowCount)
+
+			result, err := collectRows(rows, pgtype.NewMap(), tt.limits, formatJSONRows)
+
+			require.NoError(t, err)
+			assert.True(t, rows.closed)
+			assert.Equal(t, tt.expectedRows, result.RowCount)
+			assert.Len(t, result.Rows, tt.expectedRows)
+			assert.Equal(t, tt.expectTruncated, result.Truncated)
+			assert.Equal(t, tt.expectedReason, result.TruncatedReason)
+		})
+	}
+}
+
+func TestCollectRowsColumns(t *testing.T) {
+	rows := newFakeRows(1)
+	rows.fields = append(rows.fields, pgconn.FieldDescription{Name: "custom", DataTypeOID: 999999})
+	rows.values[0] = append(rows.values[0], "x")
+
+	result, err := collectRows(rows, pgtype.NewMap(), defaultQueryLimits(), formatJSONRows)
+
+	require.NoError(t, err)
+	assert.Equal(t, []queryColumn{
+		{Name: "id", TypeOID: pgtype.Int4OID, TypeName: "int4"},
+		{Name: "name", TypeOID: pgtype.TextOID, TypeName: "text"},
+		{Name: "custom", TypeOID: 999999, TypeName: "unknown"},
+	}, result.Columns)
+	assert.Equal(t, []any{int32(0), "row", "x"}, result.Rows[0])
+}
+
+// recordingTx records the args of each Query call.
+type recordingTx struct {
+	pgx.Tx
+	args [][]any
+}
+
+func (tx *recordingTx) Query(_ context.Context, _ string, args ...any) (pgx.Rows, error) {
+	tx.args = append(tx.args, args)
+	return &fakeRows{}, nil
+}
+
+func TestExecStatementUsesExtendedProtocol(t *testing.T) {
+	tx := &recordingTx{}
+
+	// Without args, pgx would otherwise fall back to the simple protocol,
+	// which runs every statement in the string.
+	_, err := execStatement(context.Background(), tx, "SELECT 1; COMMIT; DROP TABLE users", nil)
+	require.NoError(t, err)
+	_, err = execStatement(context.Background(), tx, "DELETE FROM t WHERE id = $1", []any{"7"})
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]any{
+		{pgx.QueryExecModeDescribeExec},
+		{pgx.QueryExecModeDescribeExec, "7"},
+	}, tx.args)
+}
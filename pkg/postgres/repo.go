@@ -2,7 +2,7 @@ package postgres
 
 import (
 	"context"
-	"net/http"
+	"fmt"
 
 	"github.com/render-oss/render-mcp-server/pkg/client"
 	"github.com/render-oss/render-mcp-server/pkg/session"
@@ -15,7 +15,12 @@ type postgresRepoClient interface {
 	RetrievePostgresWithResponse(ctx context.Context, id string, reqEditors ...client.RequestEditorFn) (*client.RetrievePostgresResponse, error)
 	RetrievePostgresConnectionInfoWithResponse(ctx context.Context, id string, reqEditors ...client.RequestEditorFn) (*client.RetrievePostgresConnectionInfoResponse, error)
 	CreatePostgresWithResponse(ctx context.Context, body client.PostgresPOSTInput, reqEditors ...client.RequestEditorFn) (*client.CreatePostgresResponse, error)
-	RestartPostgres(ctx context.Context, id string, reqEditors ...client.RequestEditorFn) (*http.Response, error)
+	UpdatePostgresWithResponse(ctx context.Context, id string, body client.PostgresPATCHInput, reqEditors ...client.RequestEditorFn) (*client.UpdatePostgresResponse, error)
+	RestartPostgresWithResponse(ctx context.Context, id string, reqEditors ...client.RequestEditorFn) (*client.RestartPostgresResponse, error)
+	SuspendPostgresWithResponse(ctx context.Context, id string, reqEditors ...client.RequestEditorFn) (*client.SuspendPostgresResponse, error)
+	ResumePostgresWithResponse(ctx context.Context, id string, reqEditors ...client.RequestEditorFn) (*client.ResumePostgresResponse, error)
+	FailoverPostgresWithResponse(ctx context.Context, id string, reqEditors ...client.RequestEditorFn) (*client.FailoverPostgresResponse, error)
+	DeletePostgresWithResponse(ctx context.Context, id string, reqEditors ...client.RequestEditorFn) (*client.DeletePostgresResponse, error)
 }
 
 type Repo struct {
@@ -92,8 +97,96 @@ func (r *Repo) CreatePostgres(ctx context.Context, input client.PostgresPOSTInpu
 	return client.BodyFromResponse(resp.JSON201, resp)
 }
 
+// UpdatePostgres applies input to a Postgres instance. Callers are expected to
+// have validated the instance's workspace with GetPostgresInWorkspace, since
+// they need its current state to build the update anyway.
+func (r *Repo) UpdatePostgres(ctx context.Context, id string, input client.PostgresPATCHInput) (*client.PostgresDetail, error) {
+	resp, err := r.client.UpdatePostgresWithResponse(ctx, id, input)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.BodyFromResponse(resp.JSON200, resp)
+}
+
+// GetPostgresInWorkspace retrieves a Postgres instance and validates that it
+// belongs to the workspace in the current session. Lifecycle operations call
+// it before acting on the instance.
+func (r *Repo) GetPostgresInWorkspace(ctx context.Context, id string) (*client.PostgresDetail, error) {
+	postgres, err := r.GetPostgres(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := validate.WorkspaceMatches(ctx, postgres.Owner.Id); err != nil {
+		return nil, err
+	}
+	return postgres, nil
+}
+
 func (r *Repo) RestartPostgresDatabase(ctx context.Context, id string) error {
-	resp, err := r.client.RestartPostgres(ctx, id)
+	if _, err := r.GetPostgresInWorkspace(ctx, id); err != nil {
+		return err
+	}
+
+	resp, err := r.client.RestartPostgresWithResponse(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return client.ErrorFromResponse(resp)
+}
+
+func (r *Repo) SuspendPostgres(ctx context.Context, id string) error {
+	if _, err := r.GetPostgresInWorkspace(ctx, id); err != nil {
+		return err
+	}
+
+	resp, err := r.client.SuspendPostgresWithResponse(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return client.ErrorFromResponse(resp)
+}
+
+func (r *Repo) ResumePostgres(ctx context.Context, id string) error {
+	if _, err := r.GetPostgresInWorkspace(ctx, id); err != nil {
+		return err
+	}
+
+	resp, err := r.client.ResumePostgresWithResponse(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return client.ErrorFromResponse(resp)
+}
+
+// FailoverPostgres triggers a failover to the standby of a high availability
+// Postgres instance.
+func (r *Repo) FailoverPostgres(ctx context.Context, id string) error {
+	postgres, err := r.GetPostgresInWorkspace(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !postgres.HighAvailabilityEnabled {
+		return fmt.Errorf("postgres instance %s does not have high availability enabled, so it has no standby to fail over to", id)
+	}
+
+	resp, err := r.client.FailoverPostgresWithResponse(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return client.ErrorFromResponse(resp)
+}
+
+func (r *Repo) DeletePostgres(ctx context.Context, id string) error {
+	if _, err := r.GetPostgresInWorkspace(ctx, id); err != nil {
+		return err
+	}
+
+	resp, err := r.client.DeletePostgresWithResponse(ctx, id)
 	if err != nil {
 		return err
 	}
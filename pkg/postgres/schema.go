@@ -0,0 +1,314 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// maxSchemaTables caps how many tables a schema description includes, so a
+// database with thousands of tables doesn't flood the agent's context.
+const maxSchemaTables = 200
+
+// schemaFilter narrows introspection to particular schemas and tables. Empty
+// fields match everything outside the system schemas.
+type schemaFilter struct {
+	Schemas []string
+	Tables  []string
+}
+
+type schemaDocument struct {
+	Schemas   []*schemaInfo `json:"schemas"`
+	Truncated bool          `json:"truncated,omitempty"`
+}
+
+type schemaInfo struct {
+	Name   string       `json:"name"`
+	Tables []*tableInfo `json:"tables"`
+}
+
+type tableInfo struct {
+	Name          string           `json:"name"`
+	Kind          string           `json:"kind"`
+	EstimatedRows *int64           `json:"estimatedRows,omitempty"`
+	Columns       []columnInfo     `json:"columns"`
+	PrimaryKey    []string         `json:"primaryKey,omitempty"`
+	ForeignKeys   []foreignKeyInfo `json:"foreignKeys,omitempty"`
+	Constraints   []constraintInfo `json:"constraints,omitempty"`
+	Indexes       []indexInfo      `json:"indexes,omitempty"`
+}
+
+type columnInfo struct {
+	Name     string  `json:"name"`
+	Type     string  `json:"type"`
+	Nullable bool    `json:"nullable"`
+	Default  *string `json:"default,omitempty"`
+}
+
+type foreignKeyInfo struct {
+	Name              string   `json:"name"`
+	Columns           []string `json:"columns"`
+	ReferencedSchema  string   `json:"referencedSchema"`
+	ReferencedTable   string   `json:"referencedTable"`
+	ReferencedColumns []string `json:"referencedColumns"`
+	// Definition is the constraint as Postgres prints it, including its ON
+	// DELETE and ON UPDATE actions.
+	Definition string `json:"definition"`
+}
+
+// constraintInfo is a check or unique constraint.
+type constraintInfo struct {
+	Name       string `json:"name"`
+	Type       string `json:"type"`
+	Definition string `json:"definition"`
+}
+
+type indexInfo struct {
+	Name       string `json:"name"`
+	Unique     bool   `json:"unique,omitempty"`
+	Primary    bool   `json:"primary,omitempty"`
+	Definition string `json:"definition"`
+}
+
+// relationFilterSQL restricts a query on pg_namespace n and pg_class c using
+// the schemaFilter passed as $1 (schemas) and $2 (tables).
+const relationFilterSQL = `
+	((cardinality($1::text[]) = 0
+		AND n.nspname NOT IN ('pg_catalog', 'information_schema')
+		AND n.nspname NOT LIKE 'pg\_toast%'
+		AND n.nspname NOT LIKE 'pg\_temp\_%')
+	OR n.nspname = ANY($1::text[]))
+	AND (cardinality($2::text[]) = 0 OR c.relname = ANY($2::text[]))`
+
+const describeTablesSQL = `
+SELECT c.oid, n.nspname, c.relname, c.relkind::text, c.reltuples::bigint
+FROM pg_class c
+JOIN pg_namespace n ON n.oid = c.relnamespace
+WHERE c.relkind IN ('r', 'p', 'v', 'm', 'f') AND` + relationFilterSQL + `
+ORDER BY n.nspname, c.relname
+LIMIT $3`
+
+const describeColumnsSQL = `
+SELECT n.nspname, c.relname, a.attname, format_type(a.atttypid, a.atttypmod), NOT a.attnotnull,
+	pg_get_expr(d.adbin, d.adrelid)
+FROM pg_attribute a
+JOIN pg_class c ON c.oid = a.attrelid
+JOIN pg_namespace n ON n.oid = c.relnamespace
+LEFT JOIN pg_attrdef d ON d.adrelid = a.attrelid AND d.adnum = a.attnum
+WHERE a.attnum > 0 AND NOT a.attisdropped AND c.oid = ANY($1::oid[])
+ORDER BY n.nspname, c.relname, a.attnum`
+
+const describeConstraintsSQL = `
+SELECT n.nspname, c.relname, con.conname, con.contype::text,
+	ARRAY(SELECT a.attname::text FROM unnest(con.conkey) WITH ORDINALITY k(attnum, ord)
+		JOIN pg_attribute a ON a.attrelid = con.conrelid AND a.attnum = k.attnum ORDER BY k.ord),
+	COALESCE(fn.nspname, ''), COALESCE(fc.relname, ''),
+	ARRAY(SELECT a.attname::text FROM unnest(con.confkey) WITH ORDINALITY k(attnum, ord)
+		JOIN pg_attribute a ON a.attrelid = con.confrelid AND a.attnum = k.attnum ORDER BY k.ord),
+	pg_get_constraintdef(con.oid)
+FROM pg_constraint con
+JOIN pg_class c ON c.oid = con.conrelid
+JOIN pg_namespace n ON n.oid = c.relnamespace
+LEFT JOIN pg_class fc ON fc.oid = con.confrelid
+LEFT JOIN pg_namespace fn ON fn.oid = fc.relnamespace
+WHERE con.contype IN ('p', 'f', 'c', 'u') AND c.oid = ANY($1::oid[])
+ORDER BY n.nspname, c.relname, con.conname`
+
+const describeIndexesSQL = `
+SELECT n.nspname, c.relname, i.relname, ix.indisunique, ix.indisprimary, pg_get_indexdef(ix.indexrelid)
+FROM pg_index ix
+JOIN pg_class i ON i.oid = ix.indexrelid
+JOIN pg_class c ON c.oid = ix.indrelid
+JOIN pg_namespace n ON n.oid = c.relnamespace
+WHERE c.oid = ANY($1::oid[])
+ORDER BY n.nspname, c.relname, i.relname`
+
+var constraintTypes = map[string]string{
+	"c": "check",
+	"u": "unique",
+}
+
+var relationKinds = map[string]string{
+	"r": "table",
+	"p": "partitioned table",
+	"v": "view",
+	"m": "materialized view",
+	"f": "foreign table",
+}
+
+type tableRow struct {
+	OID           uint32
+	Schema        string
+	Table         string
+	Kind          string
+	EstimatedRows int64
+}
+
+type columnRow struct {
+	Schema string
+	Table  string
+	columnInfo
+}
+
+type constraintRow struct {
+	Schema            string
+	Table             string
+	Name              string
+	Type              string
+	Columns           []string
+	ReferencedSchema  string
+	ReferencedTable   string
+	ReferencedColumns []string
+	Definition        string
+}
+
+type indexRow struct {
+	Schema string
+	Table  string
+	indexInfo
+}
+
+// describeSchema introspects the catalog through tx and returns a compact
+// description of the matching tables.
+func describeSchema(ctx context.Context, tx pgx.Tx, filter schemaFilter) (*schemaDocument, error) {
+	schemas := filter.Schemas
+	if schemas == nil {
+		schemas = []string{}
+	}
+	tables := filter.Tables
+	if tables == nil {
+		tables = []string{}
+	}
+
+	// Ask for one extra table so truncation can be detected
+	tableRows, err := queryCatalog(ctx, tx, describeTablesSQL, []any{schemas, tables, maxSchemaTables + 1},
+		func(row pgx.CollectableRow) (tableRow, error) {
+			var t tableRow
+			err := row.Scan(&t.OID, &t.Schema, &t.Table, &t.Kind, &t.EstimatedRows)
+			return t, err
+		})
+	if err != nil {
+		return nil, fmt.Errorf("error listing tables: %w", err)
+	}
+
+	// The remaining queries only cover the tables that will be described,
+	// not every table the filter matches.
+	oids := make([]uint32, 0, min(len(tableRows), maxSchemaTables))
+	for _, t := range tableRows[:min(len(tableRows), maxSchemaTables)] {
+		oids = append(oids, t.OID)
+	}
+
+	columnRows, err := queryCatalog(ctx, tx, describeColumnsSQL, []any{oids},
+		func(row pgx.CollectableRow) (columnRow, error) {
+			var c columnRow
+			err := row.Scan(&c.Schema, &c.Table, &c.Name, &c.Type, &c.Nullable, &c.Default)
+			return c, err
+		})
+	if err != nil {
+		return nil, fmt.Errorf("error listing columns: %w", err)
+	}
+
+	constraintRows, err := queryCatalog(ctx, tx, describeConstraintsSQL, []any{oids},
+		func(row pgx.CollectableRow) (constraintRow, error) {
+			var c constraintRow
+			err := row.Scan(&c.Schema, &c.Table, &c.Name, &c.Type, &c.Columns,
+				&c.ReferencedSchema, &c.ReferencedTable, &c.ReferencedColumns, &c.Definition)
+			return c, err
+		})
+	if err != nil {
+		return nil, fmt.Errorf("error listing constraints: %w", err)
+	}
+
+	indexRows, err := queryCatalog(ctx, tx, describeIndexesSQL, []any{oids},
+		func(row pgx.CollectableRow) (indexRow, error) {
+			var i indexRow
+			err := row.Scan(&i.Schema, &i.Table, &i.Name, &i.Unique, &i.Primary, &i.Definition)
+			return i, err
+		})
+	if err != nil {
+		return nil, fmt.Errorf("error listing indexes: %w", err)
+	}
+
+	return buildSchemaDocument(tableRows, columnRows, constraintRows, indexRows), nil
+}
+
+func queryCatalog[T any](ctx context.Context, tx pgx.Tx, sql string, args []any, scan pgx.RowToFunc[T]) ([]T, error) {
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, scan)
+}
+
+// buildSchemaDocument groups flat catalog rows into schemas and tables. Rows
+// for tables that were cut off by maxSchemaTables are dropped.
+func buildSchemaDocument(tableRows []tableRow, columnRows []columnRow, constraintRows []constraintRow, indexRows []indexRow) *schemaDocument {
+	doc := &schemaDocument{Schemas: []*schemaInfo{}}
+	if len(tableRows) > maxSchemaTables {
+		tableRows = tableRows[:maxSchemaTables]
+		doc.Truncated = true
+	}
+
+	type tableKey struct{ schema, table string }
+	byKey := make(map[tableKey]*tableInfo, len(tableRows))
+	var current *schemaInfo
+	for _, t := range tableRows {
+		if current == nil || current.Name != t.Schema {
+			current = &schemaInfo{Name: t.Schema, Tables: []*tableInfo{}}
+			doc.Schemas = append(doc.Schemas, current)
+		}
+		info := &tableInfo{
+			Name:    t.Table,
+			Kind:    relationKinds[t.Kind],
+			Columns: []columnInfo{},
+		}
+		// reltuples is -1 for tables that have never been vacuumed or analyzed
+		if t.EstimatedRows >= 0 && t.Kind != "v" {
+			estimatedRows := t.EstimatedRows
+			info.EstimatedRows = &estimatedRows
+		}
+		current.Tables = append(current.Tables, info)
+		byKey[tableKey{t.Schema, t.Table}] = info
+	}
+
+	for _, c := range columnRows {
+		if info, ok := byKey[tableKey{c.Schema, c.Table}]; ok {
+			info.Columns = append(info.Columns, c.columnInfo)
+		}
+	}
+
+	for _, c := range constraintRows {
+		info, ok := byKey[tableKey{c.Schema, c.Table}]
+		if !ok {
+			continue
+		}
+		switch c.Type {
+		case "p":
+			info.PrimaryKey = c.Columns
+		case "f":
+			info.ForeignKeys = append(info.ForeignKeys, foreignKeyInfo{
+				Name:              c.Name,
+				Columns:           c.Columns,
+				ReferencedSchema:  c.ReferencedSchema,
+				ReferencedTable:   c.ReferencedTable,
+				ReferencedColumns: c.ReferencedColumns,
+				Definition:        c.Definition,
+			})
+		case "c", "u":
+			info.Constraints = append(info.Constraints, constraintInfo{
+				Name:       c.Name,
+				Type:       constraintTypes[c.Type],
+				Definition: c.Definition,
+			})
+		}
+	}
+
+	for _, i := range indexRows {
+		if info, ok := byKey[tableKey{i.Schema, i.Table}]; ok {
+			info.Indexes = append(info.Indexes, i.indexInfo)
+		}
+	}
+
+	return doc
+}
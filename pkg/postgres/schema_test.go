@@ -0,0 +1,82 @@
+package postgres
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/render-oss/render-mcp-server/pkg/pointers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildSchemaDocument(t *testing.T) {
+	tableRows := []tableRow{
+		{Schema: "app", Table: "events", Kind: "r", EstimatedRows: -1},
+		{Schema: "public", Table: "orgs", Kind: "r", EstimatedRows: 10},
+		{Schema: "public", Table: "users", Kind: "r", EstimatedRows: 1200},
+		{Schema: "public", Table: "users_view", Kind: "v", EstimatedRows: 0},
+	}
+	columnRows := []columnRow{
+		{Schema: "public", Table: "users", columnInfo: columnInfo{Name: "id", Type: "bigint", Default: pointers.From("nextval('users_id_seq'::regclass)")}},
+		{Schema: "public", Table: "users", columnInfo: columnInfo{Name: "org_id", Type: "bigint", Nullable: true}},
+		{Schema: "public", Table: "not_listed", columnInfo: columnInfo{Name: "id", Type: "integer"}},
+	}
+	constraintRows := []constraintRow{
+		{Schema: "public", Table: "users", Name: "users_pkey", Type: "p", Columns: []string{"id"}},
+		{
+			Schema: "public", Table: "users", Name: "users_org_id_fkey", Type: "f", Columns: []string{"org_id"},
+			ReferencedSchema: "public", ReferencedTable: "orgs", ReferencedColumns: []string{"id"},
+			Definition: "FOREIGN KEY (org_id) REFERENCES orgs(id) ON DELETE SET NULL",
+		},
+		{Schema: "public", Table: "users", Name: "users_email_key", Type: "u", Columns: []string{"email"}, Definition: "UNIQUE (email)"},
+	}
+	indexRows := []indexRow{
+		{Schema: "public", Table: "users", indexInfo: indexInfo{
+			Name: "users_pkey", Unique: true, Primary: true,
+			Definition: "CREATE UNIQUE INDEX users_pkey ON public.users USING btree (id)",
+		}},
+	}
+
+	doc := buildSchemaDocument(tableRows, columnRows, constraintRows, indexRows)
+
+	assert.False(t, doc.Truncated)
+	require.Len(t, doc.Schemas, 2)
+	assert.Equal(t, "app", doc.Schemas[0].Name)
+	assert.Nil(t, doc.Schemas[0].Tables[0].EstimatedRows, "never-analyzed tables have no estimate")
+
+	public := doc.Schemas[1]
+	assert.Equal(t, "public", public.Name)
+	require.Len(t, public.Tables, 3)
+
+	users := public.Tables[1]
+	assert.Equal(t, "users", users.Name)
+	assert.Equal(t, "table", users.Kind)
+	assert.Equal(t, pointers.From(int64(1200)), users.EstimatedRows)
+	assert.Equal(t, "org_id", users.Columns[1].Name)
+	assert.True(t, users.Columns[1].Nullable)
+	assert.Equal(t, []string{"id"}, users.PrimaryKey)
+	require.Len(t, users.ForeignKeys, 1)
+	assert.Equal(t, "orgs", users.ForeignKeys[0].ReferencedTable)
+	assert.Equal(t, "FOREIGN KEY (org_id) REFERENCES orgs(id) ON DELETE SET NULL", users.ForeignKeys[0].Definition)
+	assert.Equal(t, []constraintInfo{{Name: "users_email_key", Type: "unique", Definition: "UNIQUE (email)"}}, users.Constraints)
+	require.Len(t, users.Indexes, 1)
+	assert.True(t, users.Indexes[0].Primary)
+
+	view := public.Tables[2]
+	assert.Equal(t, "view", view.Kind)
+	assert.Nil(t, view.EstimatedRows)
+	assert.Empty(t, view.Columns)
+}
+
+func TestBuildSchemaDocumentTruncates(t *testing.T) {
+	tableRows := make([]tableRow, 0, maxSchemaTables+1)
+	for i := 0; i <= maxSchemaTables; i++ {
+		tableRows = append(tableRows, tableRow{Schema: "public", Table: fmt.Sprintf("t%03d", i), Kind: "r"})
+	}
+
+	doc := buildSchemaDocument(tableRows, nil, nil, nil)
+
+	assert.True(t, doc.Truncated)
+	require.Len(t, doc.Schemas, 1)
+	assert.Len(t, doc.Schemas[0].Tables, maxSchemaTables)
+}
@@ -0,0 +1,237 @@
+package postgres
+
+import (
+	"sort"
+	"strings"
+)
+
+// schemaDiff is the difference between the schemas of two databases. Objects
+// present in only one of them are listed as missing from the other.
+type schemaDiff struct {
+	Identical             bool         `json:"identical"`
+	TablesMissingInTarget []string     `json:"tablesMissingInTarget,omitempty"`
+	TablesMissingInSource []string     `json:"tablesMissingInSource,omitempty"`
+	ChangedTables         []*tableDiff `json:"changedTables,omitempty"`
+	// Truncated is set when either description hit maxSchemaTables, so
+	// tables past the cap weren't compared.
+	Truncated bool `json:"truncated,omitempty"`
+}
+
+type tableDiff struct {
+	Schema                     string                  `json:"schema"`
+	Name                       string                  `json:"name"`
+	Kind                       *valueChange            `json:"kind,omitempty"`
+	ColumnsMissingInTarget     []string                `json:"columnsMissingInTarget,omitempty"`
+	ColumnsMissingInSource     []string                `json:"columnsMissingInSource,omitempty"`
+	ChangedColumns             []columnDiff            `json:"changedColumns,omitempty"`
+	PrimaryKey                 *valueChange            `json:"primaryKey,omitempty"`
+	IndexesMissingInTarget     []string                `json:"indexesMissingInTarget,omitempty"`
+	IndexesMissingInSource     []string                `json:"indexesMissingInSource,omitempty"`
+	ChangedIndexes             []namedDefinitionChange `json:"changedIndexes,omitempty"`
+	ForeignKeysMissingInTarget []string                `json:"foreignKeysMissingInTarget,omitempty"`
+	ForeignKeysMissingInSource []string                `json:"foreignKeysMissingInSource,omitempty"`
+	ChangedForeignKeys         []namedDefinitionChange `json:"changedForeignKeys,omitempty"`
+	ConstraintsMissingInTarget []string                `json:"constraintsMissingInTarget,omitempty"`
+	ConstraintsMissingInSource []string                `json:"constraintsMissingInSource,omitempty"`
+	ChangedConstraints         []namedDefinitionChange `json:"changedConstraints,omitempty"`
+}
+
+type columnDiff struct {
+	Name     string       `json:"name"`
+	Type     *valueChange `json:"type,omitempty"`
+	Nullable *valueChange `json:"nullable,omitempty"`
+	Default  *valueChange `json:"default,omitempty"`
+}
+
+// valueChange holds a property's value in the source and target databases.
+type valueChange struct {
+	Source any `json:"source"`
+	Target any `json:"target"`
+}
+
+type namedDefinitionChange struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+// diffSchemas compares two schema descriptions, treating source as the
+// reference: "missing in target" means source has something target lacks.
+func diffSchemas(source, target *schemaDocument) *schemaDiff {
+	diff := &schemaDiff{Truncated: source.Truncated || target.Truncated}
+
+	sourceTables := tablesByName(source)
+	targetTables := tablesByName(target)
+
+	for _, name := range sortedKeys(sourceTables) {
+		t, ok := targetTables[name]
+		if !ok {
+			diff.TablesMissingInTarget = append(diff.TablesMissingInTarget, name)
+			continue
+		}
+		s := sourceTables[name]
+		if td := diffTables(s.schema, s.table, t.table); td != nil {
+			diff.ChangedTables = append(diff.ChangedTables, td)
+		}
+	}
+	for _, name := range sortedKeys(targetTables) {
+		if _, ok := sourceTables[name]; !ok {
+			diff.TablesMissingInSource = append(diff.TablesMissingInSource, name)
+		}
+	}
+
+	diff.Identical = len(diff.TablesMissingInTarget) == 0 && len(diff.TablesMissingInSource) == 0 &&
+		len(diff.ChangedTables) == 0
+	return diff
+}
+
+type qualifiedTable struct {
+	schema string
+	table  *tableInfo
+}
+
+// tablesByName indexes a description's tables by their schema-qualified name.
+func tablesByName(doc *schemaDocument) map[string]qualifiedTable {
+	tables := map[string]qualifiedTable{}
+	for _, schema := range doc.Schemas {
+		for _, table := range schema.Tables {
+			tables[schema.Name+"."+table.Name] = qualifiedTable{schema: schema.Name, table: table}
+		}
+	}
+	return tables
+}
+
+// diffTables compares two versions of a table, returning nil when they match.
+func diffTables(schema string, source, target *tableInfo) *tableDiff {
+	td := &tableDiff{Schema: schema, Name: source.Name}
+	changed := false
+
+	if source.Kind != target.Kind {
+		td.Kind = &valueChange{Source: source.Kind, Target: target.Kind}
+		changed = true
+	}
+
+	targetColumns := make(map[string]columnInfo, len(target.Columns))
+	for _, c := range target.Columns {
+		targetColumns[c.Name] = c
+	}
+	sourceColumns := make(map[string]bool, len(source.Columns))
+	for _, sc := range source.Columns {
+		sourceColumns[sc.Name] = true
+		tc, ok := targetColumns[sc.Name]
+		if !ok {
+			td.ColumnsMissingInTarget = append(td.ColumnsMissingInTarget, sc.Name)
+			changed = true
+			continue
+		}
+		if cd, ok := diffColumns(sc, tc); ok {
+			td.ChangedColumns = append(td.ChangedColumns, cd)
+			changed = true
+		}
+	}
+	for _, tc := range target.Columns {
+		if !sourceColumns[tc.Name] {
+			td.ColumnsMissingInSource = append(td.ColumnsMissingInSource, tc.Name)
+			changed = true
+		}
+	}
+
+	if strings.Join(source.PrimaryKey, ",") != strings.Join(target.PrimaryKey, ",") {
+		td.PrimaryKey = &valueChange{Source: source.PrimaryKey, Target: target.PrimaryKey}
+		changed = true
+	}
+
+	sourceIndexes := make(map[string]string, len(source.Indexes))
+	for _, i := range source.Indexes {
+		sourceIndexes[i.Name] = i.Definition
+	}
+	targetIndexes := make(map[string]string, len(target.Indexes))
+	for _, i := range target.Indexes {
+		targetIndexes[i.Name] = i.Definition
+	}
+	td.IndexesMissingInTarget, td.IndexesMissingInSource, td.ChangedIndexes = diffDefinitions(sourceIndexes, targetIndexes)
+
+	sourceKeys := make(map[string]string, len(source.ForeignKeys))
+	for _, fk := range source.ForeignKeys {
+		sourceKeys[fk.Name] = fk.Definition
+	}
+	targetKeys := make(map[string]string, len(target.ForeignKeys))
+	for _, fk := range target.ForeignKeys {
+		targetKeys[fk.Name] = fk.Definition
+	}
+	td.ForeignKeysMissingInTarget, td.ForeignKeysMissingInSource, td.ChangedForeignKeys = diffDefinitions(sourceKeys, targetKeys)
+
+	sourceConstraints := make(map[string]string, len(source.Constraints))
+	for _, c := range source.Constraints {
+		sourceConstraints[c.Name] = c.Definition
+	}
+	targetConstraints := make(map[string]string, len(target.Constraints))
+	for _, c := range target.Constraints {
+		targetConstraints[c.Name] = c.Definition
+	}
+	td.ConstraintsMissingInTarget, td.ConstraintsMissingInSource, td.ChangedConstraints = diffDefinitions(sourceConstraints, targetConstraints)
+
+	if len(td.IndexesMissingInTarget) > 0 || len(td.IndexesMissingInSource) > 0 || len(td.ChangedIndexes) > 0 ||
+		len(td.ForeignKeysMissingInTarget) > 0 || len(td.ForeignKeysMissingInSource) > 0 || len(td.ChangedForeignKeys) > 0 ||
+		len(td.ConstraintsMissingInTarget) > 0 || len(td.ConstraintsMissingInSource) > 0 || len(td.ChangedConstraints) > 0 {
+		changed = true
+	}
+
+	if !changed {
+		return nil
+	}
+	return td
+}
+
+func diffColumns(source, target columnInfo) (columnDiff, bool) {
+	cd := columnDiff{Name: source.Name}
+	changed := false
+	if source.Type != target.Type {
+		cd.Type = &valueChange{Source: source.Type, Target: target.Type}
+		changed = true
+	}
+	if source.Nullable != target.Nullable {
+		cd.Nullable = &valueChange{Source: source.Nullable, Target: target.Nullable}
+		changed = true
+	}
+	if !equalDefaults(source.Default, target.Default) {
+		cd.Default = &valueChange{Source: source.Default, Target: target.Default}
+		changed = true
+	}
+	return cd, changed
+}
+
+func equalDefaults(a, b *string) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return *a == *b
+}
+
+// diffDefinitions compares named objects by their definitions.
+func diffDefinitions(source, target map[string]string) (missingInTarget, missingInSource []string, changed []namedDefinitionChange) {
+	for _, name := range sortedKeys(source) {
+		targetDef, ok := target[name]
+		switch {
+		case !ok:
+			missingInTarget = append(missingInTarget, name)
+		case targetDef != source[name]:
+			changed = append(changed, namedDefinitionChange{Name: name, Source: source[name], Target: targetDef})
+		}
+	}
+	for _, name := range sortedKeys(target) {
+		if _, ok := source[name]; !ok {
+			missingInSource = append(missingInSource, name)
+		}
+	}
+	return missingInTarget, missingInSource, changed
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
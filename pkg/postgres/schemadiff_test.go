@@ -0,0 +1,120 @@
+package postgres
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiffSchemasIdentical(t *testing.T) {
+	doc := &schemaDocument{Schemas: []*schemaInfo{{
+		Name: "public",
+		Tables: []*tableInfo{{
+			Name:       "users",
+			Kind:       "table",
+			Columns:    []columnInfo{{Name: "id", Type: "bigint"}},
+			PrimaryKey: []string{"id"},
+		}},
+	}}}
+
+	diff := diffSchemas(doc, doc)
+	assert.True(t, diff.Identical)
+	assert.Empty(t, diff.ChangedTables)
+}
+
+func TestDiffSchemas(t *testing.T) {
+	defaultNow := "now()"
+	source := &schemaDocument{Schemas: []*schemaInfo{{
+		Name: "public",
+		Tables: []*tableInfo{
+			{
+				Name: "orders",
+				Kind: "table",
+				Columns: []columnInfo{
+					{Name: "id", Type: "bigint"},
+					{Name: "user_id", Type: "bigint"},
+					{Name: "total", Type: "numeric(10,2)", Nullable: true},
+					{Name: "created_at", Type: "timestamp with time zone", Default: &defaultNow},
+				},
+				PrimaryKey: []string{"id"},
+				ForeignKeys: []foreignKeyInfo{{
+					Name: "orders_user_id_fkey", Columns: []string{"user_id"},
+					ReferencedSchema: "public", ReferencedTable: "users", ReferencedColumns: []string{"id"},
+					Definition: "FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE",
+				}},
+				Constraints: []constraintInfo{
+					{Name: "orders_total_check", Type: "check", Definition: "CHECK ((total >= (0)::numeric))"},
+					{Name: "orders_reference_key", Type: "unique", Definition: "UNIQUE (reference)"},
+				},
+				Indexes: []indexInfo{
+					{Name: "orders_pkey", Primary: true, Unique: true, Definition: "CREATE UNIQUE INDEX orders_pkey ON public.orders USING btree (id)"},
+					{Name: "orders_user_id_idx", Definition: "CREATE INDEX orders_user_id_idx ON public.orders USING btree (user_id)"},
+				},
+			},
+			{Name: "audit_log", Kind: "table", Columns: []columnInfo{{Name: "id", Type: "bigint"}}},
+		},
+	}}}
+	target := &schemaDocument{Schemas: []*schemaInfo{{
+		Name: "public",
+		Tables: []*tableInfo{
+			{
+				Name: "orders",
+				Kind: "table",
+				Columns: []columnInfo{
+					{Name: "id", Type: "bigint"},
+					{Name: "user_id", Type: "bigint"},
+					{Name: "total", Type: "numeric(12,2)"},
+					{Name: "legacy_status", Type: "text", Nullable: true},
+				},
+				PrimaryKey: []string{"id"},
+				ForeignKeys: []foreignKeyInfo{{
+					Name: "orders_user_id_fkey", Columns: []string{"user_id"},
+					ReferencedSchema: "public", ReferencedTable: "users", ReferencedColumns: []string{"id"},
+					Definition: "FOREIGN KEY (user_id) REFERENCES users(id)",
+				}},
+				Constraints: []constraintInfo{
+					{Name: "orders_total_check", Type: "check", Definition: "CHECK ((total > (0)::numeric))"},
+					{Name: "orders_status_check", Type: "check", Definition: "CHECK ((legacy_status <> ''::text))"},
+				},
+				Indexes: []indexInfo{
+					{Name: "orders_pkey", Primary: true, Unique: true, Definition: "CREATE UNIQUE INDEX orders_pkey ON public.orders USING btree (id)"},
+					{Name: "orders_user_id_idx", Definition: "CREATE INDEX orders_user_id_idx ON public.orders USING hash (user_id)"},
+				},
+			},
+			{Name: "sessions", Kind: "table", Columns: []columnInfo{{Name: "id", Type: "uuid"}}},
+		},
+	}}, Truncated: true}
+
+	diff := diffSchemas(source, target)
+	assert.False(t, diff.Identical)
+	assert.True(t, diff.Truncated)
+	assert.Equal(t, []string{"public.audit_log"}, diff.TablesMissingInTarget)
+	assert.Equal(t, []string{"public.sessions"}, diff.TablesMissingInSource)
+
+	require.Len(t, diff.ChangedTables, 1)
+	orders := diff.ChangedTables[0]
+	assert.Equal(t, "orders", orders.Name)
+	assert.Nil(t, orders.Kind)
+	assert.Nil(t, orders.PrimaryKey)
+	assert.Equal(t, []string{"created_at"}, orders.ColumnsMissingInTarget)
+	assert.Equal(t, []string{"legacy_status"}, orders.ColumnsMissingInSource)
+	assert.Equal(t, []columnDiff{{
+		Name:     "total",
+		Type:     &valueChange{Source: "numeric(10,2)", Target: "numeric(12,2)"},
+		Nullable: &valueChange{Source: true, Target: false},
+	}}, orders.ChangedColumns)
+	assert.Empty(t, orders.IndexesMissingInTarget)
+	require.Len(t, orders.ChangedIndexes, 1)
+	assert.Equal(t, "orders_user_id_idx", orders.ChangedIndexes[0].Name)
+	assert.Empty(t, orders.ForeignKeysMissingInTarget)
+	assert.Equal(t, []namedDefinitionChange{{
+		Name:   "orders_user_id_fkey",
+		Source: "FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE",
+		Target: "FOREIGN KEY (user_id) REFERENCES users(id)",
+	}}, orders.ChangedForeignKeys)
+	assert.Equal(t, []string{"orders_reference_key"}, orders.ConstraintsMissingInTarget)
+	assert.Equal(t, []string{"orders_status_check"}, orders.ConstraintsMissingInSource)
+	require.Len(t, orders.ChangedConstraints, 1)
+	assert.Equal(t, "orders_total_check", orders.ChangedConstraints[0].Name)
+}
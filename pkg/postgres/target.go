@@ -0,0 +1,115 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/render-oss/render-mcp-server/pkg/client"
+	metricstypes "github.com/render-oss/render-mcp-server/pkg/client/metrics"
+	"github.com/render-oss/render-mcp-server/pkg/metrics"
+)
+
+const (
+	// targetPrimary runs queries against the primary instance.
+	targetPrimary = "primary"
+	// targetReplica runs queries against the first read replica.
+	targetReplica = "replica"
+
+	// replicationLagWindow is how far back to look for the latest
+	// replication lag sample.
+	replicationLagWindow = 15 * time.Minute
+)
+
+// queryTarget describes the read replica a query ran against. Queries against
+// the primary don't report a target.
+type queryTarget struct {
+	Role                string          `json:"role"`
+	ID                  string          `json:"id"`
+	Name                string          `json:"name"`
+	ReplicationLag      *replicationLag `json:"replicationLag,omitempty"`
+	ReplicationLagError string          `json:"replicationLagError,omitempty"`
+}
+
+// replicationLag is the most recent replication lag sample for a replica,
+// which tells how stale its data may be.
+type replicationLag struct {
+	Value float32   `json:"value"`
+	Unit  string    `json:"unit,omitempty"`
+	At    time.Time `json:"at"`
+}
+
+// resolveReadReplica picks the read replica of postgres that target names. It
+// returns nil when target is the primary.
+func resolveReadReplica(postgres *client.PostgresDetail, target string) (*client.ReadReplica, error) {
+	switch target {
+	case "", targetPrimary:
+		return nil, nil
+	case targetReplica:
+		if len(postgres.ReadReplicas) == 0 {
+			return nil, fmt.Errorf("no read replicas exist for Postgres instance %s", postgres.Id)
+		}
+		return &postgres.ReadReplicas[0], nil
+	}
+
+	ids := make([]string, len(postgres.ReadReplicas))
+	for i, replica := range postgres.ReadReplicas {
+		if replica.Id == target {
+			return &postgres.ReadReplicas[i], nil
+		}
+		ids[i] = replica.Id
+	}
+	if len(ids) == 0 {
+		return nil, fmt.Errorf("no read replicas exist for Postgres instance %s", postgres.Id)
+	}
+	return nil, fmt.Errorf("%q is not a read replica of Postgres instance %s; its replicas are: %s",
+		target, postgres.Id, strings.Join(ids, ", "))
+}
+
+// replicaTarget describes replica as a query target, with its latest
+// replication lag. A failure to fetch the lag is reported rather than
+// failing the query.
+func replicaTarget(ctx context.Context, metricsRepo *metrics.Repo, postgresId string, replica *client.ReadReplica) *queryTarget {
+	target := &queryTarget{Role: targetReplica, ID: replica.Id, Name: replica.Name}
+
+	end := time.Now().UTC()
+	start := end.Add(-replicationLagWindow)
+	resp, err := metricsRepo.GetMetrics(ctx, metrics.MetricsRequest{
+		ResourceID:  postgresId,
+		MetricTypes: []metrics.MetricType{metrics.MetricTypeReplicationLag},
+		StartTime:   &start,
+		EndTime:     &end,
+	})
+	if err != nil {
+		target.ReplicationLagError = err.Error()
+		return target
+	}
+	if len(resp.Metrics) > 0 {
+		target.ReplicationLag = latestReplicationLag(replica.Id, resp.Metrics[0].Data)
+	}
+	if target.ReplicationLag == nil {
+		target.ReplicationLagError = fmt.Sprintf("no replication lag was reported for %s in the last %.0f minutes",
+			replica.Id, replicationLagWindow.Minutes())
+	}
+	return target
+}
+
+// latestReplicationLag finds the most recent sample for replicaId in the
+// replication_lag metric. The metric is reported against the primary, so only
+// series labeled with the replica count; it returns nil if there are none.
+func latestReplicationLag(replicaId string, data metricstypes.TimeSeriesCollection) *replicationLag {
+	var latest *replicationLag
+	for _, s := range data {
+		if !slices.ContainsFunc(s.Labels, func(label metricstypes.Label) bool { return label.Value == replicaId }) {
+			continue
+		}
+		for _, v := range s.Values {
+			if latest == nil || v.Timestamp.After(latest.At) {
+				latest = &replicationLag{Value: v.Value, Unit: s.Unit, At: v.Timestamp}
+			}
+		}
+	}
+	return latest
+}
@@ -0,0 +1,65 @@
+package postgres
+
+import (
+	"testing"
+	"time"
+
+	"github.com/render-oss/render-mcp-server/pkg/client"
+	metricstypes "github.com/render-oss/render-mcp-server/pkg/client/metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveReadReplica(t *testing.T) {
+	postgres := &client.PostgresDetail{
+		Id: "dpg-primary",
+		ReadReplicas: client.ReadReplicas{
+			{Id: "dpg-replica-1", Name: "analytics"},
+			{Id: "dpg-replica-2", Name: "reporting"},
+		},
+	}
+
+	replica, err := resolveReadReplica(postgres, targetPrimary)
+	require.NoError(t, err)
+	assert.Nil(t, replica)
+
+	replica, err = resolveReadReplica(postgres, targetReplica)
+	require.NoError(t, err)
+	assert.Equal(t, "dpg-replica-1", replica.Id)
+
+	replica, err = resolveReadReplica(postgres, "dpg-replica-2")
+	require.NoError(t, err)
+	assert.Equal(t, "reporting", replica.Name)
+
+	_, err = resolveReadReplica(postgres, "dpg-other")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "dpg-replica-1, dpg-replica-2")
+
+	_, err = resolveReadReplica(&client.PostgresDetail{Id: "dpg-primary"}, targetReplica)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no read replicas")
+}
+
+func TestLatestReplicationLag(t *testing.T) {
+	t0 := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	data := metricstypes.TimeSeriesCollection{
+		{
+			Unit:   "seconds",
+			Labels: []metricstypes.Label{{Field: "instance", Value: "dpg-replica-1"}},
+			Values: []metricstypes.TimeSeriesValue{
+				{Timestamp: t0.Add(time.Minute), Value: 4},
+				{Timestamp: t0, Value: 9},
+			},
+		},
+		{
+			Unit:   "seconds",
+			Labels: []metricstypes.Label{{Field: "instance", Value: "dpg-replica-2"}},
+			Values: []metricstypes.TimeSeriesValue{{Timestamp: t0.Add(2 * time.Minute), Value: 30}},
+		},
+	}
+
+	assert.Equal(t, &replicationLag{Value: 4, Unit: "seconds", At: t0.Add(time.Minute)},
+		latestReplicationLag("dpg-replica-1", data))
+	assert.Nil(t, latestReplicationLag("dpg-unlabeled", data), "other replicas' lag isn't reported")
+	assert.Nil(t, latestReplicationLag("dpg-replica-1", nil))
+}
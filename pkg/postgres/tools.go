@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -11,6 +12,7 @@ import (
 	"github.com/render-oss/render-mcp-server/pkg/client"
 	pgclient "github.com/render-oss/render-mcp-server/pkg/client/postgres"
 	"github.com/render-oss/render-mcp-server/pkg/mcpserver"
+	"github.com/render-oss/render-mcp-server/pkg/metrics"
 	"github.com/render-oss/render-mcp-server/pkg/pointers"
 	"github.com/render-oss/render-mcp-server/pkg/session"
 	"github.com/render-oss/render-mcp-server/pkg/validate"
@@ -18,13 +20,34 @@ import (
 
 func Tools(c *client.ClientWithResponses) []server.ServerTool {
 	postgresRepo := NewRepo(c)
+	conns := newPgxConnPool()
+	cursors := newCursorRegistry()
+	metricsRepo := metrics.NewRepo(c)
 
-	return []server.ServerTool{
+	tools := []server.ServerTool{
 		listPostgresInstances(postgresRepo),
 		getPostgres(postgresRepo),
 		createPostgres(postgresRepo),
-		queryPostgres(postgresRepo),
+		updatePostgres(postgresRepo),
+		queryPostgres(postgresRepo, conns, cursors, metricsRepo),
+		fetchPostgresCursor(cursors),
+		describePostgresSchema(postgresRepo, conns),
+		diffPostgresSchemas(postgresRepo, conns),
+		profilePostgresTable(postgresRepo, conns),
+		explainPostgresQuery(postgresRepo, conns),
+		diagnosePostgres(postgresRepo, conns, metricsRepo),
+		restartPostgres(postgresRepo),
+		suspendPostgres(postgresRepo),
+		resumePostgres(postgresRepo),
+		failoverPostgres(postgresRepo),
+		deletePostgres(postgresRepo),
 	}
+
+	// Writes are opt-in; the tool isn't registered at all unless enabled
+	if config := writeConfigOrDisabled(); config.Enabled {
+		tools = append(tools, executePostgres(postgresRepo, conns, config))
+	}
+	return tools
 }
 
 func listPostgresInstances(postgresRepo *Repo) server.ServerTool {
@@ -200,11 +223,173 @@ func createPostgres(postgresRepo *Repo) server.ServerTool {
 	}
 }
 
-func queryPostgres(postgresRepo *Repo) server.ServerTool {
+// maxReadReplicas is the most read replicas Render allows on a single
+// Postgres instance.
+const maxReadReplicas = 5
+
+func updatePostgres(postgresRepo *Repo) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("update_postgres",
+			mcp.WithDescription("Update the capacity of a Postgres instance: its plan, disk size, high availability, "+
+				"disk autoscaling or number of read replicas. Only the provided fields are changed. "+
+				"Changing the plan or enabling high availability restarts the database."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           "Update Postgres instance",
+				ReadOnlyHint:    pointers.From(false),
+				DestructiveHint: pointers.From(true),
+				IdempotentHint:  pointers.From(true),
+				OpenWorldHint:   pointers.From(false),
+			}),
+			mcp.WithString("postgresId",
+				mcp.Required(),
+				mcp.Description("The ID of the Postgres instance to update"),
+			),
+			mcp.WithString("plan",
+				mcp.Description("The new pricing plan for the database"),
+				mcp.Enum(mcpserver.PostgresPlanEnumValues()...),
+			),
+			mcp.WithNumber("diskSizeGb",
+				mcp.Description("The new capacity of the database, in GB. Storage can only be increased, never decreased. "+
+					"Specify 1 GB or any multiple of 5 GB."),
+			),
+			mcp.WithBoolean("enableHighAvailability",
+				mcp.Description("Whether to run a standby instance that the database can fail over to"),
+			),
+			mcp.WithBoolean("enableDiskAutoscaling",
+				mcp.Description("Whether to automatically increase disk size as the database fills up"),
+			),
+			mcp.WithNumber("readReplicaCount",
+				mcp.Description("The number of read replicas the database should have. Existing replicas are kept "+
+					"when increasing the count; the last replicas listed by get_postgres are removed when decreasing it."),
+				mcp.Min(0),
+				mcp.Max(maxReadReplicas),
+			),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			postgresId, err := validate.RequiredToolParam[string](request, "postgresId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			current, err := postgresRepo.GetPostgresInWorkspace(ctx, postgresId)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			var updateParams client.PostgresPATCHInput
+			postgresPlan := current.Plan
+
+			if plan, ok, err := validate.OptionalToolParam[string](request, "plan"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				postgresPlan, err = validate.PostgresPlan(plan)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				updateParams.Plan = &postgresPlan
+			}
+
+			if diskSizeGb, ok, err := validate.OptionalToolParam[float64](request, "diskSizeGb"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				diskSizeGbInt := int(diskSizeGb)
+				err = validate.PostgresDiskSizeGb(diskSizeGbInt)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if postgresPlan == pgclient.Free && diskSizeGbInt > 0 {
+					return mcp.NewToolResultError("Free plan does not support custom disk size"), nil
+				}
+				if current.DiskSizeGB != nil && diskSizeGbInt < *current.DiskSizeGB {
+					return mcp.NewToolResultError(fmt.Sprintf("diskSizeGb cannot be decreased: the database "+
+						"currently has %d GB and Render does not support shrinking disks", *current.DiskSizeGB)), nil
+				}
+				updateParams.DiskSizeGB = &diskSizeGbInt
+			}
+
+			if enableHA, ok, err := validate.OptionalToolParam[bool](request, "enableHighAvailability"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				updateParams.EnableHighAvailability = &enableHA
+			}
+
+			if enableAutoscaling, ok, err := validate.OptionalToolParam[bool](request, "enableDiskAutoscaling"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				updateParams.EnableDiskAutoscaling = &enableAutoscaling
+			}
+
+			if replicaCount, ok, err := validate.OptionalToolParam[float64](request, "readReplicaCount"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				if replicaCount < 0 || replicaCount > maxReadReplicas || replicaCount != float64(int(replicaCount)) {
+					return mcp.NewToolResultError(fmt.Sprintf("readReplicaCount must be a whole number between 0 and %d",
+						maxReadReplicas)), nil
+				}
+				updateParams.ReadReplicas = pointers.From(readReplicasForCount(current, int(replicaCount)))
+			}
+
+			if updateParams == (client.PostgresPATCHInput{}) {
+				return mcp.NewToolResultError("No changes requested. Provide at least one of plan, diskSizeGb, " +
+					"enableHighAvailability, enableDiskAutoscaling or readReplicaCount"), nil
+			}
+
+			postgres, err := postgresRepo.UpdatePostgres(ctx, postgresId, updateParams)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			respJSON, err := json.Marshal(postgres)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			return mcp.NewToolResultText(string(respJSON)), nil
+		},
+	}
+}
+
+// readReplicasForCount returns the replica list the API expects for a database
+// to end up with count read replicas. The API replaces the full list, so
+// existing replicas are carried over by name and new ones are named after the
+// primary.
+func readReplicasForCount(postgres *client.PostgresDetail, count int) client.ReadReplicasInput {
+	replicas := make(client.ReadReplicasInput, 0, count)
+	names := make(map[string]bool, len(postgres.ReadReplicas))
+	for _, replica := range postgres.ReadReplicas {
+		names[replica.Name] = true
+		if len(replicas) < count {
+			replicas = append(replicas, client.ReadReplicaInput{
+				Name:               replica.Name,
+				ParameterOverrides: replica.ParameterOverrides,
+			})
+		}
+	}
+
+	for i := 1; len(replicas) < count; i++ {
+		name := fmt.Sprintf("%s-replica-%d", postgres.Name, i)
+		if names[name] {
+			continue
+		}
+		names[name] = true
+		replicas = append(replicas, client.ReadReplicaInput{Name: name})
+	}
+
+	return replicas
+}
+
+func queryPostgres(postgresRepo *Repo, conns *connPool[*pgx.Conn], cursors *cursorRegistry, metricsRepo *metrics.Repo) server.ServerTool {
 	return server.ServerTool{
 		Tool: mcp.NewTool("query_render_postgres",
 			mcp.WithDescription("Run a read-only SQL query against a Render-hosted Postgres database. "+
-				"This tool creates a new connection for each query and closes it after the query completes."),
+				"Connections are reused across queries to the same database, so running several queries in a row is cheap. "+
+				"Results include the column names and Postgres types in order. Results are capped by `maxRows` "+
+				"and `maxBytes`; when a cap is hit, `truncated` is true and the remaining rows are not returned, "+
+				"so add a WHERE or LIMIT clause rather than raising the caps where possible. "+
+				"To read a large result in pages, set `paginate`: the first page is returned with a `nextCursor` "+
+				"token to pass to fetch_render_postgres_cursor for the following pages. "+
+				"Heavy analytical queries can be run against a read replica with `target`; the response then "+
+				"includes the replica's latest replication lag, which tells how far behind the primary its data may be."),
 			mcp.WithToolAnnotation(mcp.ToolAnnotation{
 				Title:           "Query Postgres",
 				ReadOnlyHint:    pointers.From(true),
@@ -218,7 +403,55 @@ func queryPostgres(postgresRepo *Repo) server.ServerTool {
 			),
 			mcp.WithString("sql",
 				mcp.Required(),
-				mcp.Description("The SQL query to run. Note that the query will be wrapped in a read-only transaction."),
+				mcp.Description("The SQL query to run. Note that the query will be wrapped in a read-only transaction. "+
+					"Use $1, $2, ... placeholders with `params` for values rather than writing them into the SQL."),
+			),
+			mcp.WithArray("params",
+				mcp.Description("Values for the $1, $2, ... placeholders in the query, in order. "+
+					"Strings, numbers, booleans and null are converted to the type Postgres expects for each placeholder, "+
+					"arrays become Postgres arrays, and objects become JSON. "+
+					"Pass large integers and exact decimals as strings to avoid losing precision."),
+			),
+			mcp.WithNumber("maxRows",
+				mcp.Description(fmt.Sprintf("Maximum number of rows to return. Defaults to %d.", defaultMaxRows)),
+				mcp.Min(1),
+				mcp.Max(maxRowsLimit),
+				mcp.DefaultNumber(defaultMaxRows),
+			),
+			mcp.WithString("format",
+				mcp.Description("How to render the rows. `json_rows` returns an object per row keyed by column name. "+
+					"`columnar` returns the columns once and an array of values per column. "+
+					"`csv` and `markdown` return a table, followed by a JSON document with the column types and the "+
+					"`truncated` flag. `columnar`, `csv` and `markdown` are much more compact for large results. "+
+					"Defaults to `json_rows`."),
+				mcp.Enum(resultFormats...),
+				mcp.DefaultString(string(formatJSONRows)),
+			),
+			mcp.WithNumber("maxBytes",
+				mcp.Description(fmt.Sprintf("Maximum size of the returned rows, in bytes as rendered in the chosen "+
+					"format. Defaults to %d.", defaultMaxBytes)),
+				mcp.Min(1),
+				mcp.Max(maxBytesLimit),
+				mcp.DefaultNumber(defaultMaxBytes),
+			),
+			mcp.WithNumber("timeoutSeconds",
+				mcp.Description(fmt.Sprintf("Statement timeout for the query, in seconds. Defaults to %.0f.",
+					defaultStatementTimeout.Seconds())),
+				mcp.Min(1),
+				mcp.Max(maxStatementTimeout.Seconds()),
+				mcp.DefaultNumber(defaultStatementTimeout.Seconds()),
+			),
+			mcp.WithBoolean("paginate",
+				mcp.Description(fmt.Sprintf("Run the query through a server-side cursor and return the first page of "+
+					"`maxRows` rows. While more rows remain the response has a `nextCursor` token, valid for %.0f "+
+					"minutes after each fetch. At most %d cursors can be open on a database at once. Defaults to false.",
+					cursorTTL.Minutes(), maxCursorsPerPostgres)),
+				mcp.DefaultBool(false),
+			),
+			mcp.WithString("target",
+				mcp.Description("Where to run the query: `primary`, `replica` for the first read replica, or the ID "+
+					"of a specific read replica. Defaults to `primary`."),
+				mcp.DefaultString(targetPrimary),
 			),
 		),
 		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -232,80 +465,914 @@ func queryPostgres(postgresRepo *Repo) server.ServerTool {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			connectionInfo, err := postgresRepo.GetPostgresConnectionInfo(ctx, postgresId)
+			params, err := queryParamsFromRequest(request)
 			if err != nil {
 				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			config, err := pgx.ParseConfig(connectionInfo.ExternalConnectionString)
+			limits, err := queryLimitsFromRequest(request)
 			if err != nil {
-				return mcp.NewToolResultErrorFromErr("Error parsing connection string", err), nil
+				return mcp.NewToolResultError(err.Error()), nil
 			}
-			conn, err := pgx.ConnectConfig(ctx, config)
+
+			format, err := resultFormatFromRequest(request)
 			if err != nil {
-				return mcp.NewToolResultErrorFromErr("Error connecting to database", err), nil
+				return mcp.NewToolResultError(err.Error()), nil
 			}
-			defer conn.Close(ctx)
 
-			// Wrap all queries in a READ ONLY transaction
-			tx, err := conn.BeginTx(ctx, pgx.TxOptions{AccessMode: pgx.ReadOnly})
+			paginate, _, err := validate.OptionalToolParam[bool](request, "paginate")
 			if err != nil {
-				return mcp.NewToolResultErrorFromErr("Error beginning transaction", err), nil
+				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			// Make sure we roll back the transaction if it's not committed successfully
-			defer func() {
-				_ = tx.Rollback(ctx) // Ignore error from rollback, as it might already be committed/rolled back
-			}()
+			target, _, err := validate.OptionalToolParam[string](request, "target")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			var replica *client.ReadReplica
+			if target != "" && target != targetPrimary {
+				postgres, err := postgresRepo.GetPostgresInWorkspace(ctx, postgresId)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if replica, err = resolveReadReplica(postgres, target); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+			}
+
+			connId := postgresId
+			if replica != nil {
+				connId = replica.Id
+			}
+			conn, release, err := acquirePostgresConn(ctx, postgresRepo, conns, connId)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
-			rows, err := tx.Query(ctx, sqlQuery)
+			var result *queryResult
+			if paginate {
+				// The cursor keeps the connection until it is exhausted or expires
+				result, err = cursors.open(ctx, conn, release, connId, sqlQuery, params, limits, format)
+			} else {
+				result, err = runReadOnlyQuery(ctx, conn, sqlQuery, params, limits, format)
+				release()
+			}
 			if err != nil {
-				return mcp.NewToolResultErrorFromErr("Error executing query", err), nil
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if replica != nil {
+				result.Target = replicaTarget(ctx, metricsRepo, postgresId, replica)
 			}
-			defer rows.Close()
 
-			// Get field descriptions
-			fieldDescriptions := rows.FieldDescriptions()
-			columnNames := make([]string, len(fieldDescriptions))
-			for i, fd := range fieldDescriptions {
-				columnNames[i] = string(fd.Name)
+			toolResult, err := renderQueryResult(result, format)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("Error rendering results", err), nil
 			}
 
-			results := []map[string]interface{}{}
+			return toolResult, nil
+		},
+	}
+}
 
-			for rows.Next() {
-				values, err := rows.Values()
-				if err != nil {
-					return mcp.NewToolResultErrorFromErr("Error reading row values", err), nil
-				}
+func fetchPostgresCursor(cursors *cursorRegistry) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("fetch_render_postgres_cursor",
+			mcp.WithDescription("Fetch the next page of a query started with query_render_postgres and `paginate`. "+
+				"Pages use the format and limits of the original query unless `maxRows` or `maxBytes` are given. "+
+				"While more rows remain the response has a new `nextCursor` expiry; once the last page is returned "+
+				"the cursor is closed and `nextCursor` is omitted. Set `close` to discard a cursor early."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           "Fetch Postgres cursor",
+				ReadOnlyHint:    pointers.From(true),
+				DestructiveHint: pointers.From(false),
+				IdempotentHint:  pointers.From(false),
+				OpenWorldHint:   pointers.From(false),
+			}),
+			mcp.WithString("cursor",
+				mcp.Required(),
+				mcp.Description("The `nextCursor` token from the previous page"),
+			),
+			mcp.WithNumber("maxRows",
+				mcp.Description("Maximum number of rows in this page. Defaults to the original query's maxRows."),
+				mcp.Min(1),
+				mcp.Max(maxRowsLimit),
+			),
+			mcp.WithNumber("maxBytes",
+				mcp.Description("Maximum size of this page, in bytes as rendered. Defaults to the original query's maxBytes."),
+				mcp.Min(1),
+				mcp.Max(maxBytesLimit),
+			),
+			mcp.WithBoolean("close",
+				mcp.Description("Close the cursor without fetching more rows. Defaults to false."),
+				mcp.DefaultBool(false),
+			),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			token, err := validate.RequiredToolParam[string](request, "cursor")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
 
-				rowMap := make(map[string]interface{})
-				for i, col := range columnNames {
-					// Handle pgx types appropriately
-					val := values[i]
-					switch v := val.(type) {
-					case []byte:
-						// Convert byte arrays to string
-						rowMap[col] = string(v)
-					default:
-						// For other types, use as-is
-						rowMap[col] = v
-					}
+			closeCursor, _, err := validate.OptionalToolParam[bool](request, "close")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			if closeCursor {
+				if err := cursors.closeCursor(ctx, token); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
 				}
-				results = append(results, rowMap)
+				return mcp.NewToolResultText("Cursor closed"), nil
+			}
+
+			// Only limits given on this call override the cursor's own
+			limits, err := queryLimitsFromRequest(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxRows, maxBytes := 0, 0
+			if _, ok := request.GetArguments()["maxRows"]; ok {
+				maxRows = limits.MaxRows
+			}
+			if _, ok := request.GetArguments()["maxBytes"]; ok {
+				maxBytes = limits.MaxBytes
 			}
 
-			// Check for any errors encountered during iteration
-			if err := rows.Err(); err != nil {
-				return mcp.NewToolResultErrorFromErr("Error iterating rows", err), nil
+			result, format, err := cursors.fetch(ctx, token, maxRows, maxBytes)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
 			}
 
-			respJSON, err := json.Marshal(results)
+			toolResult, err := renderQueryResult(result, format)
 			if err != nil {
-				return mcp.NewToolResultErrorFromErr("Error marshaling results", err), nil
+				return mcp.NewToolResultErrorFromErr("Error rendering results", err), nil
 			}
 
-			return mcp.NewToolResultText(string(respJSON)), nil
+			return toolResult, nil
+		},
+	}
+}
+
+func executePostgres(postgresRepo *Repo, conns *connPool[*pgx.Conn], config writeConfig) server.ServerTool {
+	ddlNote := "Schema changes, DROP and TRUNCATE are disabled."
+	if config.AllowDDL {
+		ddlNote = "Schema changes, DROP and TRUNCATE are allowed."
+	}
+
+	return server.ServerTool{
+		Tool: mcp.NewTool("execute_render_postgres",
+			mcp.WithDescription("Run SQL that modifies data in a Render-hosted Postgres database. "+
+				"Each statement is classified before anything runs, and the whole script is rejected if any "+
+				"statement isn't allowed. "+ddlNote+" SELECT INTO counts as a schema change. Reads such as "+
+				"SELECT must use query_render_postgres, and transaction control, COPY, DO, CALL and other "+
+				"unrecognized statements are always rejected. "+
+				"Without `confirm` the statements run in a transaction that is rolled back, and the response "+
+				"reports how many rows each would affect. Show this to the user and only call again with "+
+				"`confirm` set to true once they agree; all statements are then committed together."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           "Execute Postgres statements",
+				ReadOnlyHint:    pointers.From(false),
+				DestructiveHint: pointers.From(true),
+				IdempotentHint:  pointers.From(false),
+				OpenWorldHint:   pointers.From(false),
+			}),
+			mcp.WithString("postgresId",
+				mcp.Required(),
+				mcp.Description("The ID of the Postgres instance to run the statements against"),
+			),
+			mcp.WithString("sql",
+				mcp.Required(),
+				mcp.Description("The SQL to run. Separate multiple statements with semicolons; they run in order "+
+					"in a single transaction."),
+			),
+			mcp.WithArray("params",
+				mcp.Description("Values for the $1, $2, ... placeholders, in order. Only allowed when `sql` is a "+
+					"single statement. Values are converted the same way as for query_render_postgres."),
+			),
+			mcp.WithNumber("timeoutSeconds",
+				mcp.Description(fmt.Sprintf("Statement timeout, in seconds. Defaults to %.0f.",
+					defaultStatementTimeout.Seconds())),
+				mcp.Min(1),
+				mcp.Max(maxStatementTimeout.Seconds()),
+				mcp.DefaultNumber(defaultStatementTimeout.Seconds()),
+			),
+			mcp.WithBoolean("confirm",
+				mcp.Description("Commit the changes. Only set this after showing the user the dry run's "+
+					"affected row counts and getting their confirmation. Defaults to false."),
+				mcp.DefaultBool(false),
+			),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			postgresId, err := validate.RequiredToolParam[string](request, "postgresId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			script, err := validate.RequiredToolParam[string](request, "sql")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			params, err := queryParamsFromRequest(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			limits, err := queryLimitsFromRequest(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			confirm, _, err := validate.OptionalToolParam[bool](request, "confirm")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			statements, err := checkStatements(splitStatements(script), config)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			conn, release, err := acquirePostgresConn(ctx, postgresRepo, conns, postgresId)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			// Statements can leave session state such as settings and advisory
+			// locks behind, so the connection is closed rather than reused by
+			// read-only tools.
+			defer func() {
+				_ = conn.Close(context.Background())
+				release()
+			}()
+
+			result, err := executeStatements(ctx, conn, statements, params, limits.StatementTimeout, confirm)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			return jsonToolResult(result)
+		},
+	}
+}
+
+func queryLimitsFromRequest(request mcp.CallToolRequest) (queryLimits, error) {
+	limits := defaultQueryLimits()
+
+	if maxRows, ok, err := validate.OptionalToolParam[float64](request, "maxRows"); err != nil {
+		return limits, err
+	} else if ok {
+		if maxRows < 1 || maxRows > maxRowsLimit {
+			return limits, fmt.Errorf("maxRows must be between 1 and %d", maxRowsLimit)
+		}
+		limits.MaxRows = int(maxRows)
+	}
+
+	if maxBytes, ok, err := validate.OptionalToolParam[float64](request, "maxBytes"); err != nil {
+		return limits, err
+	} else if ok {
+		if maxBytes < 1 || maxBytes > maxBytesLimit {
+			return limits, fmt.Errorf("maxBytes must be between 1 and %d", maxBytesLimit)
+		}
+		limits.MaxBytes = int(maxBytes)
+	}
+
+	if timeoutSeconds, ok, err := validate.OptionalToolParam[float64](request, "timeoutSeconds"); err != nil {
+		return limits, err
+	} else if ok {
+		timeout := time.Duration(timeoutSeconds * float64(time.Second))
+		if timeout < time.Second || timeout > maxStatementTimeout {
+			return limits, fmt.Errorf("timeoutSeconds must be between 1 and %.0f", maxStatementTimeout.Seconds())
+		}
+		limits.StatementTimeout = timeout
+	}
+
+	return limits, nil
+}
+
+func describePostgresSchema(postgresRepo *Repo, conns *connPool[*pgx.Conn]) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("describe_postgres_schema",
+			mcp.WithDescription("Describe the schema of a Render-hosted Postgres database: its schemas, tables with "+
+				"estimated row counts, columns with types, nullability and defaults, primary and foreign keys, check and "+
+				"unique constraints, and indexes. "+
+				"Use this before writing queries against an unfamiliar database instead of querying the catalog by hand. "+
+				fmt.Sprintf("At most %d tables are described; narrow large databases with `schemas` or `tables`.", maxSchemaTables)),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           "Describe Postgres schema",
+				ReadOnlyHint:    pointers.From(true),
+				DestructiveHint: pointers.From(false),
+				IdempotentHint:  pointers.From(true),
+				OpenWorldHint:   pointers.From(false),
+			}),
+			mcp.WithString("postgresId",
+				mcp.Required(),
+				mcp.Description("The ID of the Postgres instance to describe"),
+			),
+			mcp.WithArray("schemas",
+				mcp.Description("Only describe these schemas. Defaults to every schema except the Postgres system schemas."),
+				mcp.Items(map[string]interface{}{"type": "string"}),
+			),
+			mcp.WithArray("tables",
+				mcp.Description("Only describe tables with these names"),
+				mcp.Items(map[string]interface{}{"type": "string"}),
+			),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			postgresId, err := validate.RequiredToolParam[string](request, "postgresId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			filter, err := schemaFilterFromRequest(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			doc, err := describePostgres(ctx, postgresRepo, conns, postgresId, filter)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			respJSON, err := json.Marshal(doc)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("Error marshaling schema", err), nil
+			}
+
+			return mcp.NewToolResultText(string(respJSON)), nil
+		},
+	}
+}
+
+func schemaFilterFromRequest(request mcp.CallToolRequest) (schemaFilter, error) {
+	var filter schemaFilter
+
+	schemas, _, err := validate.OptionalToolArrayParam[string](request, "schemas")
+	if err != nil {
+		return filter, err
+	}
+	filter.Schemas = schemas
+
+	tables, _, err := validate.OptionalToolArrayParam[string](request, "tables")
+	if err != nil {
+		return filter, err
+	}
+	filter.Tables = tables
+
+	return filter, nil
+}
+
+// describePostgres describes the schema of a Postgres instance over a pooled
+// connection, inside a read-only transaction.
+func describePostgres(ctx context.Context, postgresRepo *Repo, conns *connPool[*pgx.Conn], postgresId string, filter schemaFilter) (*schemaDocument, error) {
+	conn, release, err := acquirePostgresConn(ctx, postgresRepo, conns, postgresId)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	var doc *schemaDocument
+	err = withReadOnlyTx(ctx, conn, defaultStatementTimeout, func(tx pgx.Tx) error {
+		doc, err = describeSchema(ctx, tx, filter)
+		return err
+	})
+	return doc, err
+}
+
+func diffPostgresSchemas(postgresRepo *Repo, conns *connPool[*pgx.Conn]) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("diff_postgres_schemas",
+			mcp.WithDescription("Compare the schemas of two Render-hosted Postgres databases, for example staging "+
+				"and production, and return a structured diff: tables and columns missing from either side, column "+
+				"type, nullability and default changes, and primary key, foreign key, check and unique constraint "+
+				"and index differences. "+
+				"The source database is the reference, so \"missing in target\" lists what the source has and the "+
+				"target lacks. Use this to check that migrations have run on both databases. Only read-only catalog "+
+				fmt.Sprintf("queries are run. At most %d tables per database are compared; narrow large databases "+
+					"with `schemas` or `tables`.", maxSchemaTables)),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           "Diff Postgres schemas",
+				ReadOnlyHint:    pointers.From(true),
+				DestructiveHint: pointers.From(false),
+				IdempotentHint:  pointers.From(true),
+				OpenWorldHint:   pointers.From(false),
+			}),
+			mcp.WithString("sourcePostgresId",
+				mcp.Required(),
+				mcp.Description("The ID of the reference Postgres instance, such as staging"),
+			),
+			mcp.WithString("targetPostgresId",
+				mcp.Required(),
+				mcp.Description("The ID of the Postgres instance to compare against the source, such as production"),
+			),
+			mcp.WithArray("schemas",
+				mcp.Description("Only compare these schemas. Defaults to every schema except the Postgres system schemas."),
+				mcp.Items(map[string]interface{}{"type": "string"}),
+			),
+			mcp.WithArray("tables",
+				mcp.Description("Only compare tables with these names"),
+				mcp.Items(map[string]interface{}{"type": "string"}),
+			),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			sourceId, err := validate.RequiredToolParam[string](request, "sourcePostgresId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			targetId, err := validate.RequiredToolParam[string](request, "targetPostgresId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			filter, err := schemaFilterFromRequest(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			// Fetching connection info doesn't check the workspace, so check
+			// that both instances are in the session's workspace first.
+			for _, id := range []string{sourceId, targetId} {
+				if _, err := postgresRepo.GetPostgresInWorkspace(ctx, id); err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+			}
+
+			source, err := describePostgres(ctx, postgresRepo, conns, sourceId, filter)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("error describing source %s: %s", sourceId, err.Error())), nil
+			}
+
+			target, err := describePostgres(ctx, postgresRepo, conns, targetId, filter)
+			if err != nil {
+				return mcp.NewToolResultError(fmt.Sprintf("error describing target %s: %s", targetId, err.Error())), nil
+			}
+
+			respJSON, err := json.Marshal(diffSchemas(source, target))
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("Error marshaling schema diff", err), nil
+			}
+
+			return mcp.NewToolResultText(string(respJSON)), nil
+		},
+	}
+}
+
+func profilePostgresTable(postgresRepo *Repo, conns *connPool[*pgx.Conn]) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("profile_postgres_table",
+			mcp.WithDescription("Profile a table in a Render-hosted Postgres database: its row count, and for each "+
+				"column the share of NULLs, the planner's estimate of distinct values from pg_stats, and the min and "+
+				"max for numeric, text, date/time and enum columns, plus a few sample rows. Use this to understand "+
+				"unfamiliar data before writing queries. Only read-only queries are run. Tables with more than "+
+				fmt.Sprintf("%d estimated rows are profiled from a sample of about %d rows, and at most %d columns "+
+					"are profiled.", profileExactRowLimit, profileSampleTarget, maxProfileColumns)),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           "Profile Postgres table",
+				ReadOnlyHint:    pointers.From(true),
+				DestructiveHint: pointers.From(false),
+				IdempotentHint:  pointers.From(true),
+				OpenWorldHint:   pointers.From(false),
+			}),
+			mcp.WithString("postgresId",
+				mcp.Required(),
+				mcp.Description("The ID of the Postgres instance containing the table"),
+			),
+			mcp.WithString("table",
+				mcp.Required(),
+				mcp.Description("The name of the table to profile"),
+			),
+			mcp.WithString("schema",
+				mcp.Description("The schema containing the table. Defaults to `public`."),
+				mcp.DefaultString("public"),
+			),
+			mcp.WithNumber("sampleRows",
+				mcp.Description(fmt.Sprintf("How many example rows to include. Defaults to %d.", defaultProfileSampleRows)),
+				mcp.Min(0),
+				mcp.Max(maxProfileSampleRows),
+				mcp.DefaultNumber(defaultProfileSampleRows),
+			),
+			mcp.WithNumber("timeoutSeconds",
+				mcp.Description(fmt.Sprintf("Statement timeout, in seconds. Defaults to %.0f.",
+					defaultStatementTimeout.Seconds())),
+				mcp.Min(1),
+				mcp.Max(maxStatementTimeout.Seconds()),
+				mcp.DefaultNumber(defaultStatementTimeout.Seconds()),
+			),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			postgresId, err := validate.RequiredToolParam[string](request, "postgresId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			table, err := validate.RequiredToolParam[string](request, "table")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			schema := "public"
+			if s, ok, err := validate.OptionalToolParam[string](request, "schema"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				schema = s
+			}
+
+			sampleRows := defaultProfileSampleRows
+			if n, ok, err := validate.OptionalToolParam[float64](request, "sampleRows"); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			} else if ok {
+				if n < 0 || n > maxProfileSampleRows {
+					return mcp.NewToolResultError(fmt.Sprintf("sampleRows must be between 0 and %d", maxProfileSampleRows)), nil
+				}
+				sampleRows = int(n)
+			}
+
+			limits, err := queryLimitsFromRequest(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			conn, release, err := acquirePostgresConn(ctx, postgresRepo, conns, postgresId)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			defer release()
+
+			var profile *tableProfile
+			err = withReadOnlyTx(ctx, conn, limits.StatementTimeout, func(tx pgx.Tx) error {
+				profile, err = profileTable(ctx, tx, conn.TypeMap(), schema, table, sampleRows)
+				return err
+			})
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			respJSON, err := json.Marshal(profile)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("Error marshaling profile", err), nil
+			}
+
+			return mcp.NewToolResultText(string(respJSON)), nil
+		},
+	}
+}
+
+func explainPostgresQuery(postgresRepo *Repo, conns *connPool[*pgx.Conn]) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("explain_postgres_query",
+			mcp.WithDescription("Show the query plan for a SQL query against a Render-hosted Postgres database, "+
+				"condensed into a tree with highlights: sequential scans on large tables, row estimates that are far "+
+				"off, and the costliest nodes. Use this to debug slow queries. By default the query is only planned, "+
+				"not run. With `analyze` the query is executed inside a read-only transaction to collect actual row "+
+				"counts and timings, so it takes as long as the query itself."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           "Explain Postgres query",
+				ReadOnlyHint:    pointers.From(true),
+				DestructiveHint: pointers.From(false),
+				IdempotentHint:  pointers.From(true),
+				OpenWorldHint:   pointers.From(false),
+			}),
+			mcp.WithString("postgresId",
+				mcp.Required(),
+				mcp.Description("The ID of the Postgres instance to run the query against"),
+			),
+			mcp.WithString("sql",
+				mcp.Required(),
+				mcp.Description("The SQL query to explain, without an EXPLAIN prefix"),
+			),
+			mcp.WithBoolean("analyze",
+				mcp.Description("Execute the query to report actual rows and timings (EXPLAIN ANALYZE). "+
+					"The query still runs in a read-only transaction. Defaults to false."),
+				mcp.DefaultBool(false),
+			),
+			mcp.WithNumber("timeoutSeconds",
+				mcp.Description(fmt.Sprintf("Statement timeout, in seconds. Defaults to %.0f.",
+					defaultStatementTimeout.Seconds())),
+				mcp.Min(1),
+				mcp.Max(maxStatementTimeout.Seconds()),
+				mcp.DefaultNumber(defaultStatementTimeout.Seconds()),
+			),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			postgresId, err := validate.RequiredToolParam[string](request, "postgresId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			sqlQuery, err := validate.RequiredToolParam[string](request, "sql")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			analyze, _, err := validate.OptionalToolParam[bool](request, "analyze")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			limits, err := queryLimitsFromRequest(request)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			conn, release, err := acquirePostgresConn(ctx, postgresRepo, conns, postgresId)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			defer release()
+
+			var summary *planSummary
+			err = withReadOnlyTx(ctx, conn, limits.StatementTimeout, func(tx pgx.Tx) error {
+				summary, err = explainQuery(ctx, tx, sqlQuery, analyze)
+				return err
+			})
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			respJSON, err := json.Marshal(summary)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("Error marshaling plan", err), nil
+			}
+
+			return mcp.NewToolResultText(string(respJSON)), nil
+		},
+	}
+}
+
+func diagnosePostgres(postgresRepo *Repo, conns *connPool[*pgx.Conn], metricsRepo *metrics.Repo) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("diagnose_postgres",
+			mcp.WithDescription("Run a health check on a Render-hosted Postgres database and return a prioritized "+
+				"list of findings, most severe first. Checks connection usage, sessions blocked on locks, "+
+				"long-running and idle-in-transaction sessions, table and index bloat estimates, the buffer cache hit "+
+				"ratio, the most expensive statements from pg_stat_statements when the extension is installed, and "+
+				"active connections over the last hour. Use this as a first step when a database is slow or "+
+				"misbehaving. Only read-only catalog queries are run."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           "Diagnose Postgres instance",
+				ReadOnlyHint:    pointers.From(true),
+				DestructiveHint: pointers.From(false),
+				IdempotentHint:  pointers.From(true),
+				OpenWorldHint:   pointers.From(false),
+			}),
+			mcp.WithString("postgresId",
+				mcp.Required(),
+				mcp.Description("The ID of the Postgres instance to diagnose"),
+			),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			postgresId, err := validate.RequiredToolParam[string](request, "postgresId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			conn, release, err := acquirePostgresConn(ctx, postgresRepo, conns, postgresId)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			defer release()
+
+			var report *diagnosisReport
+			err = withReadOnlyTx(ctx, conn, defaultStatementTimeout, func(tx pgx.Tx) error {
+				report = runDiagnostics(ctx, tx)
+				return nil
+			})
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			end := time.Now().UTC()
+			start := end.Add(-activeConnectionsWindow)
+			metricsResp, err := metricsRepo.GetMetrics(ctx, metrics.MetricsRequest{
+				ResourceID:  postgresId,
+				MetricTypes: []metrics.MetricType{metrics.MetricTypeActiveConnections},
+				StartTime:   &start,
+				EndTime:     &end,
+			})
+			if err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("active_connections: %s", err.Error()))
+			} else if len(metricsResp.Metrics) > 0 {
+				report.ActiveConnections = summarizeActiveConnections(start, end, metricsResp.Metrics[0].Data)
+				report.Findings = append(report.Findings, activeConnectionsFindings(report.ActiveConnections, report.maxConnections)...)
+			}
+			prioritizeFindings(report.Findings)
+
+			respJSON, err := json.Marshal(report)
+			if err != nil {
+				return mcp.NewToolResultErrorFromErr("Error marshaling diagnosis", err), nil
+			}
+
+			return mcp.NewToolResultText(string(respJSON)), nil
+		},
+	}
+}
+
+func restartPostgres(postgresRepo *Repo) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("restart_postgres",
+			mcp.WithDescription("Restart a Postgres instance. Open connections are dropped and the database is "+
+				"briefly unavailable while it restarts."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           "Restart Postgres instance",
+				ReadOnlyHint:    pointers.From(false),
+				DestructiveHint: pointers.From(true),
+				IdempotentHint:  pointers.From(false),
+				OpenWorldHint:   pointers.From(false),
+			}),
+			mcp.WithString("postgresId",
+				mcp.Required(),
+				mcp.Description("The ID of the Postgres instance to restart"),
+			),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			postgresId, err := validate.RequiredToolParam[string](request, "postgresId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if err := postgresRepo.RestartPostgresDatabase(ctx, postgresId); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("Restart requested for Postgres instance %s. "+
+				"Use get_postgres to check on its status.", postgresId)), nil
+		},
+	}
+}
+
+func suspendPostgres(postgresRepo *Repo) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("suspend_postgres",
+			mcp.WithDescription("Suspend a Postgres instance. A suspended database rejects all connections "+
+				"until it is resumed with resume_postgres. Its data is kept."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           "Suspend Postgres instance",
+				ReadOnlyHint:    pointers.From(false),
+				DestructiveHint: pointers.From(true),
+				IdempotentHint:  pointers.From(true),
+				OpenWorldHint:   pointers.From(false),
+			}),
+			mcp.WithString("postgresId",
+				mcp.Required(),
+				mcp.Description("The ID of the Postgres instance to suspend"),
+			),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			postgresId, err := validate.RequiredToolParam[string](request, "postgresId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if err := postgresRepo.SuspendPostgres(ctx, postgresId); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("Suspend requested for Postgres instance %s. "+
+				"Use get_postgres to check on its status.", postgresId)), nil
+		},
+	}
+}
+
+func resumePostgres(postgresRepo *Repo) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("resume_postgres",
+			mcp.WithDescription("Resume a suspended Postgres instance"),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           "Resume Postgres instance",
+				ReadOnlyHint:    pointers.From(false),
+				DestructiveHint: pointers.From(false),
+				IdempotentHint:  pointers.From(true),
+				OpenWorldHint:   pointers.From(false),
+			}),
+			mcp.WithString("postgresId",
+				mcp.Required(),
+				mcp.Description("The ID of the Postgres instance to resume"),
+			),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			postgresId, err := validate.RequiredToolParam[string](request, "postgresId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if err := postgresRepo.ResumePostgres(ctx, postgresId); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("Resume requested for Postgres instance %s. "+
+				"Use get_postgres to check on its status.", postgresId)), nil
+		},
+	}
+}
+
+func failoverPostgres(postgresRepo *Repo) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("failover_postgres",
+			mcp.WithDescription("Fail over a high availability Postgres instance to its standby. "+
+				"The primary and standby swap roles and open connections are dropped. "+
+				"The first call without `confirm` only describes the failover; confirm with the user, "+
+				"then call again with `confirm` set to true."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           "Fail over Postgres instance",
+				ReadOnlyHint:    pointers.From(false),
+				DestructiveHint: pointers.From(true),
+				IdempotentHint:  pointers.From(false),
+				OpenWorldHint:   pointers.From(false),
+			}),
+			mcp.WithString("postgresId",
+				mcp.Required(),
+				mcp.Description("The ID of the Postgres instance to fail over"),
+			),
+			mcp.WithBoolean("confirm",
+				mcp.Description("Set to true only after the user has confirmed the failover. Defaults to false."),
+				mcp.DefaultBool(false),
+			),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			postgresId, err := validate.RequiredToolParam[string](request, "postgresId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			confirm, _, err := validate.OptionalToolParam[bool](request, "confirm")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if !confirm {
+				postgres, err := postgresRepo.GetPostgresInWorkspace(ctx, postgresId)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				if !postgres.HighAvailabilityEnabled {
+					return mcp.NewToolResultError(fmt.Sprintf("Postgres instance %s does not have high "+
+						"availability enabled, so it has no standby to fail over to", postgresId)), nil
+				}
+				return mcp.NewToolResultText(fmt.Sprintf("Failing over Postgres instance %q (%s) promotes its "+
+					"standby to primary and drops all open connections. Nothing has been changed yet. "+
+					"Ask the user to confirm, then call failover_postgres again with `confirm` set to true.",
+					postgres.Name, postgresId)), nil
+			}
+
+			if err := postgresRepo.FailoverPostgres(ctx, postgresId); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("Failover requested for Postgres instance %s. "+
+				"Use get_postgres to check on its status.", postgresId)), nil
+		},
+	}
+}
+
+func deletePostgres(postgresRepo *Repo) server.ServerTool {
+	return server.ServerTool{
+		Tool: mcp.NewTool("delete_postgres",
+			mcp.WithDescription("Permanently delete a Postgres instance and all of its data. This cannot be undone. "+
+				"The first call without `confirm` only describes what will be deleted; confirm with the user, "+
+				"then call again with `confirm` set to true."),
+			mcp.WithToolAnnotation(mcp.ToolAnnotation{
+				Title:           "Delete Postgres instance",
+				ReadOnlyHint:    pointers.From(false),
+				DestructiveHint: pointers.From(true),
+				IdempotentHint:  pointers.From(true),
+				OpenWorldHint:   pointers.From(false),
+			}),
+			mcp.WithString("postgresId",
+				mcp.Required(),
+				mcp.Description("The ID of the Postgres instance to delete"),
+			),
+			mcp.WithBoolean("confirm",
+				mcp.Description("Set to true only after the user has confirmed the deletion. Defaults to false."),
+				mcp.DefaultBool(false),
+			),
+		),
+		Handler: func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			postgresId, err := validate.RequiredToolParam[string](request, "postgresId")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			confirm, _, err := validate.OptionalToolParam[bool](request, "confirm")
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			if !confirm {
+				postgres, err := postgresRepo.GetPostgresInWorkspace(ctx, postgresId)
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+				return mcp.NewToolResultText(fmt.Sprintf("Deleting Postgres instance %q (%s) permanently removes "+
+					"the database %q and its %d read replica(s). Nothing has been deleted yet. "+
+					"Ask the user to confirm, then call delete_postgres again with `confirm` set to true.",
+					postgres.Name, postgresId, postgres.DatabaseName, len(postgres.ReadReplicas))), nil
+			}
+
+			if err := postgresRepo.DeletePostgres(ctx, postgresId); err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+
+			return mcp.NewToolResultText(fmt.Sprintf("Postgres instance %s deleted.", postgresId)), nil
 		},
 	}
 }
@@ -93,6 +93,227 @@ func TestCreatePostgresTool(t *testing.T) {
 	}
 }
 
+func TestUpdatePostgresTool(t *testing.T) {
+	ownerId := "own-123456"
+	postgresId := "dpg-123456"
+
+	tests := []struct {
+		name           string
+		args           map[string]any
+		expectError    bool
+		expectedUpdate client.PostgresPATCHInput
+	}{
+		{
+			name: "Update plan",
+			args: map[string]any{"postgresId": postgresId, "plan": "pro_4gb"},
+			expectedUpdate: client.PostgresPATCHInput{
+				Plan: pointers.From(pgclient.Pro4gb),
+			},
+		},
+		{
+			name: "Grow disk and enable autoscaling",
+			args: map[string]any{"postgresId": postgresId, "diskSizeGb": float64(20), "enableDiskAutoscaling": true},
+			expectedUpdate: client.PostgresPATCHInput{
+				DiskSizeGB:            pointers.From(20),
+				EnableDiskAutoscaling: pointers.From(true),
+			},
+		},
+		{
+			name:        "Shrinking disk is rejected",
+			args:        map[string]any{"postgresId": postgresId, "diskSizeGb": float64(5)},
+			expectError: true,
+		},
+		{
+			name:        "Invalid disk size is rejected",
+			args:        map[string]any{"postgresId": postgresId, "diskSizeGb": float64(12)},
+			expectError: true,
+		},
+		{
+			name:        "Custom plan is rejected",
+			args:        map[string]any{"postgresId": postgresId, "plan": "custom"},
+			expectError: true,
+		},
+		{
+			name: "Add read replicas keeps existing ones",
+			args: map[string]any{"postgresId": postgresId, "readReplicaCount": float64(2)},
+			expectedUpdate: client.PostgresPATCHInput{
+				ReadReplicas: &client.ReadReplicasInput{
+					{Name: "existing-replica"},
+					{Name: "test-database-replica-1"},
+				},
+			},
+		},
+		{
+			name: "Remove all read replicas",
+			args: map[string]any{"postgresId": postgresId, "readReplicaCount": float64(0)},
+			expectedUpdate: client.PostgresPATCHInput{
+				ReadReplicas: &client.ReadReplicasInput{},
+			},
+		},
+		{
+			name:        "No changes is rejected",
+			args:        map[string]any{"postgresId": postgresId},
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fakes.FakePostgresRepoClient{}
+			repo := NewRepo(fakeClient)
+
+			fakeClient.RetrievePostgresWithResponseReturns(&client.RetrievePostgresResponse{
+				JSON200: &client.PostgresDetail{
+					Id:           postgresId,
+					Name:         "test-database",
+					Owner:        client.Owner{Id: ownerId},
+					Plan:         pgclient.Basic1gb,
+					DiskSizeGB:   pointers.From(15),
+					ReadReplicas: client.ReadReplicas{{Id: "dpg-replica", Name: "existing-replica"}},
+				},
+				HTTPResponse: &http.Response{
+					StatusCode: 200,
+				},
+			}, nil)
+			fakeClient.UpdatePostgresWithResponseReturns(&client.UpdatePostgresResponse{
+				JSON200: &client.PostgresDetail{Id: postgresId},
+				HTTPResponse: &http.Response{
+					StatusCode: 200,
+				},
+			}, nil)
+
+			ctx := createTestContext(t, ownerId)
+
+			request := mcp.CallToolRequest{}
+			request.Params.Arguments = tt.args
+
+			tool := updatePostgres(repo)
+			result, err := tool.Handler(ctx, request)
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			if tt.expectError {
+				assert.True(t, result.IsError)
+				assert.Equal(t, 0, fakeClient.UpdatePostgresWithResponseCallCount())
+				return
+			}
+
+			require.False(t, result.IsError, "expected no error but got: %v", result.Content)
+			require.Equal(t, 1, fakeClient.UpdatePostgresWithResponseCallCount())
+			_, calledId, body, _ := fakeClient.UpdatePostgresWithResponseArgsForCall(0)
+			assert.Equal(t, postgresId, calledId)
+			assert.Equal(t, tt.expectedUpdate, body)
+		})
+	}
+}
+
+func TestDeletePostgresTool(t *testing.T) {
+	ownerId := "own-123456"
+	postgresId := "dpg-123456"
+
+	tests := []struct {
+		name            string
+		args            map[string]any
+		workspace       string
+		expectError     bool
+		expectDeleteRun bool
+	}{
+		{
+			name:      "Delete without confirm only describes the deletion",
+			args:      map[string]any{"postgresId": postgresId},
+			workspace: ownerId,
+		},
+		{
+			name:            "Delete with confirm deletes the instance",
+			args:            map[string]any{"postgresId": postgresId, "confirm": true},
+			workspace:       ownerId,
+			expectDeleteRun: true,
+		},
+		{
+			name:        "Delete in another workspace is rejected",
+			args:        map[string]any{"postgresId": postgresId, "confirm": true},
+			workspace:   "own-other",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fakeClient := &fakes.FakePostgresRepoClient{}
+			repo := NewRepo(fakeClient)
+
+			fakeClient.RetrievePostgresWithResponseReturns(&client.RetrievePostgresResponse{
+				JSON200: &client.PostgresDetail{
+					Id:    postgresId,
+					Name:  "test-database",
+					Owner: client.Owner{Id: ownerId},
+				},
+				HTTPResponse: &http.Response{
+					StatusCode: 200,
+				},
+			}, nil)
+			fakeClient.DeletePostgresWithResponseReturns(&client.DeletePostgresResponse{
+				HTTPResponse: &http.Response{
+					StatusCode: 204,
+				},
+			}, nil)
+
+			ctx := createTestContext(t, tt.workspace)
+
+			request := mcp.CallToolRequest{}
+			request.Params.Arguments = tt.args
+
+			tool := deletePostgres(repo)
+			result, err := tool.Handler(ctx, request)
+
+			require.NoError(t, err)
+			require.NotNil(t, result)
+			assert.Equal(t, tt.expectError, result.IsError)
+			if tt.expectDeleteRun {
+				require.Equal(t, 1, fakeClient.DeletePostgresWithResponseCallCount())
+				_, calledId, _ := fakeClient.DeletePostgresWithResponseArgsForCall(0)
+				assert.Equal(t, postgresId, calledId)
+			} else {
+				assert.Equal(t, 0, fakeClient.DeletePostgresWithResponseCallCount())
+			}
+		})
+	}
+}
+
+func TestFailoverPostgresToolRequiresHighAvailability(t *testing.T) {
+	ownerId := "own-123456"
+	postgresId := "dpg-123456"
+
+	for _, confirm := range []bool{false, true} {
+		fakeClient := &fakes.FakePostgresRepoClient{}
+		repo := NewRepo(fakeClient)
+
+		fakeClient.RetrievePostgresWithResponseReturns(&client.RetrievePostgresResponse{
+			JSON200: &client.PostgresDetail{
+				Id:                      postgresId,
+				Owner:                   client.Owner{Id: ownerId},
+				HighAvailabilityEnabled: false,
+			},
+			HTTPResponse: &http.Response{
+				StatusCode: 200,
+			},
+		}, nil)
+
+		ctx := createTestContext(t, ownerId)
+
+		request := mcp.CallToolRequest{}
+		request.Params.Arguments = map[string]any{"postgresId": postgresId, "confirm": confirm}
+
+		tool := failoverPostgres(repo)
+		result, err := tool.Handler(ctx, request)
+
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		assert.True(t, result.IsError)
+		assert.Equal(t, 0, fakeClient.FailoverPostgresWithResponseCallCount())
+	}
+}
+
 func createTestContext(t *testing.T, workspaceID string) context.Context {
 	t.Helper()
 	t.Setenv("RENDER_CONFIG_PATH", filepath.Join(t.TempDir(), "mcp-server.yaml"))
@@ -2,7 +2,9 @@ package session_test
 
 import (
 	"context"
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/alicebob/miniredis/v2"
 	"github.com/mark3labs/mcp-go/mcp"
@@ -111,6 +113,73 @@ func TestHTTPSession(t *testing.T) {
 	}
 }
 
+func TestSessionValues(t *testing.T) {
+	s, err := miniredis.Run()
+	if err != nil {
+		t.Fatalf("failed to start miniredis: %v", err)
+	}
+	defer s.Close()
+
+	redisStore, err := session.NewRedisStore("redis://" + s.Addr())
+	if err != nil {
+		t.Fatalf("failed to initialize Redis session store: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		store       session.Store
+		fastForward func(time.Duration)
+	}{
+		{
+			name:        "in-memory",
+			store:       session.NewInMemoryStore(),
+			fastForward: time.Sleep,
+		},
+		{
+			name:        "redis",
+			store:       redisStore,
+			fastForward: s.FastForward,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			sessionOne, _ := tt.store.Get(ctx, "one")
+			sessionTwo, _ := tt.store.Get(ctx, "two")
+
+			if _, err := sessionOne.GetValue(ctx, "cursor"); !errors.Is(err, session.ErrValueNotFound) {
+				t.Errorf("Expected ErrValueNotFound, got %v", err)
+			}
+
+			if err := sessionOne.SetValue(ctx, "cursor", "state", time.Minute); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if val, err := sessionOne.GetValue(ctx, "cursor"); err != nil || val != "state" {
+				t.Errorf("Expected state, got %q (%v)", val, err)
+			}
+			if _, err := sessionTwo.GetValue(ctx, "cursor"); !errors.Is(err, session.ErrValueNotFound) {
+				t.Errorf("Expected values to be scoped to their session, got %v", err)
+			}
+
+			if err := sessionOne.DeleteValue(ctx, "cursor"); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			if _, err := sessionOne.GetValue(ctx, "cursor"); !errors.Is(err, session.ErrValueNotFound) {
+				t.Errorf("Expected ErrValueNotFound after delete, got %v", err)
+			}
+
+			if err := sessionOne.SetValue(ctx, "short", "state", 10*time.Millisecond); err != nil {
+				t.Fatalf("Expected no error, got %v", err)
+			}
+			tt.fastForward(20 * time.Millisecond)
+			if _, err := sessionOne.GetValue(ctx, "short"); !errors.Is(err, session.ErrValueNotFound) {
+				t.Errorf("Expected ErrValueNotFound after expiry, got %v", err)
+			}
+		})
+	}
+}
+
 type fakeSession struct {
 	sessionID           string
 	notificationChannel chan mcp.JSONRPCNotification
@@ -3,6 +3,7 @@ package session
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/render-oss/render-mcp-server/pkg/config"
 )
@@ -35,6 +36,7 @@ func (i *inMemoryStore) Get(_ context.Context, sessionID string) (Session, error
 type InMemorySession struct {
 	mu                  sync.RWMutex
 	selectedWorkspaceID string
+	values              valueMap
 }
 
 var _ Session = (*InMemorySession)(nil)
@@ -54,3 +56,17 @@ func (h *InMemorySession) SetWorkspace(_ context.Context, s string) error {
 	h.selectedWorkspaceID = s
 	return nil
 }
+
+func (h *InMemorySession) GetValue(_ context.Context, key string) (string, error) {
+	return h.values.get(key)
+}
+
+func (h *InMemorySession) SetValue(_ context.Context, key, value string, ttl time.Duration) error {
+	h.values.set(key, value, ttl)
+	return nil
+}
+
+func (h *InMemorySession) DeleteValue(_ context.Context, key string) error {
+	h.values.delete(key)
+	return nil
+}
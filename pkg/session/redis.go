@@ -3,6 +3,7 @@ package session
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/redis/go-redis/v9"
 	"github.com/render-oss/render-mcp-server/pkg/config"
@@ -58,3 +59,27 @@ func (r *RedisSession) SetWorkspace(ctx context.Context, s string) error {
 func (r *RedisSession) sessionKey() string {
 	return "session:" + r.sessionID
 }
+
+func (r *RedisSession) GetValue(ctx context.Context, key string) (string, error) {
+	val, err := r.c.Get(ctx, r.valueKey(key)).Result()
+	if errors.Is(err, redis.Nil) {
+		return "", ErrValueNotFound
+	} else if err != nil {
+		return "", err
+	}
+	return val, nil
+}
+
+func (r *RedisSession) SetValue(ctx context.Context, key, value string, ttl time.Duration) error {
+	return r.c.Set(ctx, r.valueKey(key), value, ttl).Err()
+}
+
+func (r *RedisSession) DeleteValue(ctx context.Context, key string) error {
+	return r.c.Del(ctx, r.valueKey(key)).Err()
+}
+
+// valueKey stores each value under its own key, rather than a field of the
+// session hash, so that it can expire independently.
+func (r *RedisSession) valueKey(key string) string {
+	return r.sessionKey() + ":value:" + key
+}
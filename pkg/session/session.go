@@ -3,8 +3,13 @@ package session
 import (
 	"context"
 	"errors"
+	"time"
 )
 
+// ErrValueNotFound is returned by Session.GetValue for keys that were never
+// set, were deleted or have expired.
+var ErrValueNotFound = errors.New("session value not found")
+
 type Store interface {
 	Get(ctx context.Context, sessionID string) (Session, error)
 }
@@ -12,6 +17,13 @@ type Store interface {
 type Session interface {
 	GetWorkspace(context.Context) (string, error)
 	SetWorkspace(context.Context, string) error
+
+	// GetValue, SetValue and DeleteValue hold arbitrary state for the session,
+	// such as continuation tokens handed to the client. Values expire after
+	// the ttl given when they were last set.
+	GetValue(ctx context.Context, key string) (string, error)
+	SetValue(ctx context.Context, key, value string, ttl time.Duration) error
+	DeleteValue(ctx context.Context, key string) error
 }
 
 func FromContext(ctx context.Context) Session {
@@ -44,3 +56,24 @@ func (r *requestSession) SetWorkspace(ctx context.Context, workspaceID string) e
 	}
 	return r.Session.SetWorkspace(ctx, workspaceID)
 }
+
+func (r *requestSession) GetValue(ctx context.Context, key string) (string, error) {
+	if r.Session == nil {
+		return "", ErrValueNotFound
+	}
+	return r.Session.GetValue(ctx, key)
+}
+
+func (r *requestSession) SetValue(ctx context.Context, key, value string, ttl time.Duration) error {
+	if r.Session == nil {
+		return errors.New("cannot persist values without a session")
+	}
+	return r.Session.SetValue(ctx, key, value, ttl)
+}
+
+func (r *requestSession) DeleteValue(ctx context.Context, key string) error {
+	if r.Session == nil {
+		return nil
+	}
+	return r.Session.DeleteValue(ctx, key)
+}
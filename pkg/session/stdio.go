@@ -2,6 +2,7 @@ package session
 
 import (
 	"context"
+	"time"
 
 	"github.com/render-oss/render-mcp-server/pkg/config"
 )
@@ -12,6 +13,10 @@ func ContextWithStdioSession(ctx context.Context) context.Context {
 
 type StdioSession struct{}
 
+// stdioValues holds session values for the stdio transport, which serves a
+// single client for the life of the process.
+var stdioValues valueMap
+
 var _ Session = (*StdioSession)(nil)
 
 func (h *StdioSession) GetWorkspace(_ context.Context) (string, error) {
@@ -21,3 +26,17 @@ func (h *StdioSession) GetWorkspace(_ context.Context) (string, error) {
 func (h *StdioSession) SetWorkspace(_ context.Context, s string) error {
 	return config.SelectWorkspace(s)
 }
+
+func (h *StdioSession) GetValue(_ context.Context, key string) (string, error) {
+	return stdioValues.get(key)
+}
+
+func (h *StdioSession) SetValue(_ context.Context, key, value string, ttl time.Duration) error {
+	stdioValues.set(key, value, ttl)
+	return nil
+}
+
+func (h *StdioSession) DeleteValue(_ context.Context, key string) error {
+	stdioValues.delete(key)
+	return nil
+}
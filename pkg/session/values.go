@@ -0,0 +1,59 @@
+package session
+
+import (
+	"sync"
+	"time"
+)
+
+// valueMap is an in-process store of expiring session values, safe for
+// concurrent access. The zero value is ready to use.
+type valueMap struct {
+	mu     sync.Mutex
+	values map[string]expiringValue
+}
+
+type expiringValue struct {
+	value     string
+	expiresAt time.Time
+}
+
+func (m *valueMap) get(key string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	v, ok := m.values[key]
+	if !ok {
+		return "", ErrValueNotFound
+	}
+	if time.Now().After(v.expiresAt) {
+		delete(m.values, key)
+		return "", ErrValueNotFound
+	}
+	return v.value, nil
+}
+
+func (m *valueMap) set(key, value string, ttl time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.values == nil {
+		m.values = map[string]expiringValue{}
+	}
+	m.removeExpiredLocked()
+	m.values[key] = expiringValue{value: value, expiresAt: time.Now().Add(ttl)}
+}
+
+func (m *valueMap) delete(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.values, key)
+}
+
+// removeExpiredLocked keeps values that are set but never read again from
+// accumulating.
+func (m *valueMap) removeExpiredLocked() {
+	now := time.Now()
+	for key, v := range m.values {
+		if now.After(v.expiresAt) {
+			delete(m.values, key)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package validate
 import (
 	"errors"
 	"fmt"
+	"net/netip"
 	"slices"
 
 	"github.com/mark3labs/mcp-go/mcp"
@@ -129,6 +130,66 @@ func EnvVars(request mcp.CallToolRequest) ([]envvar.EnvVarInput, bool, error) {
 	return envVars, true, nil
 }
 
+// IPAllowList reads an array of {cidrBlock, description} objects from param,
+// checking that each CIDR block is valid.
+func IPAllowList(request mcp.CallToolRequest, param string) ([]client.CidrBlockAndDescription, bool, error) {
+	raw, ok := request.GetArguments()[param]
+	if !ok {
+		return nil, false, nil
+	}
+
+	invalidErr := fmt.Errorf("parameter %s is not of expected type", param)
+	items, ok := raw.([]interface{})
+	if !ok {
+		return nil, false, invalidErr
+	}
+
+	entries := make([]client.CidrBlockAndDescription, 0, len(items))
+	for _, item := range items {
+		entryMap, ok := item.(map[string]interface{})
+		if !ok {
+			return nil, false, invalidErr
+		}
+
+		cidrBlock, ok := entryMap["cidrBlock"].(string)
+		if !ok {
+			return nil, false, invalidErr
+		}
+		if err := CIDRBlock(cidrBlock); err != nil {
+			return nil, false, err
+		}
+
+		var description string
+		if d, ok := entryMap["description"]; ok {
+			if description, ok = d.(string); !ok {
+				return nil, false, invalidErr
+			}
+		}
+
+		entries = append(entries, client.CidrBlockAndDescription{CidrBlock: cidrBlock, Description: description})
+	}
+
+	return entries, true, nil
+}
+
+// CIDRBlock checks that cidr is a CIDR block in canonical form, such as
+// 203.0.113.0/24. Addresses with host bits set are rejected rather than
+// silently masked, since they usually mean the prefix length is wrong.
+func CIDRBlock(cidr string) error {
+	prefix, err := netip.ParsePrefix(cidr)
+	if err != nil {
+		if addr, addrErr := netip.ParseAddr(cidr); addrErr == nil {
+			return fmt.Errorf("invalid CIDR block %q: a single address needs a prefix length, such as %s",
+				cidr, netip.PrefixFrom(addr, addr.BitLen()))
+		}
+		return fmt.Errorf("invalid CIDR block %q", cidr)
+	}
+	if masked := prefix.Masked(); masked != prefix {
+		return fmt.Errorf("invalid CIDR block %q: it has host bits set, did you mean %s?", cidr, masked)
+	}
+	return nil
+}
+
 func ServicePlan(plan string) (*client.PaidPlan, error) {
 	paidPlan := client.PaidPlan(plan)
 	if slices.Contains(mcpserver.ValidServicePlanValues, paidPlan) {
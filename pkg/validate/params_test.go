@@ -89,3 +89,27 @@ func TestPostgresPlan(t *testing.T) {
 		assert.Contains(t, err.Error(), "invalid Postgres plan")
 	})
 }
+
+func TestCIDRBlock(t *testing.T) {
+	for _, cidr := range []string{"0.0.0.0/0", "203.0.113.0/24", "198.51.100.7/32", "2001:db8::/32"} {
+		t.Run("valid/"+cidr, func(t *testing.T) {
+			require.NoError(t, validate.CIDRBlock(cidr))
+		})
+	}
+
+	t.Run("host bits set", func(t *testing.T) {
+		err := validate.CIDRBlock("203.0.113.7/24")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "did you mean 203.0.113.0/24")
+	})
+
+	t.Run("missing prefix length", func(t *testing.T) {
+		err := validate.CIDRBlock("198.51.100.7")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "such as 198.51.100.7/32")
+	})
+
+	t.Run("garbage", func(t *testing.T) {
+		require.Error(t, validate.CIDRBlock("office"))
+	})
+}
@@ -0,0 +1,178 @@
+package validate
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+)
+
+// TimeFormatsDescription lists the forms ParseTime accepts, for tool
+// parameter descriptions.
+const TimeFormatsDescription = "Accepts RFC3339 (e.g., '2024-01-01T12:00:00Z'), a Unix timestamp in seconds or milliseconds, " +
+	"or a time relative to now such as '15m', '2h ago', 'now-1d', 'today' or 'yesterday' (days start at midnight UTC)."
+
+// DefaultTimeRangeDuration is how far before endTime the range starts when
+// startTime is omitted.
+const DefaultTimeRangeDuration = time.Hour
+
+// TimeRange is the absolute range resolved from a tool's startTime and
+// endTime parameters. Tools echo it back so callers can see exactly which
+// range relative expressions and defaults resolved to.
+type TimeRange struct {
+	Start *time.Time `json:"start"`
+	End   *time.Time `json:"end"`
+}
+
+// TimeRangeParams reads the optional startTime and endTime parameters,
+// resolving relative expressions against now. endTime defaults to now and
+// startTime to DefaultTimeRangeDuration before endTime, so both ends are
+// always set.
+func TimeRangeParams(request mcp.CallToolRequest, now time.Time) (TimeRange, error) {
+	end, ok, err := OptionalTimeParam(request, "endTime", now)
+	if err != nil {
+		return TimeRange{}, err
+	} else if !ok {
+		end = now
+	}
+
+	start, ok, err := OptionalTimeParam(request, "startTime", now)
+	if err != nil {
+		return TimeRange{}, err
+	} else if !ok {
+		start = end.Add(-DefaultTimeRangeDuration)
+	}
+
+	timeRange := TimeRange{Start: &start, End: &end}
+	if !timeRange.Start.Before(*timeRange.End) {
+		return TimeRange{}, fmt.Errorf("startTime (%s) must be before endTime (%s)",
+			timeRange.Start.Format(time.RFC3339), timeRange.End.Format(time.RFC3339))
+	}
+
+	return timeRange, nil
+}
+
+// OptionalTimeParam reads an optional time parameter. See ParseTime for the
+// accepted forms.
+func OptionalTimeParam(request mcp.CallToolRequest, param string, now time.Time) (time.Time, bool, error) {
+	value, ok, err := OptionalToolParam[string](request, param)
+	if err != nil || !ok {
+		return time.Time{}, false, err
+	}
+
+	parsed, err := ParseTime(value, now)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid %s: %w", param, err)
+	}
+	return parsed, true, nil
+}
+
+var (
+	relativeToNow = regexp.MustCompile(`^now\s*([+-])\s*(.+)$`)
+	durationPart  = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([a-z]+)\s*`)
+	unixTimestamp = regexp.MustCompile(`^\d+$`)
+)
+
+// unixMillisThreshold separates Unix timestamps in seconds from ones in
+// milliseconds. Seconds don't reach it until the year 33658.
+const unixMillisThreshold = 1_000_000_000_000
+
+var durationUnits = map[string]time.Duration{
+	"s": time.Second, "sec": time.Second, "secs": time.Second, "second": time.Second, "seconds": time.Second,
+	"m": time.Minute, "min": time.Minute, "mins": time.Minute, "minute": time.Minute, "minutes": time.Minute,
+	"h": time.Hour, "hr": time.Hour, "hrs": time.Hour, "hour": time.Hour, "hours": time.Hour,
+	"d": 24 * time.Hour, "day": 24 * time.Hour, "days": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour, "week": 7 * 24 * time.Hour, "weeks": 7 * 24 * time.Hour,
+}
+
+// ParseTime resolves an absolute or relative time expression against now.
+// It accepts:
+//   - RFC3339 timestamps
+//   - Unix timestamps in seconds or milliseconds
+//   - "now", "today" and "yesterday", with days starting at midnight UTC
+//   - durations in the past, such as "15m", "2h ago" or "1h30m"
+//   - offsets from now, such as "now-1d" or "now+5m"
+func ParseTime(value string, now time.Time) (time.Time, error) {
+	expr := strings.ToLower(strings.TrimSpace(value))
+
+	if parsed, err := time.Parse(time.RFC3339, strings.TrimSpace(value)); err == nil {
+		return parsed, nil
+	}
+
+	if unixTimestamp.MatchString(expr) {
+		n, err := strconv.ParseInt(expr, 10, 64)
+		if err != nil {
+			return time.Time{}, invalidTimeError(value)
+		}
+		if n >= unixMillisThreshold {
+			return time.UnixMilli(n).UTC(), nil
+		}
+		return time.Unix(n, 0).UTC(), nil
+	}
+
+	switch expr {
+	case "now":
+		return now, nil
+	case "today":
+		return startOfDay(now), nil
+	case "yesterday":
+		return startOfDay(now).AddDate(0, 0, -1), nil
+	}
+
+	if match := relativeToNow.FindStringSubmatch(expr); match != nil {
+		offset, ok := parseDuration(match[2])
+		if !ok {
+			return time.Time{}, invalidTimeError(value)
+		}
+		if match[1] == "-" {
+			offset = -offset
+		}
+		return now.Add(offset), nil
+	}
+
+	if ago, ok := parseDuration(strings.TrimSuffix(expr, " ago")); ok {
+		return now.Add(-ago), nil
+	}
+
+	return time.Time{}, invalidTimeError(value)
+}
+
+// parseDuration parses one or more number and unit pairs, such as "2h",
+// "90 minutes" or "1d12h".
+func parseDuration(expr string) (time.Duration, bool) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return 0, false
+	}
+
+	var total time.Duration
+	for expr != "" {
+		match := durationPart.FindStringSubmatch(expr)
+		if match == nil {
+			return 0, false
+		}
+		unit, ok := durationUnits[match[2]]
+		if !ok {
+			return 0, false
+		}
+		amount, err := strconv.ParseFloat(match[1], 64)
+		if err != nil {
+			return 0, false
+		}
+		total += time.Duration(amount * float64(unit))
+		expr = expr[len(match[0]):]
+	}
+	return total, true
+}
+
+func startOfDay(t time.Time) time.Time {
+	year, month, day := t.UTC().Date()
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func invalidTimeError(value string) error {
+	return fmt.Errorf("%q is not a recognized time. %s", value, TimeFormatsDescription)
+}
@@ -0,0 +1,84 @@
+package validate_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/render-oss/render-mcp-server/pkg/validate"
+)
+
+func TestParseTime(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 30, 0, 0, time.UTC)
+
+	cases := []struct {
+		value    string
+		expected time.Time
+	}{
+		{"2026-03-09T08:00:00Z", time.Date(2026, 3, 9, 8, 0, 0, 0, time.UTC)},
+		{"2026-03-09T08:00:00-05:00", time.Date(2026, 3, 9, 13, 0, 0, 0, time.UTC)},
+		{"1773100800", time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)},
+		{"1773100800500", time.Date(2026, 3, 10, 0, 0, 0, 500_000_000, time.UTC)},
+		{"now", now},
+		{"today", time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)},
+		{"Yesterday", time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC)},
+		{"15m", now.Add(-15 * time.Minute)},
+		{"2h ago", now.Add(-2 * time.Hour)},
+		{"90 minutes ago", now.Add(-90 * time.Minute)},
+		{"1h30m", now.Add(-90 * time.Minute)},
+		{"1.5h", now.Add(-90 * time.Minute)},
+		{"now-1d", now.AddDate(0, 0, -1)},
+		{"now - 1w", now.AddDate(0, 0, -7)},
+		{"now+5m", now.Add(5 * time.Minute)},
+	}
+	for _, tc := range cases {
+		t.Run(tc.value, func(t *testing.T) {
+			got, err := validate.ParseTime(tc.value, now)
+			require.NoError(t, err)
+			assert.True(t, tc.expected.Equal(got), "expected %s, got %s", tc.expected, got)
+		})
+	}
+
+	for _, value := range []string{"", "last tuesday", "5 fortnights", "now-", "2026-03-09 08:00", "15m from now"} {
+		t.Run("invalid/"+value, func(t *testing.T) {
+			_, err := validate.ParseTime(value, now)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "is not a recognized time")
+		})
+	}
+}
+
+func TestTimeRangeParams(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 30, 0, 0, time.UTC)
+	request := func(args map[string]any) mcp.CallToolRequest {
+		return mcp.CallToolRequest{Params: mcp.CallToolParams{Arguments: args}}
+	}
+
+	timeRange, err := validate.TimeRangeParams(request(map[string]any{"startTime": "2h ago", "endTime": "now"}), now)
+	require.NoError(t, err)
+	require.NotNil(t, timeRange.Start)
+	require.NotNil(t, timeRange.End)
+	assert.Equal(t, now.Add(-2*time.Hour), *timeRange.Start)
+	assert.Equal(t, now, *timeRange.End)
+
+	timeRange, err = validate.TimeRangeParams(request(map[string]any{}), now)
+	require.NoError(t, err)
+	require.NotNil(t, timeRange.Start)
+	require.NotNil(t, timeRange.End)
+	assert.Equal(t, now.Add(-time.Hour), *timeRange.Start)
+	assert.Equal(t, now, *timeRange.End)
+
+	timeRange, err = validate.TimeRangeParams(request(map[string]any{"endTime": "yesterday"}), now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC), *timeRange.End)
+	assert.Equal(t, time.Date(2026, 3, 8, 23, 0, 0, 0, time.UTC), *timeRange.Start)
+
+	_, err = validate.TimeRangeParams(request(map[string]any{"startTime": "1h ago", "endTime": "2h ago"}), now)
+	assert.ErrorContains(t, err, "must be before endTime")
+
+	_, err = validate.TimeRangeParams(request(map[string]any{"endTime": "soon"}), now)
+	assert.ErrorContains(t, err, "invalid endTime")
+}